@@ -0,0 +1,165 @@
+package mwebd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/ltcmweb/ltcd/chaincfg"
+	"github.com/ltcmweb/ltcd/ltcutil"
+	"github.com/ltcmweb/ltcd/ltcutil/mweb"
+	"github.com/ltcmweb/ltcd/ltcutil/mweb/mw"
+	"github.com/ltcmweb/ltcd/txscript"
+	"github.com/ltcmweb/mwebd/proto"
+	"github.com/ltcmweb/mwebd/sign"
+)
+
+// defaultMaxAddressIndex is the number of address indices searched
+// by ValidateAddress when none is given, and maxAddressIndex is the
+// most that a request may ask for.
+const (
+	defaultMaxAddressIndex = 1000
+	maxAddressIndex        = 100 * defaultMaxAddressIndex
+)
+
+var addressNetworks = []*chaincfg.Params{
+	&chaincfg.MainNetParams,
+	&chaincfg.TestNet4Params,
+	&chaincfg.RegressionNetParams,
+	&chaincfg.SigNetParams,
+	&chaincfg.SimNetParams,
+}
+
+// decodeAddress decodes an address for the server's network, or
+// failing that, for any other known network.
+func (s *Server) decodeAddress(address string) (
+	ltcutil.Address, *chaincfg.Params, error) {
+
+	addr, err := ltcutil.DecodeAddress(address, &s.cp)
+	if err == nil && addr.IsForNet(&s.cp) {
+		return addr, &s.cp, nil
+	}
+	for _, cp := range addressNetworks {
+		addr, err2 := ltcutil.DecodeAddress(address, cp)
+		if err2 == nil && addr.IsForNet(cp) {
+			return addr, cp, nil
+		}
+	}
+	if err == nil {
+		err = errors.New("unknown address network")
+	}
+	return nil, nil, err
+}
+
+func (s *Server) ValidateAddress(ctx context.Context,
+	req *proto.ValidateAddressRequest) (*proto.ValidateAddressResponse, error) {
+
+	if req.MaxIndex > maxAddressIndex {
+		return nil, fmt.Errorf("max index is above %d", maxAddressIndex)
+	}
+
+	resp := &proto.ValidateAddressResponse{}
+	addr, cp, err := s.decodeAddress(req.Address)
+	if err != nil {
+		resp.Error = err.Error()
+		return resp, nil
+	}
+	resp.Network = cp.Name
+	resp.WrongNetwork = cp.Name != s.cp.Name
+
+	switch addr := addr.(type) {
+	case *ltcutil.AddressPubKeyHash:
+		resp.Type = proto.AddressType_ADDRESS_P2PKH
+	case *ltcutil.AddressScriptHash:
+		resp.Type = proto.AddressType_ADDRESS_P2SH
+	case *ltcutil.AddressWitnessPubKeyHash:
+		resp.Type = proto.AddressType_ADDRESS_P2WPKH
+		resp.WitnessVersion = uint32(addr.WitnessVersion())
+	case *ltcutil.AddressWitnessScriptHash:
+		resp.Type = proto.AddressType_ADDRESS_P2WSH
+		resp.WitnessVersion = uint32(addr.WitnessVersion())
+	case *ltcutil.AddressTaproot:
+		resp.Type = proto.AddressType_ADDRESS_P2TR
+		resp.WitnessVersion = uint32(addr.WitnessVersion())
+	case *ltcutil.AddressMweb:
+		resp.Type = proto.AddressType_ADDRESS_MWEB
+		resp.ScanPubkey = addr.StealthAddress().Scan[:]
+		resp.SpendPubkey = addr.StealthAddress().Spend[:]
+	default:
+		resp.Error = "not an address"
+		return resp, nil
+	}
+
+	if resp.PkScript, err = txscript.PayToAddrScript(addr); err != nil {
+		resp.Error = err.Error()
+		return resp, nil
+	}
+	if resp.WrongNetwork {
+		resp.Error = "address is for " + cp.Name
+		return resp, nil
+	}
+	resp.Valid = true
+
+	if mwebAddr, ok := addr.(*ltcutil.AddressMweb); ok {
+		kc, err := s.validateAddressKeychain(req)
+		if err != nil {
+			return nil, err
+		}
+		if kc != nil {
+			resp.IsMine, resp.Index = findAddressIndex(
+				kc, mwebAddr.StealthAddress(), req.MaxIndex)
+		}
+	}
+
+	return resp, nil
+}
+
+// validateAddressKeychain returns the account of a ValidateAddress
+// request, or nil if none was given. The spend pubkey may be nil.
+func (s *Server) validateAddressKeychain(
+	req *proto.ValidateAddressRequest) (*mweb.Keychain, error) {
+
+	switch {
+	case req.AccountDescriptor != "":
+		d, err := sign.ParseDescriptor(req.AccountDescriptor, &s.cp)
+		if err != nil {
+			return nil, err
+		}
+		return &mweb.Keychain{Scan: d.Scan, SpendPubKey: d.SpendPub}, nil
+	case req.ScanSecret == nil:
+		return nil, nil
+	case len(req.ScanSecret) != len(mw.SecretKey{}):
+		return nil, errors.New("invalid scan secret")
+	}
+	kc := &mweb.Keychain{Scan: (*mw.SecretKey)(req.ScanSecret)}
+	if req.SpendPubkey != nil {
+		if len(req.SpendPubkey) != len(mw.PublicKey{}) {
+			return nil, errors.New("invalid spend pubkey")
+		}
+		kc.SpendPubKey = (*mw.PublicKey)(req.SpendPubkey)
+	}
+	return kc, nil
+}
+
+// findAddressIndex reports whether the address belongs to the account
+// of the scan secret, and if the spend pubkey is known, searches for
+// the index of the address below maxIndex.
+func findAddressIndex(kc *mweb.Keychain, sa *mw.StealthAddress,
+	maxIndex uint32) (isMine bool, index *uint32) {
+
+	if *sa.Spend.Mul(kc.Scan) != *sa.Scan {
+		return false, nil
+	}
+	if kc.SpendPubKey == nil {
+		return true, nil
+	}
+	if maxIndex == 0 {
+		maxIndex = defaultMaxAddressIndex
+	}
+	for i := range maxIndex {
+		if kc.Address(i).Equal(sa) {
+			return true, &i
+		}
+	}
+	return true, nil
+}
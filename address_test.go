@@ -0,0 +1,120 @@
+package mwebd
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ltcmweb/ltcd/chaincfg"
+	"github.com/ltcmweb/ltcd/ltcutil"
+	"github.com/ltcmweb/ltcd/ltcutil/mweb/mw"
+	"github.com/ltcmweb/mwebd/proto"
+	"github.com/ltcmweb/mwebd/sign"
+)
+
+func TestValidateAddress(t *testing.T) {
+	s := NewBareServer(chaincfg.MainNetParams)
+	validate := func(req *proto.ValidateAddressRequest) *proto.ValidateAddressResponse {
+		resp, err := s.ValidateAddress(context.Background(), req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return resp
+	}
+
+	hash := make([]byte, 20)
+	for _, test := range []struct {
+		addr  ltcutil.Address
+		cp    *chaincfg.Params
+		typ   proto.AddressType
+		valid bool
+	}{
+		{mustAddr(ltcutil.NewAddressPubKeyHash(hash, &chaincfg.MainNetParams)),
+			&chaincfg.MainNetParams, proto.AddressType_ADDRESS_P2PKH, true},
+		{mustAddr(ltcutil.NewAddressScriptHashFromHash(hash, &chaincfg.MainNetParams)),
+			&chaincfg.MainNetParams, proto.AddressType_ADDRESS_P2SH, true},
+		{mustAddr(ltcutil.NewAddressWitnessPubKeyHash(hash, &chaincfg.MainNetParams)),
+			&chaincfg.MainNetParams, proto.AddressType_ADDRESS_P2WPKH, true},
+		{mustAddr(ltcutil.NewAddressTaproot(make([]byte, 32), &chaincfg.MainNetParams)),
+			&chaincfg.MainNetParams, proto.AddressType_ADDRESS_P2TR, true},
+		{mustAddr(ltcutil.NewAddressPubKeyHash(hash, &chaincfg.TestNet4Params)),
+			&chaincfg.TestNet4Params, proto.AddressType_ADDRESS_P2PKH, false},
+		{mustAddr(ltcutil.NewAddressWitnessScriptHash(make([]byte, 32), &chaincfg.TestNet4Params)),
+			&chaincfg.TestNet4Params, proto.AddressType_ADDRESS_P2WSH, false},
+		{ltcutil.NewAddressMweb(randKeychain().Address(0), &chaincfg.TestNet4Params),
+			&chaincfg.TestNet4Params, proto.AddressType_ADDRESS_MWEB, false},
+	} {
+		resp := validate(&proto.ValidateAddressRequest{Address: test.addr.String()})
+		if resp.Valid != test.valid || resp.Type != test.typ ||
+			resp.Network != test.cp.Name || resp.WrongNetwork == test.valid ||
+			len(resp.PkScript) == 0 {
+			t.Fatal("unexpected response for", test.addr, resp)
+		}
+	}
+
+	resp := validate(&proto.ValidateAddressRequest{Address: "ltc1invalid"})
+	if resp.Valid || resp.Error == "" {
+		t.Fatal("expected invalid address")
+	}
+
+	kc := randKeychain()
+	addr := ltcutil.NewAddressMweb(kc.Address(5), &s.cp).String()
+	resp = validate(&proto.ValidateAddressRequest{
+		Address:     addr,
+		ScanSecret:  kc.Scan[:],
+		SpendPubkey: kc.Spend.PubKey()[:],
+	})
+	if !resp.Valid || resp.Type != proto.AddressType_ADDRESS_MWEB ||
+		!resp.IsMine || resp.Index == nil || *resp.Index != 5 ||
+		mw.PublicKey(resp.SpendPubkey) != *kc.Address(5).Spend {
+		t.Fatal("unexpected response for own address", resp)
+	}
+
+	resp = validate(&proto.ValidateAddressRequest{
+		Address:     addr,
+		ScanSecret:  kc.Scan[:],
+		SpendPubkey: kc.Spend.PubKey()[:],
+		MaxIndex:    5,
+	})
+	if !resp.IsMine || resp.Index != nil {
+		t.Fatal("index shouldn't be found below max index")
+	}
+
+	_, err := s.ValidateAddress(context.Background(), &proto.ValidateAddressRequest{
+		Address:     addr,
+		ScanSecret:  kc.Scan[:],
+		SpendPubkey: kc.Spend.PubKey()[:],
+		MaxIndex:    maxAddressIndex + 1,
+	})
+	if err == nil {
+		t.Fatal("expected error for max index above the cap")
+	}
+
+	d := &sign.Descriptor{Scan: kc.Scan, SpendPub: kc.Spend.PubKey()}
+	resp = validate(&proto.ValidateAddressRequest{
+		Address: addr, AccountDescriptor: d.String(),
+	})
+	if !resp.IsMine || resp.Index == nil || *resp.Index != 5 {
+		t.Fatal("unexpected response for descriptor", resp)
+	}
+
+	resp = validate(&proto.ValidateAddressRequest{
+		Address: addr, ScanSecret: kc.Scan[:],
+	})
+	if !resp.IsMine || resp.Index != nil {
+		t.Fatal("unexpected response for scan secret only", resp)
+	}
+
+	resp = validate(&proto.ValidateAddressRequest{
+		Address: addr, ScanSecret: randKeychain().Scan[:],
+	})
+	if resp.IsMine {
+		t.Fatal("address shouldn't belong to another account")
+	}
+}
+
+func mustAddr(addr ltcutil.Address, err error) ltcutil.Address {
+	if err != nil {
+		panic(err)
+	}
+	return addr
+}
@@ -0,0 +1,381 @@
+package mwebd
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	mathrand "math/rand/v2"
+	"time"
+
+	"github.com/ltcmweb/ltcd/chaincfg/chainhash"
+	"github.com/ltcmweb/ltcd/ltcutil/mweb"
+	"github.com/ltcmweb/ltcd/ltcutil/mweb/mw"
+	"github.com/ltcmweb/ltcd/wire"
+	"github.com/ltcmweb/mwebd/proto"
+	"github.com/ltcmweb/mwebd/sign"
+)
+
+const (
+	// defaultAutoMixRounds is the number of daily swap rounds that
+	// an account's swaps are spread over when none is given, and
+	// maxAutoMixRounds is the most that may be given.
+	defaultAutoMixRounds = 7
+	maxAutoMixRounds     = 30
+
+	// autoMixAddressIndices is the number of address indices whose
+	// utxos are auto-mixed. Utxos of later addresses are left alone.
+	autoMixAddressIndices = 1000
+)
+
+// autoMixAccount is an account registered for auto-mixing. Each of
+// its eligible utxos is scheduled to be swapped at a random time, so
+// that the swaps of the account can't be linked by their timing.
+//
+// Accounts are only kept in memory, as the spend secret is needed to
+// submit the swaps, so they're lost when the daemon restarts. New
+// utxos are only scheduled until the registration expires after its
+// rounds, and the account and its secrets are dropped once the swaps
+// scheduled by then are submitted. The client must register again to
+// keep mixing. The account holds its own copy of the secrets, which
+// is zeroed when it's dropped.
+type autoMixAccount struct {
+	req       *proto.CoinswapAutoMixRequest
+	keychain  *mweb.Keychain
+	addrIndex map[mw.PublicKey]uint32
+	scheduled map[chainhash.Hash]*autoMixUtxo
+	expires   time.Time
+}
+
+type autoMixUtxo struct {
+	addrIndex uint32
+	value     uint64
+	due       time.Time
+	err       string
+}
+
+func newAutoMixAccount(req *proto.CoinswapAutoMixRequest,
+	now time.Time) (*autoMixAccount, error) {
+
+	if len(req.ScanSecret) != len(mw.SecretKey{}) {
+		return nil, errors.New("invalid scan secret")
+	}
+	if len(req.SpendSecret) != len(mw.SecretKey{}) {
+		return nil, errors.New("invalid spend secret")
+	}
+	switch {
+	case req.Rounds == 0:
+		req.Rounds = defaultAutoMixRounds
+	case req.Rounds > maxAutoMixRounds:
+		return nil, fmt.Errorf("rounds is above %d", maxAutoMixRounds)
+	}
+	scan, spend := mw.SecretKey(req.ScanSecret), mw.SecretKey(req.SpendSecret)
+	a := &autoMixAccount{
+		req: &proto.CoinswapAutoMixRequest{
+			MinValue: req.MinValue,
+			Rounds:   req.Rounds,
+			MaxFee:   req.MaxFee,
+			MinHops:  req.MinHops,
+		},
+		keychain:  &mweb.Keychain{Scan: &scan, Spend: &spend},
+		addrIndex: map[mw.PublicKey]uint32{},
+		scheduled: map[chainhash.Hash]*autoMixUtxo{},
+		expires:   now.Add(time.Duration(req.Rounds) * 24 * time.Hour),
+	}
+	for i := range uint32(autoMixAddressIndices) {
+		a.addrIndex[*a.keychain.Address(i).Spend] = i
+	}
+	return a, nil
+}
+
+// add schedules a utxo of the account to be swapped at a random time
+// within the account's rounds, if it's eligible and the registration
+// hasn't expired.
+func (a *autoMixAccount) add(outputId chainhash.Hash,
+	coin *mweb.Coin, now time.Time) bool {
+
+	if !now.Before(a.expires) ||
+		coin.Value < a.req.MinValue || a.scheduled[outputId] != nil {
+		return false
+	}
+	addrIndex, ok := a.addrIndex[*coin.Address.Spend]
+	if !ok {
+		return false
+	}
+	a.scheduled[outputId] = &autoMixUtxo{
+		addrIndex: addrIndex,
+		value:     coin.Value,
+		due: now.Add(mathrand.N(
+			time.Duration(a.req.Rounds) * 24 * time.Hour)),
+	}
+	return true
+}
+
+// due returns the utxos whose time to be swapped has come.
+func (a *autoMixAccount) due(now time.Time) (outputIds []chainhash.Hash) {
+	for outputId, u := range a.scheduled {
+		if !now.Before(u.due) {
+			outputIds = append(outputIds, outputId)
+		}
+	}
+	return
+}
+
+// done reports whether the registration has expired and all of its
+// scheduled swaps have been submitted.
+func (a *autoMixAccount) done(now time.Time) bool {
+	return !now.Before(a.expires) && len(a.scheduled) == 0
+}
+
+// retry reschedules a utxo whose submission failed to a random time
+// in the next round.
+func (a *autoMixAccount) retry(outputId chainhash.Hash, err error, now time.Time) {
+	u := a.scheduled[outputId]
+	u.err = err.Error()
+	u.due = now.UTC().Truncate(24 * time.Hour).
+		Add(24*time.Hour + mathrand.N(24*time.Hour))
+}
+
+// coinswapRequest returns the request for swapping a scheduled utxo.
+// It has its own copy of the secrets, so that it can be submitted
+// without holding autoMixMtx, and the caller must clear it after.
+func (a *autoMixAccount) coinswapRequest(outputId chainhash.Hash) *proto.CoinswapRequest {
+	return &proto.CoinswapRequest{
+		ScanSecret:  bytes.Clone(a.keychain.Scan[:]),
+		SpendSecret: bytes.Clone(a.keychain.Spend[:]),
+		OutputId:    hex.EncodeToString(outputId[:]),
+		AddrIndex:   a.scheduled[outputId].addrIndex,
+		MaxFee:      a.req.MaxFee,
+		MinHops:     a.req.MinHops,
+	}
+}
+
+// zero zeroes the account's secrets once it's disabled or replaced.
+func (a *autoMixAccount) zero() {
+	sign.Zero(a.keychain.Scan, a.keychain.Spend)
+}
+
+func (a *autoMixAccount) response() *proto.CoinswapAutoMixResponse {
+	resp := &proto.CoinswapAutoMixResponse{ExpiresTime: a.expires.Unix()}
+	for outputId, u := range a.scheduled {
+		resp.Scheduled = append(resp.Scheduled, &proto.CoinswapScheduled{
+			OutputId:      hex.EncodeToString(outputId[:]),
+			Value:         u.value,
+			ScheduledTime: u.due.Unix(),
+			Error:         u.err,
+		})
+	}
+	return resp
+}
+
+// coinswapOutputs returns the inputs and outputs of the swaps
+// submitted through the daemon, which aren't swapped again.
+func (s *Server) coinswapOutputs() (map[chainhash.Hash]bool, error) {
+	records, err := s.getCoinswaps(nil)
+	if err != nil {
+		return nil, err
+	}
+	outputs := map[chainhash.Hash]bool{}
+	add := func(outputId string) {
+		if b, err := hex.DecodeString(outputId); err == nil &&
+			len(b) == chainhash.HashSize {
+			outputs[chainhash.Hash(b)] = true
+		}
+	}
+	for _, r := range records {
+		add(r.InputId)
+		for _, a := range r.Attempts {
+			add(a.OutputId)
+		}
+	}
+	return outputs, nil
+}
+
+// addAutoMixUtxos schedules the confirmed utxos that belong to the
+// account and haven't been swapped already.
+func (s *Server) addAutoMixUtxos(a *autoMixAccount,
+	utxos []*wire.MwebNetUtxo, swapped map[chainhash.Hash]bool) {
+
+	for _, utxo := range utxos {
+		if utxo.Height == 0 || swapped[*utxo.OutputId] {
+			continue
+		}
+		coin, err := s.rewindOutput(utxo.Output, a.keychain.Scan)
+		if err == nil {
+			a.add(*utxo.OutputId, coin, time.Now())
+		}
+	}
+}
+
+func (s *Server) CoinswapAutoMix(ctx context.Context,
+	req *proto.CoinswapAutoMixRequest) (*proto.CoinswapAutoMixResponse, error) {
+
+	defer clear(req.SpendSecret)
+
+	s.autoMixMtx.Lock()
+	defer s.autoMixMtx.Unlock()
+
+	if req.Disable {
+		if len(req.ScanSecret) == len(mw.SecretKey{}) {
+			key := s.coinCache.key((*mw.SecretKey)(req.ScanSecret))
+			if a := s.autoMix[key]; a != nil {
+				delete(s.autoMix, key)
+				a.zero()
+			}
+		}
+		return &proto.CoinswapAutoMixResponse{}, nil
+	}
+
+	a, err := newAutoMixAccount(req, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	swapped, err := s.coinswapOutputs()
+	if err != nil {
+		return nil, err
+	}
+	lfs, err := s.cs.MwebCoinDB.GetLeafset()
+	if err != nil {
+		return nil, err
+	}
+	err = s.fetchLeaves(lfs, 0, func(utxos []*wire.MwebNetUtxo) error {
+		s.addAutoMixUtxos(a, utxos, swapped)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	key := s.coinCache.key(a.keychain.Scan)
+	if old := s.autoMix[key]; old != nil {
+		for outputId, u := range old.scheduled {
+			if a.scheduled[outputId] != nil {
+				a.scheduled[outputId] = u
+			}
+		}
+		old.zero()
+	}
+	if s.autoMix == nil {
+		s.autoMix = map[chainhash.Hash]*autoMixAccount{}
+	}
+	s.autoMix[key] = a
+	return a.response(), nil
+}
+
+// queueAutoMix queues changed utxos for the auto-mix worker to
+// schedule, and wakes it. It's called whenever the utxo set changes.
+func (s *Server) queueAutoMix(utxos []*wire.MwebNetUtxo) {
+	s.autoMixMtx.Lock()
+	if len(s.autoMix) > 0 {
+		s.autoMixUtxos = append(s.autoMixUtxos, utxos...)
+	}
+	s.autoMixMtx.Unlock()
+
+	select {
+	case s.autoMixWake <- struct{}{}:
+	default:
+	}
+}
+
+// watchAutoMix runs the auto-mix each time it's woken by
+// queueAutoMix, and every coinswapCheckInterval so that swaps are
+// submitted when due. Being the only caller of runAutoMix, it keeps
+// a due swap from being submitted twice.
+func (s *Server) watchAutoMix() {
+	ticker := time.NewTicker(coinswapCheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case _, ok := <-s.autoMixWake:
+			if !ok {
+				return
+			}
+		case <-ticker.C:
+		}
+		s.runAutoMix()
+	}
+}
+
+// autoMixSwap is a due swap of a registered account.
+type autoMixSwap struct {
+	key      chainhash.Hash
+	outputId chainhash.Hash
+	req      *proto.CoinswapRequest
+	err      error
+}
+
+// runAutoMix schedules the queued utxos of the registered accounts,
+// and submits the swaps that are due. The lock isn't held while
+// talking to the nodes, so the results are applied to whichever
+// registration the account has by then.
+func (s *Server) runAutoMix() {
+	s.autoMixMtx.Lock()
+	swaps, err := s.dueAutoMix(time.Now())
+	s.autoMixMtx.Unlock()
+	if err != nil || len(swaps) == 0 {
+		return
+	}
+
+	ctx := context.Background()
+	nodes, err := s.coinswapNodes(ctx)
+	for _, swap := range swaps {
+		swap.err = err
+		if err == nil {
+			_, swap.err = s.coinswap(ctx, swap.req, nodes, time.Time{})
+		}
+		clear(swap.req.ScanSecret)
+		clear(swap.req.SpendSecret)
+	}
+
+	s.autoMixMtx.Lock()
+	defer s.autoMixMtx.Unlock()
+	now := time.Now()
+	for _, swap := range swaps {
+		a := s.autoMix[swap.key]
+		switch {
+		case a == nil || a.scheduled[swap.outputId] == nil:
+			// The account was disabled meanwhile.
+		case swap.err != nil:
+			a.retry(swap.outputId, swap.err, now)
+		default:
+			delete(a.scheduled, swap.outputId)
+		}
+	}
+	for key, a := range s.autoMix {
+		if a.done(now) {
+			delete(s.autoMix, key)
+			a.zero()
+		}
+	}
+}
+
+// dueAutoMix schedules the queued utxos, and returns the swaps that
+// are due. It's called with autoMixMtx held.
+func (s *Server) dueAutoMix(now time.Time) (swaps []*autoMixSwap, err error) {
+	if len(s.autoMix) == 0 {
+		s.autoMixUtxos = nil
+		return
+	}
+	swapped, err := s.coinswapOutputs()
+	if err != nil {
+		return
+	}
+	utxos := s.autoMixUtxos
+	s.autoMixUtxos = nil
+	for key, a := range s.autoMix {
+		s.addAutoMixUtxos(a, utxos, swapped)
+		for _, outputId := range a.due(now) {
+			if !s.cs.MwebUtxoExists(&outputId) {
+				delete(a.scheduled, outputId)
+				continue
+			}
+			swaps = append(swaps, &autoMixSwap{
+				key:      key,
+				outputId: outputId,
+				req:      a.coinswapRequest(outputId),
+			})
+		}
+	}
+	return
+}
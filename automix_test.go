@@ -0,0 +1,163 @@
+package mwebd
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ltcmweb/ltcd/chaincfg/chainhash"
+	"github.com/ltcmweb/ltcd/ltcutil/mweb"
+	"github.com/ltcmweb/ltcd/ltcutil/mweb/mw"
+	"github.com/ltcmweb/ltcd/wire"
+	"github.com/ltcmweb/mwebd/proto"
+)
+
+func TestAutoMixSchedule(t *testing.T) {
+	kc := randKeychain()
+	now := time.Date(2025, 3, 4, 12, 0, 0, 0, time.UTC)
+	a, err := newAutoMixAccount(&proto.CoinswapAutoMixRequest{
+		ScanSecret:  kc.Scan[:],
+		SpendSecret: kc.Spend[:],
+		MinValue:    50_000,
+		MaxFee:      10_000,
+	}, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if a.req.Rounds != defaultAutoMixRounds ||
+		!a.expires.Equal(now.Add(defaultAutoMixRounds*24*time.Hour)) {
+		t.Fatal("expected default rounds")
+	}
+	if a.add(chainhash.Hash{1}, &mweb.Coin{
+		Value: 49_999, Address: kc.Address(3)}, now) {
+		t.Fatal("utxo below min value shouldn't be scheduled")
+	}
+	if a.add(chainhash.Hash{2}, &mweb.Coin{
+		Value: 50_000, Address: randKeychain().Address(3)}, now) {
+		t.Fatal("utxo of unknown address shouldn't be scheduled")
+	}
+	if !a.add(chainhash.Hash{3}, &mweb.Coin{
+		Value: 50_000, Address: kc.Address(3)}, now) {
+		t.Fatal("utxo should be scheduled")
+	}
+	u := a.scheduled[chainhash.Hash{3}]
+	if u.addrIndex != 3 || u.due.Before(now) ||
+		!u.due.Before(now.Add(defaultAutoMixRounds*24*time.Hour)) {
+		t.Fatal("unexpected schedule", u)
+	}
+	if a.add(chainhash.Hash{3}, &mweb.Coin{
+		Value: 50_000, Address: kc.Address(3)}, now) {
+		t.Fatal("utxo shouldn't be scheduled twice")
+	}
+	if a.add(chainhash.Hash{4}, &mweb.Coin{
+		Value: 50_000, Address: kc.Address(autoMixAddressIndices)}, now) {
+		t.Fatal("utxo beyond the address indices shouldn't be scheduled")
+	}
+	if a.add(chainhash.Hash{5}, &mweb.Coin{
+		Value: 50_000, Address: kc.Address(4)}, a.expires) {
+		t.Fatal("utxo shouldn't be scheduled after expiry")
+	}
+
+	if len(a.due(u.due.Add(-time.Second))) != 0 {
+		t.Fatal("utxo shouldn't be due yet")
+	}
+	due := a.due(u.due)
+	if len(due) != 1 || due[0] != (chainhash.Hash{3}) {
+		t.Fatal("utxo should be due", due)
+	}
+
+	req := a.coinswapRequest(due[0])
+	if req.OutputId != hex.EncodeToString(due[0][:]) || req.AddrIndex != 3 ||
+		req.MaxFee != 10_000 || string(req.SpendSecret) != string(kc.Spend[:]) {
+		t.Fatal("unexpected coinswap request", req)
+	}
+
+	a.retry(due[0], errors.New("no alive nodes"), now)
+	nextRound := time.Date(2025, 3, 5, 0, 0, 0, 0, time.UTC)
+	if u.err == "" || u.due.Before(nextRound) ||
+		!u.due.Before(nextRound.Add(24*time.Hour)) {
+		t.Fatal("retry should be in the next round", u.due)
+	}
+
+	resp := a.response()
+	if resp.ExpiresTime != a.expires.Unix() ||
+		len(resp.Scheduled) != 1 || resp.Scheduled[0].Value != 50_000 ||
+		resp.Scheduled[0].ScheduledTime != u.due.Unix() ||
+		resp.Scheduled[0].Error != u.err {
+		t.Fatal("unexpected response", resp)
+	}
+
+	if a.done(a.expires) {
+		t.Fatal("account with scheduled swaps shouldn't be done")
+	}
+	delete(a.scheduled, due[0])
+	if a.done(a.expires.Add(-time.Second)) || !a.done(a.expires) {
+		t.Fatal("account should be done once expired")
+	}
+
+	a.zero()
+	if *a.keychain.Spend != (mw.SecretKey{}) || *kc.Spend == (mw.SecretKey{}) ||
+		*kc.Scan == (mw.SecretKey{}) {
+		t.Fatal("zero should only clear the account's copy of the secrets")
+	}
+
+	if _, err = newAutoMixAccount(&proto.CoinswapAutoMixRequest{
+		ScanSecret: kc.Scan[:],
+	}, now); err == nil {
+		t.Fatal("expected missing spend secret error")
+	}
+	if _, err = newAutoMixAccount(&proto.CoinswapAutoMixRequest{
+		ScanSecret:  kc.Scan[:],
+		SpendSecret: kc.Spend[:],
+		Rounds:      maxAutoMixRounds + 1,
+	}, now); err == nil {
+		t.Fatal("expected too many rounds error")
+	}
+}
+
+func TestAutoMixRegistration(t *testing.T) {
+	s := newTestServer(t)
+	kc := randKeychain()
+	register := func(disable bool) {
+		_, err := s.CoinswapAutoMix(context.Background(), &proto.CoinswapAutoMixRequest{
+			ScanSecret:  bytes.Clone(kc.Scan[:]),
+			SpendSecret: bytes.Clone(kc.Spend[:]),
+			Disable:     disable,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Without a wake channel, queueing doesn't block.
+	utxos := []*wire.MwebNetUtxo{{OutputId: &chainhash.Hash{1}}}
+	s.queueAutoMix(utxos)
+	if len(s.autoMixUtxos) != 0 {
+		t.Fatal("utxos shouldn't be queued without accounts")
+	}
+
+	register(false)
+	if len(s.autoMix) != 1 {
+		t.Fatal("expected registered account")
+	}
+	s.queueAutoMix(utxos)
+	if len(s.autoMixUtxos) != 1 {
+		t.Fatal("expected queued utxo")
+	}
+	s.runAutoMix()
+	if len(s.autoMixUtxos) != 0 {
+		t.Fatal("queued utxos should be scheduled")
+	}
+	for key := range s.autoMix {
+		if key == chainhash.Hash(*kc.Scan) || key != s.coinCache.key(kc.Scan) {
+			t.Fatal("account should be keyed by the salted hash")
+		}
+	}
+	register(true)
+	if len(s.autoMix) != 0 {
+		t.Fatal("expected account to be removed")
+	}
+}
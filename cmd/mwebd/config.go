@@ -0,0 +1,200 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ltcmweb/mwebd"
+)
+
+// configFileName is the name of the config file in the data directory.
+// It has a "name = value" line for each option, named as its flag.
+// Blank lines and lines starting with '#' or ';' are ignored.
+const configFileName = "mwebd.conf"
+
+// config holds the options of the daemon. Each option is taken from,
+// in order of precedence, its flag, its environment variable (the
+// flag name in upper case, prefixed by MWEBD_ and with dashes as
+// underscores), the config file and its default.
+type config struct {
+	configFile  string
+	printConfig bool
+
+	chain, dataDir, peers string
+	listen, unix          string
+	tlsCert, tlsKey       string
+	proxy, logLevel       string
+
+	coinCacheAccounts, coinCacheSize int
+
+	coinswapNodes        string
+	coinswapHopFee       int64
+	coinswapRequireProxy bool
+
+	encrypt bool
+}
+
+// aliases are the short flags kept from before the long ones.
+var aliases = map[string]string{
+	"c": "chain", "d": "datadir", "p": "peer", "l": "listen",
+}
+
+// notConfig are the flags that aren't options of the daemon, and so
+// aren't read from the environment or the config file.
+var notConfig = map[string]bool{"config": true, "print-config": true}
+
+// notInFile are the options that can't be set in the config file, as
+// the default location of the file depends on them.
+var notInFile = map[string]bool{"datadir": true}
+
+func (cfg *config) flagSet() *flag.FlagSet {
+	f := flag.NewFlagSet("mwebd", flag.ContinueOnError)
+	f.StringVar(&cfg.configFile, "config", "", "Config file (default <datadir>/"+configFileName+")")
+	f.BoolVar(&cfg.printConfig, "print-config", false, "Print the effective configuration and exit")
+
+	f.StringVar(&cfg.chain, "chain", "mainnet", "Chain")
+	f.StringVar(&cfg.dataDir, "datadir", ".", "Data directory")
+	f.StringVar(&cfg.peers, "peer", "", "Connect to peers, comma separated")
+	f.StringVar(&cfg.listen, "listen", "127.0.0.1:12345", "Bind address")
+	f.StringVar(&cfg.unix, "unix", "", "Also listen on a unix socket at this path")
+	f.StringVar(&cfg.tlsCert, "tls-cert", "", "TLS certificate file for the RPC server")
+	f.StringVar(&cfg.tlsKey, "tls-key", "", "TLS key file for the RPC server")
+	f.StringVar(&cfg.proxy, "proxy", "", `Proxy address (e.g. "socks5://127.0.0.1:9050")`)
+	f.StringVar(&cfg.logLevel, "log-level", "debug", "Log level (trace, debug, info, warn, error, critical or off)")
+
+	f.IntVar(&cfg.coinCacheAccounts, "coin-cache-accounts", 10, "Number of accounts whose rewound coins are cached")
+	f.IntVar(&cfg.coinCacheSize, "coin-cache-size", 100, "Number of rewound coins cached per account")
+
+	f.StringVar(&cfg.coinswapNodes, "coinswap-nodes", "", "Comma separated coinswap nodes as <pubkey>@<url>")
+	f.Int64Var(&cfg.coinswapHopFee, "coinswap-hop-fee", 0, "Fee paid to each coinswap node")
+	f.BoolVar(&cfg.coinswapRequireProxy, "coinswap-require-proxy", false, "Refuse coinswaps unless a proxy is set")
+
+	f.BoolVar(&cfg.encrypt, "encrypt", false, "Encrypt the daemon's data with a passphrase given by the Unlock RPC")
+
+	for alias, name := range aliases {
+		f.Var(f.Lookup(name).Value, alias, "Alias of -"+name)
+	}
+	return f
+}
+
+func envName(name string) string {
+	return "MWEBD_" + strings.ToUpper(strings.ReplaceAll(name, "-", "_"))
+}
+
+// loadConfig parses the command line, and fills in the options that
+// it doesn't set from the environment and the config file.
+func loadConfig(args []string, getenv func(string) string) (*config, *flag.FlagSet, error) {
+	cfg := &config{}
+	f := cfg.flagSet()
+	if err := f.Parse(args); err != nil {
+		return nil, nil, err
+	}
+
+	set := map[string]bool{}
+	f.Visit(func(fl *flag.Flag) {
+		if alias := aliases[fl.Name]; alias != "" {
+			set[alias] = true
+		} else {
+			set[fl.Name] = true
+		}
+	})
+
+	var err error
+	f.VisitAll(func(fl *flag.Flag) {
+		if err != nil || set[fl.Name] || aliases[fl.Name] != "" || notConfig[fl.Name] {
+			return
+		}
+		if v := getenv(envName(fl.Name)); v != "" {
+			if err = f.Set(fl.Name, v); err != nil {
+				err = fmt.Errorf("invalid value %q for %s: %v", v, envName(fl.Name), err)
+			}
+			set[fl.Name] = true
+		}
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	path := cfg.configFile
+	if path == "" {
+		path = filepath.Join(cfg.dataDir, configFileName)
+	}
+	b, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) && cfg.configFile == "" {
+		return cfg, f, nil
+	} else if err != nil {
+		return nil, nil, err
+	}
+	if err = readConfigFile(bytes.NewReader(b), f, set); err != nil {
+		return nil, nil, fmt.Errorf("%s: %v", path, err)
+	}
+	return cfg, f, nil
+}
+
+// readConfigFile sets the options in the file that aren't already set.
+func readConfigFile(r io.Reader, f *flag.FlagSet, set map[string]bool) error {
+	s := bufio.NewScanner(r)
+	for n := 1; s.Scan(); n++ {
+		line := strings.TrimSpace(s.Text())
+		if line == "" || line[0] == '#' || line[0] == ';' {
+			continue
+		}
+		name, value, ok := strings.Cut(line, "=")
+		if !ok {
+			return fmt.Errorf("line %d: expected name = value", n)
+		}
+		name, value = strings.TrimSpace(name), strings.TrimSpace(value)
+		if alias := aliases[name]; alias != "" {
+			name = alias
+		}
+		if f.Lookup(name) == nil || notConfig[name] {
+			return fmt.Errorf("line %d: unknown option %q", n, name)
+		}
+		if notInFile[name] {
+			return fmt.Errorf("line %d: %s can't be set in the config file", n, name)
+		}
+		if set[name] {
+			continue
+		}
+		if err := f.Set(name, value); err != nil {
+			return fmt.Errorf("line %d: invalid value %q for %s: %v", n, value, name, err)
+		}
+	}
+	return s.Err()
+}
+
+// printConfig writes the options in the format of the config file.
+// Options that can't be set in the file are commented out.
+func printConfig(w io.Writer, f *flag.FlagSet) {
+	f.VisitAll(func(fl *flag.Flag) {
+		if aliases[fl.Name] != "" || notConfig[fl.Name] {
+			return
+		}
+		fmt.Fprintf(w, "# %s\n", fl.Usage)
+		if notInFile[fl.Name] {
+			fmt.Fprint(w, "# ")
+		}
+		fmt.Fprintf(w, "%s = %s\n\n", fl.Name, fl.Value)
+	})
+}
+
+func (cfg *config) serverArgs() *mwebd.ServerArgs {
+	return &mwebd.ServerArgs{
+		Chain: cfg.chain, DataDir: cfg.dataDir,
+		PeerAddr: cfg.peers, ProxyAddr: cfg.proxy,
+		TLSCertFile: cfg.tlsCert, TLSKeyFile: cfg.tlsKey,
+		LogLevel:          cfg.logLevel,
+		CoinCacheAccounts: cfg.coinCacheAccounts, CoinCacheSize: cfg.coinCacheSize,
+		CoinswapNodes: cfg.coinswapNodes, CoinswapHopFee: cfg.coinswapHopFee,
+		CoinswapRequireProxy: cfg.coinswapRequireProxy,
+		Encrypt:              cfg.encrypt,
+	}
+}
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadConfig(t *testing.T) {
+	dir := t.TempDir()
+	err := os.WriteFile(filepath.Join(dir, configFileName), []byte(`
+# Comments and blank lines are skipped.
+chain = testnet
+p = 1.2.3.4:19335,5.6.7.8:19335
+proxy = socks5://127.0.0.1:9050
+coin-cache-size = 50
+coinswap-require-proxy = true
+`), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	env := map[string]string{
+		"MWEBD_PROXY":            "socks5://127.0.0.1:9150",
+		"MWEBD_COINSWAP_HOP_FEE": "1000",
+		"MWEBD_CHAIN":            "mainnet",
+		"MWEBD_CONFIG":           filepath.Join(dir, "missing"),
+	}
+	cfg, f, err := loadConfig([]string{"-d", dir, "--chain", "regtest"},
+		func(name string) string { return env[name] })
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.chain != "regtest" {
+		t.Fatal("flag should override environment and file", cfg.chain)
+	}
+	if cfg.proxy != "socks5://127.0.0.1:9150" || cfg.coinswapHopFee != 1000 {
+		t.Fatal("environment should override file", cfg.proxy)
+	}
+	if cfg.peers != "1.2.3.4:19335,5.6.7.8:19335" || cfg.coinCacheSize != 50 ||
+		!cfg.coinswapRequireProxy {
+		t.Fatal("options should be read from file", cfg)
+	}
+	if cfg.listen != "127.0.0.1:12345" || cfg.coinCacheAccounts != 10 {
+		t.Fatal("unset options should be defaults", cfg)
+	}
+
+	// The printed config reads back as the same config.
+	var buf bytes.Buffer
+	printConfig(&buf, f)
+	path := filepath.Join(t.TempDir(), "printed.conf")
+	if err = os.WriteFile(path, buf.Bytes(), 0600); err != nil {
+		t.Fatal(err)
+	}
+	cfg2, _, err := loadConfig([]string{"-config", path},
+		func(string) string { return "" })
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := *cfg
+	want.configFile, want.dataDir = path, "."
+	if *cfg2 != want {
+		t.Fatal("printed config mismatch", cfg2)
+	}
+
+	for _, conf := range []string{
+		"chain testnet",
+		"unknown = 1",
+		"print-config = true",
+		"datadir = /tmp",
+		"d = /tmp",
+		"coin-cache-size = many",
+	} {
+		if err = os.WriteFile(path, []byte(conf), 0600); err != nil {
+			t.Fatal(err)
+		}
+		if _, _, err = loadConfig([]string{"-config", path},
+			func(string) string { return "" }); err == nil {
+			t.Fatal("expected error for", conf)
+		}
+	}
+
+	if _, _, err = loadConfig([]string{"-config", filepath.Join(dir, "missing")},
+		func(string) string { return "" }); err == nil {
+		t.Fatal("expected error for missing config file")
+	}
+}
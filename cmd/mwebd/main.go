@@ -9,27 +9,31 @@ import (
 	"github.com/ltcmweb/mwebd"
 )
 
-var (
-	chain    = flag.String("c", "mainnet", "Chain")
-	dataDir  = flag.String("d", ".", "Data directory")
-	peer     = flag.String("p", "", "Connect to peer")
-	bindAddr = flag.String("l", "127.0.0.1:12345", "Bind address")
-	proxy    = flag.String("proxy", "", `Proxy address (e.g. "socks5://127.0.0.1:9050")`)
-)
-
 func main() {
-	flag.Parse()
+	cfg, f, err := loadConfig(os.Args[1:], os.Getenv)
+	if err == flag.ErrHelp {
+		return
+	} else if err != nil {
+		log.Fatalln("Invalid configuration:", err)
+	}
+	if cfg.printConfig {
+		printConfig(os.Stdout, f)
+		return
+	}
 
-	server, err := mwebd.NewServer2(&mwebd.ServerArgs{
-		Chain: *chain, DataDir: *dataDir,
-		PeerAddr: *peer, ProxyAddr: *proxy,
-	})
+	server, err := mwebd.NewServer2(cfg.serverArgs())
 	if err != nil {
 		log.Fatalln("Unable to start server:", err)
 	}
 
+	if cfg.unix != "" {
+		if err = server.StartUnix(cfg.unix); err != nil {
+			log.Fatalln("Failed to listen:", err)
+		}
+	}
+
 	go waitForParent(server)
-	if _, err = server.StartAddr(*bindAddr); err != nil {
+	if _, err = server.StartAddr(cfg.listen); err != nil {
 		log.Fatalln("Failed to listen:", err)
 	}
 }
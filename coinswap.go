@@ -2,9 +2,17 @@ package mwebd
 
 import (
 	"context"
+	"crypto/ecdh"
 	"crypto/rand"
 	"encoding/hex"
+	"encoding/json"
 	"errors"
+	"fmt"
+	mathrand "math/rand/v2"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
 
 	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/ltcmweb/coinswapd/config"
@@ -14,44 +22,138 @@ import (
 	"github.com/ltcmweb/ltcd/ltcutil/mweb/mw"
 	"github.com/ltcmweb/ltcd/wire"
 	"github.com/ltcmweb/mwebd/proto"
+	"github.com/ltcmweb/mwebd/sign"
+	"github.com/ltcsuite/ltcwallet/walletdb"
 )
 
-func (s *Server) Coinswap(ctx context.Context,
-	req *proto.CoinswapRequest) (*proto.CoinswapResponse, error) {
+var coinswapsBucket = []byte("mweb-coinswaps")
 
-	nodes, _ := config.AliveNodes(ctx, nil)
-	if len(nodes) == 0 {
-		return nil, errors.New("no alive nodes")
+// defaultCoinswapHopFee is the fee paid to each node, which covers
+// its share of the swap transaction's weight.
+const defaultCoinswapHopFee = (mweb.KernelWithStealthWeight +
+	mweb.StandardOutputWeight) * mweb.BaseMwebFee
+
+type coinswapNode struct {
+	url    string
+	pubKey *ecdh.PublicKey
+}
+
+// parseCoinswapNodes parses a comma separated list of nodes in the
+// form <pubkey>@<url>, where the pubkey is the node's X25519 key in
+// hex.
+func parseCoinswapNodes(s string) (nodes []*coinswapNode, err error) {
+	for _, node := range strings.Split(s, ",") {
+		pubKeyHex, url, found := strings.Cut(strings.TrimSpace(node), "@")
+		if !found {
+			return nil, fmt.Errorf("invalid coinswap node %q", node)
+		}
+		b, err := hex.DecodeString(pubKeyHex)
+		if err != nil {
+			return nil, err
+		}
+		pubKey, err := ecdh.X25519().NewPublicKey(b)
+		if err != nil {
+			return nil, err
+		}
+		nodes = append(nodes, &coinswapNode{url: url, pubKey: pubKey})
 	}
+	return
+}
 
-	keychain := &mweb.Keychain{
-		Scan:  (*mw.SecretKey)(req.ScanSecret),
-		Spend: (*mw.SecretKey)(req.SpendSecret),
+// coinswapClient returns the HTTP client for talking to coinswap
+// nodes, which goes through the proxy if one is configured.
+func (s *Server) coinswapClient() (*http.Client, error) {
+	switch {
+	case s.coinswapHTTP != nil:
+		return s.coinswapHTTP, nil
+	case s.coinswapRequireProxy:
+		return nil, errors.New("coinswap requires a proxy")
 	}
+	return http.DefaultClient, nil
+}
 
-	outputId, err := hex.DecodeString(req.OutputId)
+// coinswapNodes returns the coinswap nodes that are alive, in the
+// order that they form the mixing route. The first node is the
+// entry node that accepts swap requests. Pinned nodes are used
+// instead of the public node list if configured.
+func (s *Server) coinswapNodes(ctx context.Context) (nodes []*coinswapNode, err error) {
+	client, err := s.coinswapClient()
 	if err != nil {
 		return nil, err
 	}
+	candidates := s.pinnedCoinswapNodes
+	if candidates == nil && client == http.DefaultClient {
+		alive, _ := config.AliveNodes(ctx, nil)
+		for _, node := range alive {
+			nodes = append(nodes, &coinswapNode{url: node.Url, pubKey: node.PubKey()})
+		}
+	} else if candidates == nil {
+		// The remote node list would be fetched without the proxy,
+		// so only the built-in list is used.
+		for _, node := range config.Nodes {
+			candidates = append(candidates,
+				&coinswapNode{url: node.Url, pubKey: node.PubKey()})
+		}
+	}
+	for _, node := range candidates {
+		if coinswapNodeAlive(ctx, client, node) {
+			nodes = append(nodes, node)
+		}
+	}
+	if len(nodes) == 0 {
+		return nil, errors.New("no alive nodes")
+	}
+	return
+}
 
-	output, err := s.fetchCoin(chainhash.Hash(outputId))
+func coinswapNodeAlive(ctx context.Context,
+	client *http.Client, node *coinswapNode) bool {
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, node.url, nil)
 	if err != nil {
-		return nil, err
+		return false
 	}
-
-	coin, err := s.rewindOutput(output, keychain.Scan)
+	resp, err := client.Do(req)
 	if err != nil {
-		return nil, err
+		return false
+	}
+	resp.Body.Close()
+	return resp.StatusCode == http.StatusOK
+}
+
+// coinswapRoutes returns the routes to try for a swap. The first
+// is through all nodes, and the rest skip one node each in case it
+// fails, starting with the entry node. Routes with fewer hops than
+// the request's minimum are left out by the caller.
+func coinswapRoutes(nodes []*coinswapNode) (routes [][]*coinswapNode) {
+	routes = append(routes, nodes)
+	if len(nodes) > 1 {
+		for i := range nodes {
+			routes = append(routes, slices.Delete(slices.Clone(nodes), i, i+1))
+		}
 	}
-	coin.CalculateOutputKey(keychain.SpendKey(req.AddrIndex))
+	return
+}
+
+// coinswapAttempt is a signed onion for one route. The onions for
+// every route are created up front, so that a swap can be retried
+// without the account's keys.
+type coinswapAttempt struct {
+	Urls     []string     `json:"urls"`
+	PubKeys  []string     `json:"pub_keys"`
+	OutputId string       `json:"output_id"`
+	Onion    *onion.Onion `json:"onion"`
+	Tried    bool         `json:"tried"`
+}
+
+func newCoinswapAttempt(coin *mweb.Coin, route []*coinswapNode,
+	hopFee uint64) (*coinswapAttempt, error) {
 
 	var hops []*onion.Hop
-	for _, node := range nodes {
-		hops = append(hops, &onion.Hop{
-			PubKey: node.PubKey(),
-			Fee: (mweb.KernelWithStealthWeight +
-				mweb.StandardOutputWeight) * mweb.BaseMwebFee,
-		})
+	for _, node := range route {
+		hops = append(hops, &onion.Hop{PubKey: node.pubKey, Fee: hopFee})
 	}
 
 	var fee uint64
@@ -87,17 +189,550 @@ func (s *Server) Coinswap(ctx context.Context,
 	}
 	onion.Sign(input, coin.SpendKey)
 
-	client, err := rpc.DialContext(ctx, nodes[0].Url)
+	a := &coinswapAttempt{
+		OutputId: hex.EncodeToString(output.Hash()[:]),
+		Onion:    onion,
+	}
+	for _, node := range route {
+		a.Urls = append(a.Urls, node.url)
+		a.PubKeys = append(a.PubKeys, hex.EncodeToString(node.pubKey.Bytes()))
+	}
+	return a, nil
+}
+
+// coinswapRecord tracks a swap of a utxo from submission until the
+// output of the swap is seen, or the utxo is spent otherwise. While
+// the swap is waiting to be submitted through another route,
+// Scheduled is when that will happen.
+//
+// The record links the utxo to the outputs of its swap, and holds
+// the signed onions until the swap has finished. It's only encrypted
+// if the daemon runs with -encrypt.
+type coinswapRecord struct {
+	InputId   string             `json:"input_id"`
+	State     int32              `json:"state"`
+	Error     string             `json:"error,omitempty"`
+	Created   int64              `json:"created"`
+	Submitted int64              `json:"submitted"`
+	Scheduled int64              `json:"scheduled,omitempty"`
+	Attempt   int                `json:"attempt"`
+	Attempts  []*coinswapAttempt `json:"attempts"`
+}
+
+// finish sets the final state of the swap. The onions are dropped as
+// they're no longer needed.
+func (r *coinswapRecord) finish(state proto.CoinswapState, reason string) {
+	r.State = int32(state)
+	r.Error = reason
+	r.Scheduled = 0
+	for _, a := range r.Attempts {
+		a.Onion = nil
+	}
+}
+
+// coinswapGracePeriod is how long after the day's swap round the
+// swap transaction is given to be seen.
+const coinswapGracePeriod = 2 * time.Hour
+
+// coinswapCheckInterval is how often the swaps are checked when the
+// utxo set doesn't change.
+const coinswapCheckInterval = time.Minute
+
+// coinswapDeadline returns when a swap submitted at the given time
+// should have completed by. This follows the schedule of coinswapd,
+// whose nodes perform swaps once a day at midnight UTC, and must be
+// changed along with it.
+func coinswapDeadline(submitted time.Time) time.Time {
+	return submitted.UTC().Truncate(24 * time.Hour).
+		Add(24*time.Hour + coinswapGracePeriod)
+}
+
+// coinswapRandomTime returns a random time before the next swap round
+// to submit a swap at, so that the nodes can't link submissions by
+// their timing.
+func coinswapRandomTime(now time.Time) time.Time {
+	round := now.UTC().Truncate(24 * time.Hour).Add(24 * time.Hour)
+	return now.Add(mathrand.N(round.Sub(now)))
+}
+
+func (s *Server) putCoinswap(r *coinswapRecord) error {
+	key, err := hex.DecodeString(r.InputId)
+	if err != nil {
+		return err
+	}
+	b, err := json.Marshal(r)
+	if err != nil {
+		return err
+	}
+	return s.update(func(dbtx walletdb.ReadWriteTx) error {
+		bucket, err := s.writeBucket(dbtx, coinswapsBucket)
+		if err != nil {
+			return err
+		}
+		return bucket.Put(key, b)
+	})
+}
+
+// getCoinswaps returns the swap of the utxo, or every swap if
+// inputId is nil.
+func (s *Server) getCoinswaps(inputId []byte) (records []*coinswapRecord, err error) {
+	err = s.view(func(dbtx walletdb.ReadTx) error {
+		bucket, err := s.readBucket(dbtx, coinswapsBucket)
+		if bucket == nil {
+			return err
+		}
+		if inputId != nil {
+			b, err := bucket.Get(inputId)
+			if b == nil && err == nil {
+				inputId = slices.Clone(inputId)
+				slices.Reverse(inputId)
+				b, err = bucket.Get(inputId)
+			}
+			if b == nil {
+				return err
+			}
+			r := &coinswapRecord{}
+			records = append(records, r)
+			return json.Unmarshal(b, r)
+		}
+		return bucket.ForEach(func(k, v []byte) error {
+			r := &coinswapRecord{}
+			records = append(records, r)
+			return json.Unmarshal(v, r)
+		})
+	})
+	return
+}
+
+// checkCoinswapPolicy checks that a route through all the nodes
+// satisfies the request's fee and hop limits, and returns the fee
+// to pay each node.
+func (s *Server) checkCoinswapPolicy(req *proto.CoinswapRequest,
+	nodes []*coinswapNode) (hopFee uint64, err error) {
+
+	if len(nodes) < int(req.MinHops) {
+		return 0, fmt.Errorf("only %d coinswap nodes are alive, "+
+			"%d hops required", len(nodes), req.MinHops)
+	}
+	hopFee = s.coinswapHopFee
+	if hopFee == 0 {
+		hopFee = defaultCoinswapHopFee
+	}
+	if fee := hopFee * uint64(len(nodes)); req.MaxFee > 0 && fee > req.MaxFee {
+		return 0, fmt.Errorf("coinswap fee of %d exceeds maximum of %d",
+			fee, req.MaxFee)
+	}
+	return
+}
+
+func (s *Server) Coinswap(ctx context.Context,
+	req *proto.CoinswapRequest) (*proto.CoinswapResponse, error) {
+
+	defer clear(req.SpendSecret)
+
+	nodes, err := s.coinswapNodes(ctx)
 	if err != nil {
 		return nil, err
 	}
-	if err = client.CallContext(ctx, nil, "swap_swap", onion); err != nil {
+	return s.coinswap(ctx, req, nodes, time.Time{})
+}
+
+// coinswap creates the attempts for a swap and submits it, or if a
+// time is given, stores it to be submitted by checkCoinswaps then.
+func (s *Server) coinswap(ctx context.Context, req *proto.CoinswapRequest,
+	nodes []*coinswapNode, at time.Time) (*proto.CoinswapResponse, error) {
+
+	hopFee, err := s.checkCoinswapPolicy(req, nodes)
+	if err != nil {
 		return nil, err
 	}
 
-	return &proto.CoinswapResponse{
-		OutputId: hex.EncodeToString(output.Hash()[:]),
-	}, nil
+	keychain := &mweb.Keychain{
+		Scan:  (*mw.SecretKey)(req.ScanSecret),
+		Spend: (*mw.SecretKey)(req.SpendSecret),
+	}
+
+	outputId, err := hex.DecodeString(req.OutputId)
+	if err != nil {
+		return nil, err
+	}
+
+	output, err := s.fetchCoin(chainhash.Hash(outputId))
+	if err != nil {
+		return nil, err
+	}
+
+	coin, err := s.rewindOutput(output, keychain.Scan)
+	if err != nil {
+		return nil, err
+	}
+	spendKey := keychain.SpendKey(req.AddrIndex)
+	coin.CalculateOutputKey(spendKey)
+	sign.Zero(spendKey)
+	defer sign.Zero(coin.SpendKey)
+
+	r := &coinswapRecord{
+		InputId: hex.EncodeToString(output.Hash()[:]),
+		Created: time.Now().Unix(),
+	}
+	for _, route := range coinswapRoutes(nodes) {
+		if len(route) < int(req.MinHops) {
+			continue
+		}
+		a, err := newCoinswapAttempt(coin, route, hopFee)
+		if err != nil {
+			if len(r.Attempts) == 0 {
+				return nil, err
+			}
+			continue
+		}
+		r.Attempts = append(r.Attempts, a)
+	}
+
+	if !at.IsZero() {
+		r.Scheduled = at.Unix()
+	}
+	s.coinswapMtx.Lock()
+	err = s.addCoinswap(r)
+	s.coinswapMtx.Unlock()
+	if err != nil {
+		return nil, err
+	}
+	if r.Scheduled > 0 {
+		return &proto.CoinswapResponse{OutputId: r.Attempts[0].OutputId}, nil
+	}
+
+	err = s.submitCoinswap(ctx, r, nodes)
+
+	s.coinswapMtx.Lock()
+	defer s.coinswapMtx.Unlock()
+	delete(s.coinswapBusy, r.InputId)
+	if err2 := s.putCoinswap(r); err2 != nil {
+		return nil, err2
+	}
+	if r.State == int32(proto.CoinswapState_COINSWAP_FAILED) {
+		return nil, err
+	}
+
+	resp := &proto.CoinswapResponse{}
+	if err == nil {
+		resp.OutputId = r.Attempts[r.Attempt].OutputId
+	}
+	return resp, nil
+}
+
+// addCoinswap adds a new swap, unless the utxo already has a pending
+// one. A swap scheduled for later is stored, otherwise it's marked
+// busy for the caller to submit, which keeps it from being submitted
+// twice without holding the lock meanwhile.
+func (s *Server) addCoinswap(r *coinswapRecord) error {
+	inputId, err := hex.DecodeString(r.InputId)
+	if err != nil {
+		return err
+	}
+	records, err := s.getCoinswaps(inputId)
+	if err != nil {
+		return err
+	}
+	if s.coinswapBusy[r.InputId] || len(records) > 0 &&
+		records[0].State == int32(proto.CoinswapState_COINSWAP_PENDING) {
+		return errors.New("coinswap already pending")
+	}
+	if r.Scheduled > 0 {
+		return s.putCoinswap(r)
+	}
+	if s.coinswapBusy == nil {
+		s.coinswapBusy = map[string]bool{}
+	}
+	s.coinswapBusy[r.InputId] = true
+	return nil
+}
+
+// CoinswapBatch schedules each swap at its own random time before the
+// next round, rather than submitting them together, so that the
+// entry node can't link the utxos by their arrival.
+func (s *Server) CoinswapBatch(ctx context.Context,
+	req *proto.CoinswapBatchRequest) (*proto.CoinswapBatchResponse, error) {
+
+	defer clear(req.SpendSecret)
+
+	nodes, err := s.coinswapNodes(ctx)
+	if err != nil {
+		return nil, err
+	}
+	resp := &proto.CoinswapBatchResponse{}
+	for _, input := range req.Inputs {
+		result := &proto.CoinswapBatchResult{}
+		resp.Results = append(resp.Results, result)
+		at := coinswapRandomTime(time.Now())
+		swap, err := s.coinswap(ctx, &proto.CoinswapRequest{
+			ScanSecret:  req.ScanSecret,
+			SpendSecret: req.SpendSecret,
+			OutputId:    input.OutputId,
+			AddrIndex:   input.AddrIndex,
+			MaxFee:      req.MaxFee,
+			MinHops:     req.MinHops,
+		}, nodes, at)
+		if err != nil {
+			result.Error = err.Error()
+			continue
+		}
+		result.OutputId = swap.OutputId
+		result.ScheduledTime = at.Unix()
+	}
+	return resp, nil
+}
+
+// submitCoinswap submits the first untried attempt whose route only
+// uses alive nodes. Only one attempt is made per call, as trying the
+// next route straight away would show its nodes that the input is
+// the one that just failed. On failure the swap is scheduled to be
+// resubmitted by checkCoinswaps at a random time before the next
+// round, or failed once every attempt has been tried.
+func (s *Server) submitCoinswap(ctx context.Context,
+	r *coinswapRecord, nodes []*coinswapNode) (err error) {
+
+	alive := map[string]bool{}
+	for _, node := range nodes {
+		alive[hex.EncodeToString(node.pubKey.Bytes())] = true
+	}
+
+	i := slices.IndexFunc(r.Attempts, func(a *coinswapAttempt) bool {
+		return !a.Tried && !slices.ContainsFunc(a.PubKeys,
+			func(pubKey string) bool { return !alive[pubKey] })
+	})
+	client, err := s.coinswapClient()
+	if err == nil && i < 0 {
+		err = errors.New("no route through alive nodes")
+	}
+	if err == nil {
+		a := r.Attempts[i]
+		a.Tried = true
+		var rpcClient *rpc.Client
+		rpcClient, err = rpc.DialOptions(ctx, a.Urls[0], rpc.WithHTTPClient(client))
+		if err == nil {
+			err = rpcClient.CallContext(ctx, nil, "swap_swap", a.Onion)
+			rpcClient.Close()
+		}
+	}
+
+	now := time.Now()
+	switch {
+	case err == nil:
+		r.State = int32(proto.CoinswapState_COINSWAP_PENDING)
+		r.Error = ""
+		r.Submitted = now.Unix()
+		r.Scheduled = 0
+		r.Attempt = i
+	case slices.ContainsFunc(r.Attempts,
+		func(a *coinswapAttempt) bool { return !a.Tried }):
+		r.Error = err.Error()
+		r.Scheduled = coinswapRandomTime(now).Unix()
+	default:
+		r.finish(proto.CoinswapState_COINSWAP_FAILED, err.Error())
+	}
+	return
+}
+
+// updateCoinswap checks whether a pending swap has completed, or
+// whether its input has been spent otherwise. It returns true if the
+// swap is still pending after its deadline.
+func (s *Server) updateCoinswap(r *coinswapRecord, now time.Time) (overdue bool) {
+	if r.State != int32(proto.CoinswapState_COINSWAP_PENDING) {
+		return false
+	}
+	for i, a := range r.Attempts {
+		if !a.Tried {
+			continue
+		}
+		outputId, _ := hex.DecodeString(a.OutputId)
+		found := s.cs.MwebUtxoExists((*chainhash.Hash)(outputId))
+		if !found {
+			found, _ = s.inMempool([]chainhash.Hash{chainhash.Hash(outputId)})
+		}
+		if found {
+			r.Attempt = i
+			r.finish(proto.CoinswapState_COINSWAP_COMPLETED, "")
+			return false
+		}
+	}
+	inputId, _ := hex.DecodeString(r.InputId)
+	found := s.cs.MwebUtxoExists((*chainhash.Hash)(inputId))
+	if !found {
+		found, _ = s.inMempool([]chainhash.Hash{chainhash.Hash(inputId)})
+	}
+	if !found {
+		r.finish(proto.CoinswapState_COINSWAP_FAILED,
+			"input was spent without the swap output being seen")
+		return false
+	}
+	return r.Submitted > 0 &&
+		now.After(coinswapDeadline(time.Unix(r.Submitted, 0)))
+}
+
+// notifyCoinswaps wakes watchCoinswaps, unless it's already due to
+// run. It's called whenever the utxo set changes.
+func (s *Server) notifyCoinswaps() {
+	select {
+	case s.coinswapWake <- struct{}{}:
+	default:
+	}
+}
+
+// watchCoinswaps checks the swaps each time it's woken by
+// notifyCoinswaps, and every coinswapCheckInterval so that scheduled
+// submissions don't wait for the utxo set to change.
+func (s *Server) watchCoinswaps() {
+	ticker := time.NewTicker(coinswapCheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case _, ok := <-s.coinswapWake:
+			if !ok {
+				return
+			}
+		case <-ticker.C:
+		}
+		s.checkCoinswaps()
+	}
+}
+
+// checkCoinswaps updates the pending swaps, schedules those that
+// didn't complete in time to be resubmitted through another route,
+// and submits those whose time has come. Only swaps whose state
+// changed are written back. The lock isn't held while talking to the
+// nodes, instead the swaps being submitted are marked busy.
+func (s *Server) checkCoinswaps() {
+	now := time.Now()
+	s.coinswapMtx.Lock()
+	records, err := s.getCoinswaps(nil)
+	if err != nil {
+		s.coinswapMtx.Unlock()
+		if err != errDBLocked {
+			log.Errorf("Unable to get coinswaps: %v", err)
+		}
+		return
+	}
+	var due []*coinswapRecord
+	for _, r := range records {
+		if r.State != int32(proto.CoinswapState_COINSWAP_PENDING) ||
+			s.coinswapBusy[r.InputId] {
+			continue
+		}
+		state, scheduled := r.State, r.Scheduled
+		if s.updateCoinswap(r, now) && r.Scheduled == 0 {
+			r.Scheduled = coinswapRandomTime(now).Unix()
+		}
+		if r.State != state || r.Scheduled != scheduled {
+			if err = s.putCoinswap(r); err != nil {
+				log.Errorf("Unable to save coinswap of %s: %v", r.InputId, err)
+			}
+		}
+		if r.State == int32(proto.CoinswapState_COINSWAP_PENDING) &&
+			r.Scheduled > 0 && r.Scheduled <= now.Unix() {
+			if s.coinswapBusy == nil {
+				s.coinswapBusy = map[string]bool{}
+			}
+			s.coinswapBusy[r.InputId] = true
+			due = append(due, r)
+		}
+	}
+	s.coinswapMtx.Unlock()
+	if len(due) == 0 {
+		return
+	}
+
+	ctx := context.Background()
+	nodes, err := s.coinswapNodes(ctx)
+	if err != nil {
+		log.Warnf("Unable to get coinswap nodes: %v", err)
+	}
+	for _, r := range due {
+		if err = s.submitCoinswap(ctx, r, nodes); err != nil {
+			log.Warnf("Unable to resubmit coinswap of %s: %v", r.InputId, err)
+		}
+	}
+
+	s.coinswapMtx.Lock()
+	defer s.coinswapMtx.Unlock()
+	for _, r := range due {
+		delete(s.coinswapBusy, r.InputId)
+		if err = s.putCoinswap(r); err != nil {
+			log.Errorf("Unable to save coinswap of %s: %v", r.InputId, err)
+		}
+	}
+}
+
+func (s *Server) CoinswapStatus(ctx context.Context,
+	req *proto.CoinswapStatusRequest) (*proto.CoinswapStatusResponse, error) {
+
+	inputId, err := hex.DecodeString(req.OutputId)
+	if err != nil {
+		return nil, err
+	}
+	s.coinswapMtx.Lock()
+	defer s.coinswapMtx.Unlock()
+	records, err := s.getCoinswaps(inputId)
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 {
+		return nil, errors.New("coinswap not found")
+	}
+	return s.coinswapStatus(records[0])
+}
+
+func (s *Server) CoinswapList(ctx context.Context,
+	req *proto.CoinswapListRequest) (*proto.CoinswapListResponse, error) {
+
+	s.coinswapMtx.Lock()
+	defer s.coinswapMtx.Unlock()
+	records, err := s.getCoinswaps(nil)
+	if err != nil {
+		return nil, err
+	}
+	resp := &proto.CoinswapListResponse{}
+	for _, r := range records {
+		status, err := s.coinswapStatus(r)
+		if err != nil {
+			return nil, err
+		}
+		resp.Coinswap = append(resp.Coinswap, status)
+	}
+	return resp, nil
+}
+
+func (s *Server) coinswapStatus(r *coinswapRecord) (*proto.CoinswapStatusResponse, error) {
+	state := r.State
+	if !s.coinswapBusy[r.InputId] {
+		s.updateCoinswap(r, time.Now())
+	}
+	if r.State != state {
+		if err := s.putCoinswap(r); err != nil {
+			return nil, err
+		}
+	}
+
+	status := &proto.CoinswapStatusResponse{
+		OutputId:      r.InputId,
+		State:         proto.CoinswapState(r.State),
+		Error:         r.Error,
+		SubmittedTime: r.Submitted,
+		ScheduledTime: r.Scheduled,
+	}
+	for _, a := range r.Attempts {
+		if a.Tried {
+			status.Attempts++
+		}
+	}
+	if r.Submitted > 0 {
+		a := r.Attempts[r.Attempt]
+		status.SwapOutputId = a.OutputId
+		status.EntryNode = a.Urls[0]
+		status.Hops = uint32(len(a.Urls))
+		status.DeadlineTime = coinswapDeadline(time.Unix(r.Submitted, 0)).Unix()
+	}
+	return status, nil
 }
 
 func makeCoinswapTx(coin *mweb.Coin, recipient *mweb.Recipient) (
@@ -111,6 +746,7 @@ func makeCoinswapTx(coin *mweb.Coin, recipient *mweb.Recipient) (
 	}()
 
 	var inputKey, outputKey mw.SecretKey
+	defer sign.Zero(&inputKey, &outputKey)
 	if _, err = rand.Read(inputKey[:]); err != nil {
 		return
 	}
@@ -1,15 +1,27 @@
 package mwebd
 
 import (
+	"context"
 	"crypto/ecdh"
 	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
 	"reflect"
+	"slices"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/ltcmweb/coinswapd/onion"
+	"github.com/ltcmweb/ltcd/chaincfg"
 	"github.com/ltcmweb/ltcd/chaincfg/chainhash"
 	"github.com/ltcmweb/ltcd/ltcutil/mweb"
 	"github.com/ltcmweb/ltcd/ltcutil/mweb/mw"
+	"github.com/ltcmweb/mwebd/proto"
 )
 
 func TestOnion(t *testing.T) {
@@ -122,3 +134,296 @@ func TestOnion(t *testing.T) {
 		t.Fatal("stealth sums unbalanced")
 	}
 }
+
+func newTestCoinswapNodes(t *testing.T, n int) (nodes []*coinswapNode) {
+	for i := range n {
+		privKey, err := ecdh.X25519().GenerateKey(rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+		nodes = append(nodes, &coinswapNode{
+			url:    fmt.Sprintf("http://127.0.0.1:1/%d", i),
+			pubKey: privKey.PublicKey(),
+		})
+	}
+	return
+}
+
+func TestCoinswapRoutes(t *testing.T) {
+	nodes := newTestCoinswapNodes(t, 3)
+	routes := coinswapRoutes(nodes)
+	if len(routes) != 4 || len(routes[0]) != 3 {
+		t.Fatal("unexpected routes")
+	}
+	for i, route := range routes[1:] {
+		if len(route) != 2 || slices.Contains(route, nodes[i]) {
+			t.Fatal("route should skip node", i)
+		}
+	}
+	if routes[1][0] != nodes[1] {
+		t.Fatal("first retry should use a different entry node")
+	}
+	if len(coinswapRoutes(nodes[:1])) != 1 {
+		t.Fatal("single node should have a single route")
+	}
+}
+
+func TestCoinswapDeadline(t *testing.T) {
+	submitted := time.Date(2025, 3, 4, 23, 59, 0, 0, time.UTC)
+	deadline := time.Date(2025, 3, 5, 2, 0, 0, 0, time.UTC)
+	if !coinswapDeadline(submitted).Equal(deadline) {
+		t.Fatal("unexpected deadline", coinswapDeadline(submitted))
+	}
+	submitted = time.Date(2025, 3, 5, 0, 1, 0, 0, time.FixedZone("", 3600))
+	deadline = time.Date(2025, 3, 5, 2, 0, 0, 0, time.UTC)
+	if !coinswapDeadline(submitted).Equal(deadline) {
+		t.Fatal("deadline should be in UTC", coinswapDeadline(submitted))
+	}
+}
+
+func TestCoinswapAttempt(t *testing.T) {
+	kc := randKeychain()
+	coin := &mweb.Coin{
+		SpendKey: kc.SpendKey(0),
+		Blind:    &mw.BlindingFactor{1},
+		Value:    100_000,
+		OutputId: &chainhash.Hash{2},
+		Address:  kc.Address(0),
+	}
+	nodes := newTestCoinswapNodes(t, 3)
+
+	r := &coinswapRecord{InputId: hex.EncodeToString(coin.OutputId[:])}
+	for _, route := range coinswapRoutes(nodes) {
+		a, err := newCoinswapAttempt(coin, route, defaultCoinswapHopFee)
+		if err != nil {
+			t.Fatal(err)
+		}
+		r.Attempts = append(r.Attempts, a)
+	}
+	if r.Attempts[0].OutputId == r.Attempts[1].OutputId ||
+		len(r.Attempts[1].Urls) != 2 || r.Attempts[1].Urls[0] != nodes[1].url {
+		t.Fatal("unexpected attempts")
+	}
+
+	b, err := json.Marshal(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r2 := &coinswapRecord{}
+	if err = json.Unmarshal(b, r2); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(r, r2) {
+		t.Fatal("record mismatch")
+	}
+
+	coin.Value = 10
+	if _, err = newCoinswapAttempt(coin, nodes, defaultCoinswapHopFee); err == nil {
+		t.Fatal("expected insufficient value error")
+	}
+
+	// Every node is unreachable. Each call tries one route through
+	// the alive nodes, and the swap fails once all have been tried.
+	s := NewBareServer(chaincfg.MainNetParams)
+	if err = s.submitCoinswap(context.Background(), r, nodes[1:]); err == nil {
+		t.Fatal("expected submission error")
+	}
+	if r.State != int32(proto.CoinswapState_COINSWAP_PENDING) || r.Attempts[0].Tried ||
+		!r.Attempts[1].Tried || r.Attempts[2].Tried || r.Attempts[3].Tried {
+		t.Fatal("only one route through alive nodes should be tried")
+	}
+	round := time.Now().UTC().Truncate(24 * time.Hour).Add(24 * time.Hour)
+	if r.Scheduled < time.Now().Unix()-1 || r.Scheduled > round.Unix() {
+		t.Fatal("retry should be scheduled before the next round", r.Scheduled)
+	}
+	for range 2 {
+		if err = s.submitCoinswap(context.Background(), r, nodes); err == nil {
+			t.Fatal("expected submission error")
+		}
+		if r.State != int32(proto.CoinswapState_COINSWAP_PENDING) {
+			t.Fatal("swap failed before every route was tried")
+		}
+	}
+	if err = s.submitCoinswap(context.Background(), r, nodes); err == nil {
+		t.Fatal("expected submission error")
+	}
+	if r.State != int32(proto.CoinswapState_COINSWAP_FAILED) || r.Error == "" ||
+		r.Scheduled != 0 || r.Attempts[0].Onion != nil {
+		t.Fatal("expected failed state without onions")
+	}
+}
+
+func TestCoinswapNodesConfig(t *testing.T) {
+	alive := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {}))
+	defer alive.Close()
+
+	nodes := newTestCoinswapNodes(t, 2)
+	nodes[0].url = alive.URL
+	var config []string
+	for _, node := range nodes {
+		config = append(config, hex.EncodeToString(node.pubKey.Bytes())+"@"+node.url)
+	}
+	parsed, err := parseCoinswapNodes(strings.Join(config, ", "))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(parsed) != 2 || parsed[1].url != nodes[1].url ||
+		!parsed[1].pubKey.Equal(nodes[1].pubKey) {
+		t.Fatal("unexpected nodes", parsed)
+	}
+	for _, s := range []string{alive.URL, "00@" + alive.URL, "zz@" + alive.URL} {
+		if _, err = parseCoinswapNodes(s); err == nil {
+			t.Fatal("expected error for", s)
+		}
+	}
+
+	s := NewBareServer(chaincfg.MainNetParams)
+	s.pinnedCoinswapNodes = parsed
+	if alive, _ := s.coinswapNodes(context.Background()); len(alive) != 1 ||
+		alive[0] != parsed[0] {
+		t.Fatal("only the first node is alive", alive)
+	}
+
+	s.pinnedCoinswapNodes = parsed[1:]
+	if _, err = s.coinswapNodes(context.Background()); err == nil {
+		t.Fatal("expected no alive nodes error")
+	}
+}
+
+type recordingDialer struct {
+	addrs []string
+}
+
+func (d *recordingDialer) Dial(network, addr string) (net.Conn, error) {
+	d.addrs = append(d.addrs, addr)
+	return net.Dial(network, addr)
+}
+
+func TestCoinswapProxy(t *testing.T) {
+	alive := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {}))
+	defer alive.Close()
+
+	s := NewBareServer(chaincfg.MainNetParams)
+	s.pinnedCoinswapNodes = newTestCoinswapNodes(t, 1)
+	s.pinnedCoinswapNodes[0].url = alive.URL
+	s.coinswapRequireProxy = true
+	if _, err := s.coinswapNodes(context.Background()); err == nil {
+		t.Fatal("coinswap should be refused without a proxy")
+	}
+	r := &coinswapRecord{Attempts: []*coinswapAttempt{{}}}
+	if s.submitCoinswap(context.Background(), r, nil) == nil ||
+		r.Attempts[0].Tried {
+		t.Fatal("submission should be refused without a proxy")
+	}
+
+	dialer := &recordingDialer{}
+	s.coinswapHTTP = proxyHTTPClient(dialer)
+	nodes, err := s.coinswapNodes(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(nodes) != 1 || len(dialer.addrs) != 1 ||
+		"http://"+dialer.addrs[0] != alive.URL {
+		t.Fatal("node should be checked through the proxy", dialer.addrs)
+	}
+}
+
+func TestCoinswapPolicy(t *testing.T) {
+	s := NewBareServer(chaincfg.MainNetParams)
+	nodes := newTestCoinswapNodes(t, 3)
+
+	hopFee, err := s.checkCoinswapPolicy(&proto.CoinswapRequest{}, nodes)
+	if err != nil || hopFee != defaultCoinswapHopFee {
+		t.Fatal("unexpected hop fee", hopFee, err)
+	}
+	_, err = s.checkCoinswapPolicy(&proto.CoinswapRequest{
+		MaxFee: 3*defaultCoinswapHopFee - 1,
+	}, nodes)
+	if err == nil {
+		t.Fatal("expected max fee error")
+	}
+	if _, err = s.checkCoinswapPolicy(&proto.CoinswapRequest{MinHops: 4}, nodes); err == nil {
+		t.Fatal("expected min hops error")
+	}
+
+	s.coinswapHopFee = 1000
+	hopFee, err = s.checkCoinswapPolicy(&proto.CoinswapRequest{
+		MaxFee: 3000, MinHops: 3,
+	}, nodes)
+	if err != nil || hopFee != 1000 {
+		t.Fatal("unexpected hop fee", hopFee, err)
+	}
+}
+
+func TestCheckCoinswaps(t *testing.T) {
+	s := newTestServer(t)
+	s.pinnedCoinswapNodes = newTestCoinswapNodes(t, 1)
+	now := time.Now()
+	newRecord := func(inputId []byte) *coinswapRecord {
+		return &coinswapRecord{
+			InputId:   hex.EncodeToString(inputId),
+			State:     int32(proto.CoinswapState_COINSWAP_PENDING),
+			Submitted: now.Unix(),
+			Attempts: []*coinswapAttempt{{
+				PubKeys: []string{"00"}, Tried: true,
+				OutputId: hex.EncodeToString(make([]byte, chainhash.HashSize)),
+			}, {
+				PubKeys:  []string{"01"},
+				OutputId: hex.EncodeToString(make([]byte, chainhash.HashSize)),
+			}},
+		}
+	}
+	pending := newRecord(addTestUtxo(t, s, randKeychain(), 1, 100_000).Hash()[:])
+	overdue := newRecord(addTestUtxo(t, s, randKeychain(), 1, 100_000).Hash()[:])
+	overdue.Submitted = now.Add(-72 * time.Hour).Unix()
+	due := newRecord(addTestUtxo(t, s, randKeychain(), 1, 100_000).Hash()[:])
+	due.Submitted = 0
+	due.Scheduled = now.Add(-time.Minute).Unix()
+	spent := newRecord(make([]byte, chainhash.HashSize))
+	for _, r := range []*coinswapRecord{pending, overdue, due, spent} {
+		if err := s.putCoinswap(r); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Without a wake channel, notifying doesn't block.
+	s.notifyCoinswaps()
+	s.checkCoinswaps()
+
+	records, err := s.getCoinswaps(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	round := now.UTC().Truncate(24 * time.Hour).Add(24 * time.Hour).Unix()
+	for _, r := range records {
+		switch r.InputId {
+		case pending.InputId:
+			if r.State != int32(proto.CoinswapState_COINSWAP_PENDING) ||
+				r.Scheduled != 0 {
+				t.Fatal("swap shouldn't be rescheduled before its deadline")
+			}
+		case overdue.InputId:
+			if r.State != int32(proto.CoinswapState_COINSWAP_PENDING) ||
+				r.Scheduled < now.Unix() || r.Scheduled > round {
+				t.Fatal("overdue swap should be scheduled before the next round")
+			}
+		case due.InputId:
+			// No node is alive, so the submission fails and is
+			// rescheduled without trying the remaining route.
+			if r.State != int32(proto.CoinswapState_COINSWAP_PENDING) ||
+				r.Error == "" || r.Attempts[1].Tried ||
+				r.Scheduled < now.Unix() || r.Scheduled > round {
+				t.Fatal("failed submission should be rescheduled", r)
+			}
+		case spent.InputId:
+			if r.State != int32(proto.CoinswapState_COINSWAP_FAILED) {
+				t.Fatal("swap of spent utxo should fail")
+			}
+		}
+	}
+	if len(s.coinswapBusy) != 0 {
+		t.Fatal("swaps left busy")
+	}
+}
@@ -0,0 +1,402 @@
+package mwebd
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdh"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/ltcmweb/coinswapd/onion"
+	"github.com/ltcmweb/ltcd/chaincfg"
+	"github.com/ltcmweb/ltcd/chaincfg/chainhash"
+	"github.com/ltcmweb/ltcd/ltcutil/mweb"
+	"github.com/ltcmweb/ltcd/ltcutil/mweb/mw"
+	"github.com/ltcmweb/ltcd/wire"
+	"github.com/ltcmweb/mwebd/proto"
+	"github.com/ltcmweb/neutrino"
+	"github.com/ltcsuite/ltcwallet/walletdb"
+)
+
+// fakeCoinswapNode stands in for a coinswapd node. Only the entry
+// node accepts swaps, which are checked by peeling the onion with the
+// keys of the alive nodes that follow, as the nodes would at swap
+// time.
+type fakeCoinswapNode struct {
+	net     *fakeCoinswapNet
+	privKey *ecdh.PrivateKey
+	server  *httptest.Server
+	down    bool
+	fail    bool
+}
+
+type fakeCoinswapNet struct {
+	mtx       sync.Mutex
+	nodes     []*fakeCoinswapNode
+	fetchCoin func(chainhash.Hash) (*wire.MwebOutput, error)
+	swaps     []*fakeCoinswap
+}
+
+// fakeCoinswap is a swap accepted by the fake nodes.
+type fakeCoinswap struct {
+	entry  *fakeCoinswapNode
+	hops   int
+	fee    uint64
+	output *wire.MwebOutput
+}
+
+type fakeCoinswapService struct {
+	node *fakeCoinswapNode
+}
+
+func newFakeCoinswapNet(t *testing.T, n int,
+	fetchCoin func(chainhash.Hash) (*wire.MwebOutput, error)) *fakeCoinswapNet {
+
+	net := &fakeCoinswapNet{fetchCoin: fetchCoin}
+	for range n {
+		privKey, err := ecdh.X25519().GenerateKey(rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+		node := &fakeCoinswapNode{net: net, privKey: privKey}
+		server := rpc.NewServer()
+		if err = server.RegisterName("swap", &fakeCoinswapService{node}); err != nil {
+			t.Fatal(err)
+		}
+		node.server = httptest.NewServer(http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				net.mtx.Lock()
+				down := node.down
+				net.mtx.Unlock()
+				if down {
+					w.WriteHeader(http.StatusServiceUnavailable)
+					return
+				}
+				server.ServeHTTP(w, r)
+			}))
+		t.Cleanup(node.server.Close)
+		t.Cleanup(server.Stop)
+		net.nodes = append(net.nodes, node)
+	}
+	return net
+}
+
+// config returns the nodes in the form of ServerArgs.CoinswapNodes.
+func (net *fakeCoinswapNet) config() string {
+	var nodes []string
+	for _, node := range net.nodes {
+		nodes = append(nodes, hex.EncodeToString(
+			node.privKey.PublicKey().Bytes())+"@"+node.server.URL)
+	}
+	return strings.Join(nodes, ",")
+}
+
+func (svc *fakeCoinswapService) Swap(o onion.Onion) error {
+	net := svc.node.net
+	net.mtx.Lock()
+	defer net.mtx.Unlock()
+
+	if svc.node.fail {
+		return errors.New("swap failed")
+	}
+
+	input := &wire.MwebInput{
+		Features:     wire.MwebInputStealthKeyFeatureBit,
+		OutputId:     chainhash.Hash(o.Input.OutputId),
+		Commitment:   mw.Commitment(o.Input.Commitment),
+		InputPubKey:  (*mw.PublicKey)(o.Input.InputPubKey),
+		OutputPubKey: mw.PublicKey(o.Input.OutputPubKey),
+		Signature:    mw.Signature(o.Input.Signature),
+	}
+	output, err := net.fetchCoin(input.OutputId)
+	if err != nil {
+		return err
+	}
+	if input.Commitment != output.Commitment {
+		return errors.New("commitment mismatch")
+	}
+	if input.OutputPubKey != output.ReceiverPubKey {
+		return errors.New("output pubkey mismatch")
+	}
+	if !input.VerifySig() {
+		return errors.New("verify input sig failed")
+	}
+	if !o.VerifySig() {
+		return errors.New("verify onion sig failed")
+	}
+
+	var route []*fakeCoinswapNode
+	for _, node := range net.nodes {
+		if node == svc.node || len(route) > 0 && !node.down {
+			route = append(route, node)
+		}
+	}
+
+	swap := &fakeCoinswap{entry: svc.node, hops: len(route)}
+	commit := &input.Commitment
+	stealthSum := input.OutputPubKey.Sub(input.InputPubKey)
+	next := &o
+	for i, node := range route {
+		hop, peeled, err := next.Peel(node.privKey)
+		if err != nil {
+			return err
+		}
+		next = peeled
+		swap.fee += hop.Fee
+		commit = commit.Add(mw.NewCommitment(&hop.KernelBlind, 0)).
+			Sub(mw.NewCommitment(&mw.BlindingFactor{}, hop.Fee))
+		stealthBlind := mw.SecretKey(hop.StealthBlind)
+		stealthSum = stealthSum.Add(stealthBlind.PubKey())
+
+		if (i == len(route)-1) != (hop.Output != nil) {
+			return errors.New("output on wrong hop")
+		}
+		if hop.Output == nil {
+			continue
+		}
+		var msg bytes.Buffer
+		hop.Output.Message.Serialize(&msg)
+		if *commit != hop.Output.Commitment ||
+			*stealthSum != hop.Output.SenderPubKey ||
+			hop.Output.RangeProof == nil ||
+			!hop.Output.RangeProof.Verify(*commit, msg.Bytes()) ||
+			!hop.Output.VerifySig() {
+			return errors.New("invalid output")
+		}
+		swap.output = hop.Output
+	}
+
+	net.swaps = append(net.swaps, swap)
+	return nil
+}
+
+// newTestServer returns a server with a database and an unstarted
+// chain service, so that utxos can be added to the mempool bucket.
+func newTestServer(t *testing.T) *Server {
+	dir := t.TempDir()
+	s := NewBareServer(chaincfg.RegressionNetParams)
+	s.coinCache = newCoinCache(defaultCoinCacheAccounts, defaultCoinCacheSize)
+
+	var err error
+	s.db, err = walletdb.Create(
+		"bdb", filepath.Join(dir, "neutrino.db"), false, time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { s.db.Close() })
+
+	s.cs, err = neutrino.NewChainService(neutrino.Config{
+		DataDir:     dir,
+		Database:    s.db,
+		ChainParams: s.cp,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+// addTestUtxo adds an output paying the address to the mempool.
+func addTestUtxo(t *testing.T, s *Server, kc *mweb.Keychain,
+	addrIndex uint32, value uint64) *wire.MwebOutput {
+
+	var senderKey mw.SecretKey
+	rand.Read(senderKey[:])
+	recipient := &mweb.Recipient{Value: value, Address: kc.Address(addrIndex)}
+	output, blind, _ := mweb.CreateOutput(recipient, &senderKey)
+	mweb.SignOutput(output, value, blind, &senderKey)
+
+	s.utxoHandler(nil, []*wire.MwebNetUtxo{{
+		Output: output, OutputId: output.Hash(),
+	}})
+	return output
+}
+
+func TestCoinswapEndToEnd(t *testing.T) {
+	s := newTestServer(t)
+	net := newFakeCoinswapNet(t, 3, s.fetchCoin)
+	var err error
+	if s.pinnedCoinswapNodes, err = parseCoinswapNodes(net.config()); err != nil {
+		t.Fatal(err)
+	}
+
+	kc := randKeychain()
+	swap := func(output *wire.MwebOutput, addrIndex uint32) (
+		*proto.CoinswapResponse, error) {
+
+		return s.Coinswap(context.Background(), &proto.CoinswapRequest{
+			ScanSecret:  kc.Scan[:],
+			SpendSecret: bytes.Clone(kc.Spend[:]),
+			OutputId:    hex.EncodeToString(output.Hash()[:]),
+			AddrIndex:   addrIndex,
+			MaxFee:      3 * defaultCoinswapHopFee,
+			MinHops:     2,
+		})
+	}
+	checkSwap := func(swap *fakeCoinswap, entry *fakeCoinswapNode,
+		hops int, outputId string) {
+
+		if swap.entry != entry || swap.hops != hops ||
+			swap.fee != uint64(hops)*defaultCoinswapHopFee ||
+			hex.EncodeToString(swap.output.Hash()[:]) != outputId {
+			t.Fatal("unexpected swap", swap)
+		}
+		coin, err := mweb.RewindOutput(swap.output, kc.Scan)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if coin.Value != 1_000_000-swap.fee {
+			t.Fatal("unexpected swap output value", coin.Value)
+		}
+	}
+
+	output := addTestUtxo(t, s, kc, 2, 1_000_000)
+	resp, err := swap(output, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(net.swaps) != 1 {
+		t.Fatal("swap wasn't accepted")
+	}
+	checkSwap(net.swaps[0], net.nodes[0], 3, resp.OutputId)
+	if _, err = swap(output, 2); err == nil {
+		t.Fatal("expected coinswap already pending error")
+	}
+
+	// The failed submission isn't retried straight away, but is
+	// left for checkCoinswaps at its scheduled time.
+	net.nodes[0].fail = true
+	output = addTestUtxo(t, s, kc, 3, 1_000_000)
+	if resp, err = swap(output, 3); err != nil {
+		t.Fatal(err)
+	}
+	if len(net.swaps) != 1 || resp.OutputId != "" {
+		t.Fatal("swap shouldn't be retried straight away")
+	}
+	status, err := s.CoinswapStatus(context.Background(),
+		&proto.CoinswapStatusRequest{OutputId: hex.EncodeToString(output.Hash()[:])})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if status.State != proto.CoinswapState_COINSWAP_PENDING ||
+		status.Attempts != 1 || status.Error == "" || status.SubmittedTime != 0 {
+		t.Fatal("unexpected status", status)
+	}
+	records, err := s.getCoinswaps(output.Hash()[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	records[0].Scheduled = time.Now().Unix()
+	if err = s.putCoinswap(records[0]); err != nil {
+		t.Fatal(err)
+	}
+	s.checkCoinswaps()
+	if len(net.swaps) != 2 {
+		t.Fatal("swap wasn't retried")
+	}
+	status, err = s.CoinswapStatus(context.Background(),
+		&proto.CoinswapStatusRequest{OutputId: hex.EncodeToString(output.Hash()[:])})
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkSwap(net.swaps[1], net.nodes[1], 2, status.SwapOutputId)
+	if status.State != proto.CoinswapState_COINSWAP_PENDING ||
+		status.Attempts != 2 || status.Hops != 2 || status.Error != "" ||
+		status.EntryNode != net.nodes[1].server.URL {
+		t.Fatal("unexpected status", status)
+	}
+
+	net.nodes[0].fail = false
+	net.nodes[1].down = true
+	output = addTestUtxo(t, s, kc, 4, 1_000_000)
+	if resp, err = swap(output, 4); err != nil {
+		t.Fatal(err)
+	}
+	checkSwap(net.swaps[2], net.nodes[0], 2, resp.OutputId)
+
+	net.nodes[2].down = true
+	output = addTestUtxo(t, s, kc, 5, 1_000_000)
+	if _, err = swap(output, 5); err == nil {
+		t.Fatal("expected min hops error")
+	}
+	if len(net.swaps) != 3 {
+		t.Fatal("swap shouldn't be submitted")
+	}
+}
+
+func TestCoinswapBatch(t *testing.T) {
+	s := newTestServer(t)
+	net := newFakeCoinswapNet(t, 2, s.fetchCoin)
+	var err error
+	if s.pinnedCoinswapNodes, err = parseCoinswapNodes(net.config()); err != nil {
+		t.Fatal(err)
+	}
+
+	kc := randKeychain()
+	req := &proto.CoinswapBatchRequest{
+		ScanSecret:  kc.Scan[:],
+		SpendSecret: bytes.Clone(kc.Spend[:]),
+	}
+	for i := range uint32(2) {
+		output := addTestUtxo(t, s, kc, i, 1_000_000)
+		req.Inputs = append(req.Inputs, &proto.CoinswapBatchInput{
+			OutputId:  hex.EncodeToString(output.Hash()[:]),
+			AddrIndex: i,
+		})
+	}
+	req.Inputs = append(req.Inputs, req.Inputs[0])
+
+	now := time.Now()
+	resp, err := s.CoinswapBatch(context.Background(), req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(net.swaps) != 0 {
+		t.Fatal("swaps shouldn't be submitted straight away")
+	}
+	round := now.UTC().Truncate(24 * time.Hour).Add(24 * time.Hour).Unix()
+	for _, result := range resp.Results[:2] {
+		if result.Error != "" || result.OutputId == "" ||
+			result.ScheduledTime < now.Unix() || result.ScheduledTime > round {
+			t.Fatal("swap should be scheduled before the next round", result)
+		}
+	}
+	if resp.Results[2].Error == "" {
+		t.Fatal("expected coinswap already pending error")
+	}
+
+	records, err := s.getCoinswaps(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, r := range records {
+		r.Scheduled = now.Unix()
+		if err = s.putCoinswap(r); err != nil {
+			t.Fatal(err)
+		}
+	}
+	s.checkCoinswaps()
+	if len(net.swaps) != 2 {
+		t.Fatal("scheduled swaps weren't submitted")
+	}
+	for i, result := range resp.Results[:2] {
+		status, err := s.CoinswapStatus(context.Background(),
+			&proto.CoinswapStatusRequest{OutputId: req.Inputs[i].OutputId})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if status.ScheduledTime != 0 || status.SubmittedTime == 0 ||
+			status.SwapOutputId != result.OutputId {
+			t.Fatal("unexpected status", status)
+		}
+	}
+}
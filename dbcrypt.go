@@ -0,0 +1,420 @@
+package mwebd
+
+import (
+	"bytes"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"sync"
+
+	"github.com/ltcmweb/mwebd/proto"
+	"github.com/ltcsuite/ltcwallet/walletdb"
+	"golang.org/x/crypto/scrypt"
+)
+
+// The daemon's own buckets may be encrypted with a random database
+// key, which is kept in the crypt bucket encrypted with a key derived
+// from a passphrase. Entries are stored under an HMAC of their key,
+// with the key and value sealed by AES-GCM, so that neither reveals
+// the outputs that the wallet is interested in. Neutrino's buckets
+// only hold public chain data, and are left unencrypted so that
+// syncing isn't slowed down.
+var cryptBucket = []byte("mweb-crypt")
+
+var (
+	cryptParamsKey = []byte("params")
+	cryptDBKeyKey  = []byte("key")
+)
+
+// daemonBuckets are the buckets that are encrypted.
+var daemonBuckets = [][]byte{
+	mempoolBucket, peginsBucket, pegoutsBucket, coinswapsBucket,
+}
+
+// scryptParams are the parameters that the passphrase key is derived
+// with. They're stored with the database key, so that the default
+// can be raised without breaking existing databases.
+type scryptParams struct {
+	N, R, P uint32
+	Salt    [16]byte
+}
+
+var defaultScryptParams = scryptParams{N: 1 << 15, R: 8, P: 1}
+
+const dbKeySize = 64
+
+var errDBLocked = errors.New("database is locked")
+
+// dbCrypt is the encryption state of the database.
+type dbCrypt struct {
+	// mtx is held for reading by transactions on the daemon's
+	// buckets, and for writing while the database key changes.
+	mtx     sync.RWMutex
+	enabled bool
+	key     *dbKey
+
+	// started is set once the chain service has been started after
+	// unlocking, so that Unlock can be retried if starting it failed.
+	started bool
+
+	// unlockMtx serializes calls to Unlock, so that the chain service
+	// isn't started by two of them at once.
+	unlockMtx sync.Mutex
+}
+
+// dbKey encrypts the entries of a bucket. The first half of the key
+// is for AES-GCM and the second half for the HMAC of entry keys.
+type dbKey struct {
+	aead cipher.AEAD
+	mac  []byte
+}
+
+func newDBKey(b []byte) (*dbKey, error) {
+	if len(b) != dbKeySize {
+		return nil, errors.New("invalid database key")
+	}
+	block, err := aes.NewCipher(b[:dbKeySize/2])
+	if err != nil {
+		return nil, err
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	return &dbKey{aead: aead, mac: bytes.Clone(b[dbKeySize/2:])}, nil
+}
+
+// passphraseKey derives the key that the database key is encrypted
+// with.
+func passphraseKey(passphrase string, params *scryptParams) (*dbKey, error) {
+	b, err := scrypt.Key([]byte(passphrase), params.Salt[:],
+		int(params.N), int(params.R), int(params.P), dbKeySize)
+	if err != nil {
+		return nil, err
+	}
+	defer clear(b)
+	return newDBKey(b)
+}
+
+// name returns the key that an entry is stored under.
+func (k *dbKey) name(key []byte) []byte {
+	h := hmac.New(sha256.New, k.mac)
+	h.Write(key)
+	return h.Sum(nil)
+}
+
+// seal encrypts an entry, binding it to the name it's stored under.
+func (k *dbKey) seal(name, key, value []byte) []byte {
+	b := binary.AppendUvarint(nil, uint64(len(key)))
+	b = append(append(b, key...), value...)
+	nonce := make([]byte, k.aead.NonceSize(),
+		k.aead.NonceSize()+len(b)+k.aead.Overhead())
+	rand.Read(nonce)
+	return k.aead.Seal(nonce, nonce, b, name)
+}
+
+func (k *dbKey) open(name, sealed []byte) (key, value []byte, err error) {
+	errInvalid := errors.New("invalid encrypted entry")
+	n := k.aead.NonceSize()
+	if len(sealed) < n {
+		return nil, nil, errInvalid
+	}
+	b, err := k.aead.Open(nil, sealed[:n], sealed[n:], name)
+	if err != nil {
+		return nil, nil, errInvalid
+	}
+	keyLen, m := binary.Uvarint(b)
+	if m <= 0 || keyLen > uint64(len(b)-m) {
+		return nil, nil, errInvalid
+	}
+	return b[m : m+int(keyLen)], b[m+int(keyLen):], nil
+}
+
+// bucket is one of the daemon's buckets, whose entries are encrypted
+// when the database is.
+type bucket struct {
+	r   walletdb.ReadBucket
+	w   walletdb.ReadWriteBucket
+	key *dbKey
+}
+
+func (b *bucket) Get(k []byte) ([]byte, error) {
+	if b.key == nil {
+		return b.r.Get(k), nil
+	}
+	name := b.key.name(k)
+	sealed := b.r.Get(name)
+	if sealed == nil {
+		return nil, nil
+	}
+	_, v, err := b.key.open(name, sealed)
+	return v, err
+}
+
+func (b *bucket) Put(k, v []byte) error {
+	if b.key == nil {
+		return b.w.Put(k, v)
+	}
+	name := b.key.name(k)
+	return b.w.Put(name, b.key.seal(name, k, v))
+}
+
+func (b *bucket) Delete(k []byte) error {
+	if b.key != nil {
+		k = b.key.name(k)
+	}
+	return b.w.Delete(k)
+}
+
+func (b *bucket) ForEach(fn func(k, v []byte) error) error {
+	if b.key == nil {
+		return b.r.ForEach(fn)
+	}
+	return b.r.ForEach(func(name, sealed []byte) error {
+		k, v, err := b.key.open(name, sealed)
+		if err != nil {
+			return err
+		}
+		return fn(k, v)
+	})
+}
+
+// view runs a read transaction that uses the daemon's buckets.
+func (s *Server) view(fn func(dbtx walletdb.ReadTx) error) error {
+	s.crypt.mtx.RLock()
+	defer s.crypt.mtx.RUnlock()
+	return walletdb.View(s.db, fn)
+}
+
+// update runs a write transaction that uses the daemon's buckets.
+func (s *Server) update(fn func(dbtx walletdb.ReadWriteTx) error) error {
+	s.crypt.mtx.RLock()
+	defer s.crypt.mtx.RUnlock()
+	return walletdb.Update(s.db, fn)
+}
+
+// readBucket returns one of the daemon's buckets, or nil if it
+// doesn't exist. It's called within view or update.
+func (s *Server) readBucket(dbtx walletdb.ReadTx, name []byte) (*bucket, error) {
+	if s.crypt.enabled && s.crypt.key == nil {
+		return nil, errDBLocked
+	}
+	r := dbtx.ReadBucket(name)
+	if r == nil {
+		return nil, nil
+	}
+	return &bucket{r: r, key: s.crypt.key}, nil
+}
+
+// writeBucket returns one of the daemon's buckets, creating it if it
+// doesn't exist. It's called within update.
+func (s *Server) writeBucket(dbtx walletdb.ReadWriteTx, name []byte) (*bucket, error) {
+	if s.crypt.enabled && s.crypt.key == nil {
+		return nil, errDBLocked
+	}
+	w, err := dbtx.CreateTopLevelBucket(name)
+	if err != nil {
+		return nil, err
+	}
+	return &bucket{r: w, w: w, key: s.crypt.key}, nil
+}
+
+// initCrypt enables encryption if it's requested or the database is
+// already encrypted, in which case it stays locked until unlocked
+// with the passphrase.
+func (s *Server) initCrypt(encrypt bool) error {
+	s.crypt.mtx.Lock()
+	defer s.crypt.mtx.Unlock()
+	return walletdb.View(s.db, func(dbtx walletdb.ReadTx) error {
+		s.crypt.enabled = encrypt || dbtx.ReadBucket(cryptBucket) != nil
+		return nil
+	})
+}
+
+func (s *Server) dbLocked() bool {
+	s.crypt.mtx.RLock()
+	defer s.crypt.mtx.RUnlock()
+	return s.crypt.enabled && s.crypt.key == nil
+}
+
+func (s *Server) Unlock(ctx context.Context,
+	req *proto.UnlockRequest) (*proto.UnlockResponse, error) {
+
+	s.crypt.unlockMtx.Lock()
+	defer s.crypt.unlockMtx.Unlock()
+
+	if err := s.unlockDB(req.Passphrase); err != nil {
+		return nil, err
+	}
+	if err := s.cs.Start(); err != nil {
+		return nil, err
+	}
+	s.crypt.mtx.Lock()
+	s.crypt.started = true
+	s.crypt.mtx.Unlock()
+	return &proto.UnlockResponse{}, nil
+}
+
+// unlockDB unlocks the database with the passphrase. On the first
+// unlock, the database key is generated and the existing entries are
+// encrypted. If the database is unlocked but the chain service wasn't
+// started, only the passphrase is checked so that starting it can be
+// retried.
+func (s *Server) unlockDB(passphrase string) error {
+	s.crypt.mtx.Lock()
+	defer s.crypt.mtx.Unlock()
+
+	if !s.crypt.enabled {
+		return errors.New("database encryption isn't enabled")
+	}
+	if s.crypt.key != nil && s.crypt.started {
+		return errors.New("database is already unlocked")
+	}
+	if passphrase == "" {
+		return errors.New("empty passphrase")
+	}
+	if s.crypt.key != nil {
+		return walletdb.View(s.db, func(dbtx walletdb.ReadTx) error {
+			_, err := getDBKey(dbtx.ReadBucket(cryptBucket), passphrase)
+			return err
+		})
+	}
+
+	var key *dbKey
+	err := walletdb.Update(s.db, func(dbtx walletdb.ReadWriteTx) (err error) {
+		if cb := dbtx.ReadBucket(cryptBucket); cb != nil {
+			key, err = getDBKey(cb, passphrase)
+			return
+		}
+		if key, err = putDBKey(dbtx, passphrase); err != nil {
+			return
+		}
+		return reencryptBuckets(dbtx, nil, key)
+	})
+	if err != nil {
+		return err
+	}
+	s.crypt.key = key
+	return nil
+}
+
+func (s *Server) RotateKey(ctx context.Context,
+	req *proto.RotateKeyRequest) (*proto.RotateKeyResponse, error) {
+
+	s.crypt.mtx.Lock()
+	defer s.crypt.mtx.Unlock()
+
+	if !s.crypt.enabled {
+		return nil, errors.New("database encryption isn't enabled")
+	}
+	if s.crypt.key == nil {
+		return nil, errDBLocked
+	}
+	if req.NewPassphrase == "" {
+		return nil, errors.New("empty passphrase")
+	}
+
+	var key *dbKey
+	err := walletdb.Update(s.db, func(dbtx walletdb.ReadWriteTx) error {
+		oldKey, err := getDBKey(dbtx.ReadBucket(cryptBucket), req.OldPassphrase)
+		if err != nil {
+			return err
+		}
+		if key, err = putDBKey(dbtx, req.NewPassphrase); err != nil {
+			return err
+		}
+		return reencryptBuckets(dbtx, oldKey, key)
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.crypt.key = key
+	return &proto.RotateKeyResponse{}, nil
+}
+
+// getDBKey decrypts the database key with the passphrase.
+func getDBKey(cb walletdb.ReadBucket, passphrase string) (*dbKey, error) {
+	var params scryptParams
+	err := binary.Read(bytes.NewReader(cb.Get(cryptParamsKey)),
+		binary.LittleEndian, &params)
+	if err != nil {
+		return nil, err
+	}
+	pk, err := passphraseKey(passphrase, &params)
+	if err != nil {
+		return nil, err
+	}
+	_, b, err := pk.open(cryptDBKeyKey, cb.Get(cryptDBKeyKey))
+	if err != nil {
+		return nil, errors.New("wrong passphrase")
+	}
+	defer clear(b)
+	return newDBKey(b)
+}
+
+// putDBKey generates a new database key, and stores it encrypted
+// with the passphrase.
+func putDBKey(dbtx walletdb.ReadWriteTx, passphrase string) (*dbKey, error) {
+	params := defaultScryptParams
+	rand.Read(params.Salt[:])
+	pk, err := passphraseKey(passphrase, &params)
+	if err != nil {
+		return nil, err
+	}
+	b := make([]byte, dbKeySize)
+	rand.Read(b)
+	defer clear(b)
+
+	cb, err := dbtx.CreateTopLevelBucket(cryptBucket)
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	binary.Write(&buf, binary.LittleEndian, &params)
+	if err = cb.Put(cryptParamsKey, buf.Bytes()); err != nil {
+		return nil, err
+	}
+	if err = cb.Put(cryptDBKeyKey, pk.seal(cryptDBKeyKey, nil, b)); err != nil {
+		return nil, err
+	}
+	return newDBKey(b)
+}
+
+// reencryptBuckets rewrites the entries of the daemon's buckets from
+// the old key to the new key. A nil old key is for plaintext entries.
+func reencryptBuckets(dbtx walletdb.ReadWriteTx, oldKey, newKey *dbKey) error {
+	for _, name := range daemonBuckets {
+		r := dbtx.ReadBucket(name)
+		if r == nil {
+			continue
+		}
+		var keys, values [][]byte
+		err := (&bucket{r: r, key: oldKey}).ForEach(func(k, v []byte) error {
+			keys = append(keys, bytes.Clone(k))
+			values = append(values, bytes.Clone(v))
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		if err = dbtx.DeleteTopLevelBucket(name); err != nil {
+			return err
+		}
+		w, err := dbtx.CreateTopLevelBucket(name)
+		if err != nil {
+			return err
+		}
+		b := &bucket{r: w, w: w, key: newKey}
+		for i, k := range keys {
+			if err = b.Put(k, values[i]); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,130 @@
+package mwebd
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"testing"
+
+	"github.com/ltcmweb/ltcd/chaincfg/chainhash"
+	"github.com/ltcmweb/mwebd/proto"
+	"github.com/ltcsuite/ltcwallet/walletdb"
+)
+
+func TestDBCrypt(t *testing.T) {
+	s := newTestServer(t)
+	kc := randKeychain()
+	output := addTestUtxo(t, s, kc, 1, 100_000)
+	outputId := *output.Hash()
+	if err := s.putCoinswap(&coinswapRecord{
+		InputId: hex.EncodeToString(outputId[:]),
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	check := func() {
+		if _, err := s.fetchCoin(outputId); err != nil {
+			t.Fatal(err)
+		}
+		if found, err := s.inMempool([]chainhash.Hash{outputId}); !found || err != nil {
+			t.Fatal("expected output in mempool", err)
+		}
+		records, err := s.getCoinswaps(outputId[:])
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(records) != 1 {
+			t.Fatal("expected coinswap record")
+		}
+		if records, err = s.getCoinswaps(nil); err != nil || len(records) != 1 ||
+			records[0].InputId != hex.EncodeToString(outputId[:]) {
+			t.Fatal("unexpected coinswap records", err)
+		}
+	}
+	check()
+	lock := func() {
+		s.crypt.mtx.Lock()
+		s.crypt.key = nil
+		s.crypt.mtx.Unlock()
+	}
+
+	// Encryption is enabled on restart, leaving the database locked.
+	if err := s.initCrypt(true); err != nil {
+		t.Fatal(err)
+	}
+	if !s.dbLocked() {
+		t.Fatal("expected database to be locked")
+	}
+	if _, err := s.getCoinswaps(nil); err != errDBLocked {
+		t.Fatal("expected locked error", err)
+	}
+	if err := s.unlockDB(""); err == nil {
+		t.Fatal("expected empty passphrase error")
+	}
+	if err := s.unlockDB("hunter2"); err != nil {
+		t.Fatal(err)
+	}
+	check()
+
+	// Until the chain service is started, unlocking again only
+	// checks the passphrase, so that starting it can be retried.
+	if err := s.unlockDB("hunter3"); err == nil {
+		t.Fatal("expected wrong passphrase error")
+	}
+	if err := s.unlockDB("hunter2"); err != nil {
+		t.Fatal(err)
+	}
+	s.crypt.started = true
+	if err := s.unlockDB("hunter2"); err == nil {
+		t.Fatal("expected already unlocked error")
+	}
+
+	// Existing entries were encrypted, so the output ID appears in
+	// neither the keys nor the values.
+	err := walletdb.View(s.db, func(dbtx walletdb.ReadTx) error {
+		for _, name := range [][]byte{mempoolBucket, coinswapsBucket} {
+			dbtx.ReadBucket(name).ForEach(func(k, v []byte) error {
+				if bytes.Contains(k, outputId[:]) ||
+					bytes.Contains(v, outputId[:]) ||
+					bytes.Contains(v, []byte(hex.EncodeToString(outputId[:]))) {
+					t.Fatal("plaintext entry in", string(name))
+				}
+				return nil
+			})
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	lock()
+	if err = s.unlockDB("hunter3"); err == nil {
+		t.Fatal("expected wrong passphrase error")
+	}
+	if err = s.unlockDB("hunter2"); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	if _, err = s.RotateKey(ctx, &proto.RotateKeyRequest{
+		OldPassphrase: "hunter3", NewPassphrase: "hunter4",
+	}); err == nil {
+		t.Fatal("expected wrong passphrase error")
+	}
+	if _, err = s.RotateKey(ctx, &proto.RotateKeyRequest{
+		OldPassphrase: "hunter2", NewPassphrase: "hunter4",
+	}); err != nil {
+		t.Fatal(err)
+	}
+	check()
+
+	lock()
+	if err = s.unlockDB("hunter2"); err == nil {
+		t.Fatal("expected old passphrase to be rejected")
+	}
+	if err = s.unlockDB("hunter4"); err != nil {
+		t.Fatal(err)
+	}
+	check()
+}
@@ -0,0 +1,105 @@
+package mwebd
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/ltcmweb/ltcd/ltcutil/mweb/mw"
+	"github.com/ltcmweb/ltcd/ltcutil/psbt"
+	"github.com/ltcmweb/mwebd/proto"
+)
+
+// The size of the HogEx input that spends a peg-in output:
+// the outpoint (36), an empty script sig (1) and the sequence (4).
+const hogexInputSize = 41
+
+// feeEstimate is a breakdown of the fees added by the MWEB
+// portion of a transaction.
+type feeEstimate struct {
+	// The fee for the weight of the MWEB components.
+	mwebFee uint64
+
+	// The fee for the size of the peg-out TxOuts, which end
+	// up on the HogEx transaction.
+	pegoutFee uint64
+
+	// The fee committed to by the kernel. This can exceed
+	// mwebFee+pegoutFee when the MWEB inputs leave no room
+	// for change.
+	fee uint64
+
+	// The peg-in required to fund any shortfall.
+	pegin uint64
+
+	// The fee for the HogEx input spending the peg-in output.
+	hogexFee uint64
+}
+
+func divCeil(x, y uint64) uint64 { return (x + y - 1) / y }
+
+func newFeeEstimate(mwebFee, pegoutFee,
+	inputs, outputs, feeRatePerKb uint64) *feeEstimate {
+
+	est := &feeEstimate{
+		mwebFee:   mwebFee,
+		pegoutFee: pegoutFee,
+		fee:       mwebFee + pegoutFee,
+	}
+	if outputs+est.fee > inputs {
+		est.pegin = outputs + est.fee - inputs
+		est.hogexFee = divCeil(hogexInputSize*feeRatePerKb, 1000)
+	} else {
+		est.fee = inputs - outputs
+	}
+	return est
+}
+
+func (s *Server) EstimateFee(ctx context.Context,
+	req *proto.EstimateFeeRequest) (*proto.EstimateFeeResponse, error) {
+
+	var est *feeEstimate
+	switch {
+	case req.PsbtB64 != "":
+		p, err := psbt.NewFromRawBytes(strings.NewReader(req.PsbtB64), true)
+		if err != nil {
+			return nil, err
+		}
+		s.adjustKernel(p, req.FeeRatePerKb)
+		est = &feeEstimate{}
+		est.mwebFee, est.pegoutFee = s.calcFeeParts(p, req.FeeRatePerKb)
+		for _, pKernel := range p.Kernels {
+			if pKernel.Fee != nil {
+				est.fee += uint64(*pKernel.Fee)
+			}
+			if pKernel.PeginAmount != nil {
+				est.pegin += uint64(*pKernel.PeginAmount)
+			}
+		}
+		if est.pegin > 0 {
+			est.hogexFee = divCeil(hogexInputSize*req.FeeRatePerKb, 1000)
+		}
+
+	case req.RawTx != nil:
+		t, err := s.parseTemplate(req.RawTx, (*mw.SecretKey)(req.ScanSecret))
+		if err != nil {
+			return nil, err
+		}
+		if len(t.coins) == 0 && len(t.recipients) == 0 {
+			return &proto.EstimateFeeResponse{}, nil
+		}
+		est = t.estimateFee(req.FeeRatePerKb)
+
+	default:
+		return nil, errors.New("either raw tx or psbt is required")
+	}
+
+	return &proto.EstimateFeeResponse{
+		MwebFee:   est.mwebFee,
+		PegoutFee: est.pegoutFee,
+		KernelFee: est.fee,
+		Pegin:     est.pegin,
+		HogexFee:  est.hogexFee,
+		TotalFee:  est.fee + est.hogexFee,
+	}, nil
+}
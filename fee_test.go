@@ -0,0 +1,87 @@
+package mwebd
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ltcmweb/ltcd/chaincfg"
+	"github.com/ltcmweb/ltcd/ltcutil"
+	"github.com/ltcmweb/ltcd/txscript"
+	"github.com/ltcmweb/ltcd/wire"
+	"github.com/ltcmweb/mwebd/proto"
+)
+
+func TestFeeEstimate(t *testing.T) {
+	est := newFeeEstimate(500, 32, 1000, 2000, 1500)
+	if est.fee != 532 || est.pegin != 1532 {
+		t.Fatal("unexpected fee or pegin")
+	}
+	if est.hogexFee != 62 {
+		t.Fatal("unexpected hogex fee", est.hogexFee)
+	}
+
+	est = newFeeEstimate(500, 32, 5000, 2000, 1500)
+	if est.fee != 3000 || est.pegin != 0 || est.hogexFee != 0 {
+		t.Fatal("excess inputs should go to the fee")
+	}
+}
+
+func TestEstimateFeeTemplateMatchesPsbt(t *testing.T) {
+	const feeRatePerKb = 10_000
+
+	s := NewBareServer(chaincfg.MainNetParams)
+	ctx := context.Background()
+
+	mwebAddr := ltcutil.NewAddressMweb(randKeychain().Address(0), &s.cp)
+	pegoutAddr, err := ltcutil.NewAddressWitnessPubKeyHash(make([]byte, 20), &s.cp)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	p, err := s.PsbtCreate(ctx, &proto.PsbtCreateRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t2 := &createTemplate{}
+	for _, r := range []*proto.PsbtRecipient{
+		{Address: mwebAddr.String(), Value: 50_000},
+		{Address: pegoutAddr.String(), Value: 20_000},
+	} {
+		p, err = s.PsbtAddRecipient(ctx, &proto.PsbtAddRecipientRequest{
+			PsbtB64:      p.PsbtB64,
+			Recipient:    r,
+			FeeRatePerKb: feeRatePerKb,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		addr, _ := ltcutil.DecodeAddress(r.Address, &s.cp)
+		pkScript, _ := txscript.PayToAddrScript(addr)
+		t2.txOuts = append(t2.txOuts, wire.NewTxOut(r.Value, pkScript))
+		t2.sumOutputs += uint64(r.Value)
+	}
+
+	resp, err := s.EstimateFee(ctx, &proto.EstimateFeeRequest{
+		PsbtB64:      p.PsbtB64,
+		FeeRatePerKb: feeRatePerKb,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Pegin != 70_000+resp.KernelFee {
+		t.Fatal("pegin should cover outputs and fee")
+	}
+	if resp.HogexFee != hogexInputSize*feeRatePerKb/1000 {
+		t.Fatal("unexpected hogex fee")
+	}
+	if resp.TotalFee != resp.KernelFee+resp.HogexFee {
+		t.Fatal("unexpected total fee")
+	}
+
+	est := t2.estimateFee(feeRatePerKb)
+	if est.mwebFee != resp.MwebFee || est.pegoutFee != resp.PegoutFee ||
+		est.fee != resp.KernelFee || est.pegin != resp.Pegin ||
+		est.hogexFee != resp.HogexFee {
+		t.Fatal("template and psbt estimates differ")
+	}
+}
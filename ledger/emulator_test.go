@@ -0,0 +1,224 @@
+package ledger
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/ltcmweb/ltcd/chaincfg/chainhash"
+	"github.com/ltcmweb/ltcd/ltcutil/mweb"
+	"github.com/ltcmweb/ltcd/ltcutil/mweb/mw"
+	"github.com/ltcmweb/ltcd/wire"
+)
+
+// emulator is a pure-Go stand-in for the MWEB app on a Ledger device.
+// It holds the account keychain and answers the CLA_MWEB instructions
+// the same way the device does, keeping the running sums of blinding
+// factors and keys needed to sign the kernel at the end.
+type emulator struct {
+	kc *mweb.Keychain
+
+	inputBlind  mw.BlindingFactor
+	inputKey    mw.SecretKey
+	outputBlind mw.BlindingFactor
+	outputKey   mw.SecretKey
+	output      *wire.MwebOutput
+	senderKey   *mw.SecretKey
+
+	fee, pegin uint64
+	lockHeight uint32
+	numPegouts int
+	pegouts    []*wire.TxOut
+}
+
+func newEmulator(kc *mweb.Keychain) *emulator {
+	return &emulator{kc: kc}
+}
+
+func (e *emulator) exchange(apdu []byte) ([]byte, error) {
+	if len(apdu) < 5 || len(apdu) != 5+int(apdu[4]) {
+		return nil, errors.New("bad apdu length")
+	}
+	if apdu[0] != CLA_MWEB {
+		return nil, errors.New("bad cla")
+	}
+	r := bytes.NewReader(apdu[5:])
+	switch apdu[1] {
+	case INS_MWEB_ADD_INPUT:
+		return e.addInput(r)
+	case INS_MWEB_ADD_OUTPUT:
+		return e.addOutput(r)
+	case INS_MWEB_SIGN_OUTPUT:
+		return e.signOutput(r)
+	case INS_MWEB_SIGN_KERNEL:
+		switch {
+		case apdu[2] == 1:
+			return e.initKernel(r)
+		case len(e.pegouts) < e.numPegouts:
+			return e.addPegout(r)
+		default:
+			return e.signKernel()
+		}
+	}
+	return nil, fmt.Errorf("unknown ins %#x", apdu[1])
+}
+
+func (e *emulator) addInput(r io.Reader) ([]byte, error) {
+	var req struct {
+		Blind        mw.BlindingFactor
+		Value        uint64
+		OutputId     chainhash.Hash
+		AddrIndex    uint64
+		SharedSecret mw.SecretKey
+	}
+	if err := binary.Read(r, binary.LittleEndian, &req); err != nil {
+		return nil, err
+	}
+	coin := &mweb.Coin{
+		Blind:        &req.Blind,
+		Value:        req.Value,
+		OutputId:     &req.OutputId,
+		SharedSecret: &req.SharedSecret,
+	}
+	coin.CalculateOutputKey(e.kc.SpendKey(uint32(req.AddrIndex)))
+
+	var inputKey mw.SecretKey
+	if _, err := rand.Read(inputKey[:]); err != nil {
+		return nil, err
+	}
+	input := mweb.CreateInput(coin, &inputKey)
+	e.inputBlind = *e.inputBlind.Add(mw.BlindSwitch(coin.Blind, coin.Value))
+	e.inputKey = *e.inputKey.Add(&inputKey).Sub(coin.SpendKey)
+
+	var buf bytes.Buffer
+	binary.Write(&buf, binary.LittleEndian, input.Features)
+	buf.Write(input.OutputId[:])
+	buf.Write(input.Commitment[:])
+	buf.Write(input.InputPubKey[:])
+	buf.Write(input.OutputPubKey[:])
+	buf.Write(input.Signature[:])
+	return buf.Bytes(), nil
+}
+
+func (e *emulator) addOutput(r io.Reader) ([]byte, error) {
+	var req struct {
+		Value uint64
+		A, B  [65]byte
+	}
+	if err := binary.Read(r, binary.LittleEndian, &req); err != nil {
+		return nil, err
+	}
+	pA, err := secp256k1.ParsePubKey(req.A[:])
+	if err != nil {
+		return nil, err
+	}
+	pB, err := secp256k1.ParsePubKey(req.B[:])
+	if err != nil {
+		return nil, err
+	}
+	recipient := &mweb.Recipient{
+		Value: req.Value,
+		Address: &mw.StealthAddress{
+			Scan:  (*mw.PublicKey)(pA.SerializeCompressed()),
+			Spend: (*mw.PublicKey)(pB.SerializeCompressed()),
+		},
+	}
+
+	e.senderKey = &mw.SecretKey{}
+	if _, err = rand.Read(e.senderKey[:]); err != nil {
+		return nil, err
+	}
+	output, blind, shared := mweb.CreateOutput(recipient, e.senderKey)
+	e.output = output
+	e.outputBlind = *e.outputBlind.Add(mw.BlindSwitch(blind, req.Value))
+	e.outputKey = *e.outputKey.Add(e.senderKey)
+
+	var buf bytes.Buffer
+	buf.Write(output.Commitment[:])
+	buf.Write(output.SenderPubKey[:])
+	buf.Write(output.ReceiverPubKey[:])
+	if err = output.Message.Serialize(&buf); err != nil {
+		return nil, err
+	}
+	buf.Write(blind[:])
+	buf.Write(shared[:])
+	return buf.Bytes(), nil
+}
+
+func (e *emulator) signOutput(r io.Reader) ([]byte, error) {
+	if e.output == nil {
+		return nil, errors.New("no output to sign")
+	}
+	_, err := io.ReadFull(r, e.output.RangeProofHash[:])
+	if err != nil {
+		return nil, err
+	}
+	sig := mw.Sign(e.senderKey, e.output.SigMsg())
+	e.output, e.senderKey = nil, nil
+	return sig[:], nil
+}
+
+func (e *emulator) initKernel(r io.Reader) ([]byte, error) {
+	var req struct {
+		Fee, Pegin uint64
+		NumPegouts uint16
+		LockHeight uint32
+	}
+	if err := binary.Read(r, binary.LittleEndian, &req); err != nil {
+		return nil, err
+	}
+	e.fee, e.pegin = req.Fee, req.Pegin
+	e.numPegouts = int(req.NumPegouts)
+	e.lockHeight = req.LockHeight
+	e.pegouts = nil
+	return nil, nil
+}
+
+func (e *emulator) addPegout(r io.Reader) ([]byte, error) {
+	var req struct {
+		Value uint64
+		Len   byte
+	}
+	if err := binary.Read(r, binary.LittleEndian, &req); err != nil {
+		return nil, err
+	}
+	pkScript := make([]byte, req.Len)
+	if _, err := io.ReadFull(r, pkScript); err != nil {
+		return nil, err
+	}
+	e.pegouts = append(e.pegouts, wire.NewTxOut(int64(req.Value), pkScript))
+	return nil, nil
+}
+
+func (e *emulator) signKernel() ([]byte, error) {
+	var kernelOffset, stealthBlind mw.BlindingFactor
+	if _, err := rand.Read(kernelOffset[:]); err != nil {
+		return nil, err
+	}
+	if _, err := rand.Read(stealthBlind[:]); err != nil {
+		return nil, err
+	}
+	kernelBlind := e.outputBlind.Sub(&kernelOffset).Sub(&e.inputBlind)
+
+	var lockHeight *int32
+	if e.lockHeight > 0 {
+		h := int32(e.lockHeight)
+		lockHeight = &h
+	}
+	kernel := mweb.CreateKernel(kernelBlind, &stealthBlind,
+		&e.fee, &e.pegin, e.pegouts, lockHeight)
+	stealthOffset := (*mw.BlindingFactor)(e.outputKey.Add(&e.inputKey)).Sub(&stealthBlind)
+
+	var buf bytes.Buffer
+	buf.Write(kernelOffset[:])
+	buf.Write(stealthOffset[:])
+	binary.Write(&buf, binary.LittleEndian, kernel.Features)
+	buf.Write(kernel.Excess[:])
+	buf.Write(kernel.StealthExcess[:])
+	buf.Write(kernel.Signature[:])
+	return buf.Bytes(), nil
+}
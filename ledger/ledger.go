@@ -23,6 +23,7 @@ type (
 		Recipients []*mweb.Recipient
 		Fee, Pegin uint64
 		Pegouts    []*wire.TxOut
+		LockHeight int32
 
 		state    txState
 		inputs   []*wire.MwebInput
@@ -0,0 +1,271 @@
+package ledger
+
+import (
+	"crypto/rand"
+	"io"
+	"testing"
+
+	"github.com/ltcmweb/ltcd/ltcutil/mweb"
+	"github.com/ltcmweb/ltcd/ltcutil/mweb/mw"
+	"github.com/ltcmweb/ltcd/wire"
+	"lukechampine.com/blake3"
+)
+
+func randKeychain(t *testing.T) *mweb.Keychain {
+	var scan, spend mw.SecretKey
+	if _, err := rand.Read(scan[:]); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := rand.Read(spend[:]); err != nil {
+		t.Fatal(err)
+	}
+	return &mweb.Keychain{Scan: &scan, Spend: &spend}
+}
+
+// newCoin creates an output paying kc at addrIndex and rewinds it,
+// giving a coin as the wallet would see it in the utxo set.
+func newCoin(t *testing.T, kc *mweb.Keychain,
+	addrIndex uint32, value uint64) *mweb.Coin {
+
+	var senderKey mw.SecretKey
+	if _, err := rand.Read(senderKey[:]); err != nil {
+		t.Fatal(err)
+	}
+	recipient := &mweb.Recipient{Value: value, Address: kc.Address(addrIndex)}
+	output, blind, _ := mweb.CreateOutput(recipient, &senderKey)
+	mweb.SignOutput(output, value, blind, &senderKey)
+	coin, err := mweb.RewindOutput(output, kc.Scan)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return coin
+}
+
+func runEmulator(ctx *TxContext, e *emulator) error {
+	for ctx.Tx == nil {
+		resp, err := e.exchange(ctx.Request())
+		if err != nil {
+			return err
+		}
+		if err = ctx.Process(resp); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func verifyTx(t *testing.T, tx *wire.MwebTx) {
+	for _, input := range tx.TxBody.Inputs {
+		if !input.VerifySig() {
+			t.Fatal("invalid input signature")
+		}
+	}
+	for _, output := range tx.TxBody.Outputs {
+		if !output.VerifySig() {
+			t.Fatal("invalid output signature")
+		}
+	}
+
+	var (
+		lhs, rhs []*mw.Commitment
+		supply   int64
+	)
+	for _, kernel := range tx.TxBody.Kernels {
+		h := blake3.New(32, nil)
+		h.Write(kernel.Excess.PubKey()[:])
+		h.Write(kernel.StealthExcess[:])
+		pubKey := kernel.Excess.PubKey().
+			Mul((*mw.SecretKey)(h.Sum(nil))).Add(&kernel.StealthExcess)
+		if !kernel.Signature.Verify(pubKey, kernel.MessageHash()[:]) {
+			t.Fatal("invalid kernel signature")
+		}
+		rhs = append(rhs, &kernel.Excess)
+		supply += kernel.SupplyChange()
+	}
+
+	// sum(outputs) - sum(inputs) - supply*H = sum(excess) + offset*G
+	for _, output := range tx.TxBody.Outputs {
+		lhs = append(lhs, &output.Commitment)
+	}
+	for _, input := range tx.TxBody.Inputs {
+		rhs = append(rhs, &input.Commitment)
+	}
+	if supply < 0 {
+		lhs = append(lhs, mw.NewCommitment(&mw.BlindingFactor{}, uint64(-supply)))
+	} else if supply > 0 {
+		rhs = append(rhs, mw.NewCommitment(&mw.BlindingFactor{}, uint64(supply)))
+	}
+	rhs = append(rhs, mw.NewCommitment(&tx.KernelOffset, 0))
+	sum := func(cs []*mw.Commitment) *mw.Commitment {
+		c := cs[0]
+		for _, c2 := range cs[1:] {
+			c = c.Add(c2)
+		}
+		return c
+	}
+	if len(lhs) == 0 || *sum(lhs) != *sum(rhs) {
+		t.Fatal("kernel sums unbalanced")
+	}
+
+	// sum(Ks) + sum(Ki) = sum(Ko) + sum(stealth excess) + stealth_offset*G
+	var lhsKeys, rhsKeys []*mw.PublicKey
+	for _, output := range tx.TxBody.Outputs {
+		lhsKeys = append(lhsKeys, &output.SenderPubKey)
+	}
+	for _, input := range tx.TxBody.Inputs {
+		lhsKeys = append(lhsKeys, input.InputPubKey)
+		rhsKeys = append(rhsKeys, &input.OutputPubKey)
+	}
+	for _, kernel := range tx.TxBody.Kernels {
+		rhsKeys = append(rhsKeys, &kernel.StealthExcess)
+	}
+	rhsKeys = append(rhsKeys, (*mw.SecretKey)(&tx.StealthOffset).PubKey())
+	sumKeys := func(pks []*mw.PublicKey) *mw.PublicKey {
+		pk := pks[0]
+		for _, pk2 := range pks[1:] {
+			pk = pk.Add(pk2)
+		}
+		return pk
+	}
+	if len(lhsKeys) == 0 || *sumKeys(lhsKeys) != *sumKeys(rhsKeys) {
+		t.Fatal("stealth sums unbalanced")
+	}
+}
+
+func TestTxContext(t *testing.T) {
+	var (
+		kc      = randKeychain(t)
+		dest    = randKeychain(t)
+		pegout1 = wire.NewTxOut(5000, append([]byte{0, 20}, make([]byte, 20)...))
+		pegout2 = wire.NewTxOut(7000, append([]byte{0, 32}, make([]byte, 32)...))
+		pegout3 = wire.NewTxOut(9000, append([]byte{0x51, 32}, make([]byte, 32)...))
+	)
+
+	tests := []struct {
+		name       string
+		coins      []uint64
+		recipients []uint64
+		fee, pegin uint64
+		pegouts    []*wire.TxOut
+		lockHeight int32
+	}{{
+		name:  "inputs only",
+		coins: []uint64{1000, 2000},
+		fee:   3000,
+	}, {
+		name:       "outputs only",
+		recipients: []uint64{10_000},
+		fee:        300,
+		pegin:      10_300,
+	}, {
+		name:       "pegin",
+		coins:      []uint64{5000},
+		recipients: []uint64{8000, 1500},
+		fee:        500,
+		pegin:      5000,
+	}, {
+		name:       "multi pegout",
+		coins:      []uint64{30_000},
+		recipients: []uint64{8000},
+		fee:        1000,
+		pegouts:    []*wire.TxOut{pegout1, pegout2, pegout3},
+	}, {
+		name:       "mixed",
+		coins:      []uint64{4000, 6000, 20_000},
+		recipients: []uint64{3000, 4000, 11_000},
+		fee:        1000,
+		pegin:      1000,
+		pegouts:    []*wire.TxOut{pegout1, pegout2},
+	}, {
+		name:       "lock height",
+		coins:      []uint64{10_000},
+		recipients: []uint64{9000},
+		fee:        1000,
+		lockHeight: 3_000_000,
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctx := &TxContext{
+				Fee:        test.fee,
+				Pegin:      test.pegin,
+				Pegouts:    test.pegouts,
+				LockHeight: test.lockHeight,
+			}
+			for i, value := range test.coins {
+				addrIndex := uint32(i)
+				ctx.Coins = append(ctx.Coins, newCoin(t, kc, addrIndex, value))
+				ctx.AddrIndex = append(ctx.AddrIndex, addrIndex)
+			}
+			for i, value := range test.recipients {
+				ctx.Recipients = append(ctx.Recipients, &mweb.Recipient{
+					Value:   value,
+					Address: dest.Address(uint32(i)),
+				})
+			}
+
+			if err := runEmulator(ctx, newEmulator(kc)); err != nil {
+				t.Fatal(err)
+			}
+			tx := ctx.Tx
+
+			if len(tx.TxBody.Inputs) != len(test.coins) {
+				t.Fatal("input count mismatch")
+			}
+			if len(tx.TxBody.Outputs) != len(test.recipients) {
+				t.Fatal("output count mismatch")
+			}
+			if len(tx.TxBody.Kernels) != 1 {
+				t.Fatal("expected a single kernel")
+			}
+			kernel := tx.TxBody.Kernels[0]
+			if kernel.Fee != test.fee || kernel.Pegin != test.pegin {
+				t.Fatal("kernel amounts mismatch")
+			}
+			if len(kernel.Pegouts) != len(test.pegouts) {
+				t.Fatal("pegout count mismatch")
+			}
+			if kernel.LockHeight != test.lockHeight ||
+				(kernel.Features&wire.MwebKernelHeightLockFeatureBit > 0) !=
+					(test.lockHeight > 0) {
+				t.Fatal("lock height mismatch")
+			}
+			verifyTx(t, tx)
+
+			outputs := map[mw.Commitment]*wire.MwebOutput{}
+			for _, output := range tx.TxBody.Outputs {
+				outputs[output.Commitment] = output
+			}
+			for i, coin := range ctx.NewCoins {
+				output := outputs[*mw.SwitchCommit(coin.Blind, coin.Value)]
+				if output == nil || *coin.OutputId != *output.Hash() {
+					t.Fatal("new coin doesn't match an output")
+				}
+				rewound, err := mweb.RewindOutput(output, dest.Scan)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if rewound.Value != test.recipients[i] ||
+					!rewound.Address.Equal(dest.Address(uint32(i))) {
+					t.Fatal("recipient can't rewind output")
+				}
+			}
+		})
+	}
+}
+
+func TestTxContextShortResponse(t *testing.T) {
+	kc := randKeychain(t)
+	ctx := &TxContext{
+		Coins:     []*mweb.Coin{newCoin(t, kc, 0, 1000)},
+		AddrIndex: []uint32{0},
+		Fee:       1000,
+	}
+	resp, err := newEmulator(kc).exchange(ctx.Request())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = ctx.Process(resp[:len(resp)-1]); err != io.ErrUnexpectedEOF {
+		t.Fatal("expected unexpected EOF, got", err)
+	}
+}
@@ -12,7 +12,7 @@ func (mwebInitKernelState) request(ctx *TxContext) []byte {
 	buf = binary.LittleEndian.AppendUint64(buf, ctx.Fee)
 	buf = binary.LittleEndian.AppendUint64(buf, ctx.Pegin)
 	buf = binary.LittleEndian.AppendUint16(buf, uint16(len(ctx.Pegouts)))
-	buf = binary.LittleEndian.AppendUint32(buf, 0)
+	buf = binary.LittleEndian.AppendUint32(buf, uint32(ctx.LockHeight))
 	return buf
 }
 
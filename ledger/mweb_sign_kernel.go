@@ -38,6 +38,7 @@ func (mwebSignKernelState) process(ctx *TxContext, r io.Reader) (txState, error)
 				Fee:           ctx.Fee,
 				Pegin:         ctx.Pegin,
 				Pegouts:       ctx.Pegouts,
+				LockHeight:    ctx.LockHeight,
 				Excess:        result.KernelExcess,
 				StealthExcess: result.StealthExcess,
 				Signature:     result.Signature,
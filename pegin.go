@@ -0,0 +1,260 @@
+package mwebd
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+
+	"github.com/ltcmweb/ltcd/chaincfg/chainhash"
+	"github.com/ltcmweb/ltcd/ltcutil/mweb"
+	"github.com/ltcmweb/ltcd/wire"
+	"github.com/ltcmweb/mwebd/proto"
+	"github.com/ltcsuite/ltcwallet/walletdb"
+)
+
+var peginsBucket = []byte("mweb-pegins")
+
+// peginRecord is a peg-in broadcast through the daemon. The height
+// is the chain height at the time of broadcast, after which the peg-in
+// output is looked for. It's advanced as the blocks are scanned so
+// that each block is only scanned once. Once found, the txid and mined height are
+// saved so that the blocks aren't scanned again. Credited is saved
+// once the MWEB outputs are seen in the utxo set, as they may be
+// spent afterwards.
+type peginRecord struct {
+	value       uint64
+	height      uint32
+	minedHeight uint32
+	credited    bool
+	txid        chainhash.Hash
+	outputIds   []chainhash.Hash
+}
+
+func (r *peginRecord) serialize() []byte {
+	var buf bytes.Buffer
+	binary.Write(&buf, binary.LittleEndian, r.value)
+	binary.Write(&buf, binary.LittleEndian, r.height)
+	binary.Write(&buf, binary.LittleEndian, r.minedHeight)
+	binary.Write(&buf, binary.LittleEndian, r.credited)
+	buf.Write(r.txid[:])
+	for _, outputId := range r.outputIds {
+		buf.Write(outputId[:])
+	}
+	return buf.Bytes()
+}
+
+func (r *peginRecord) deserialize(b []byte) error {
+	br := bytes.NewReader(b)
+	for _, v := range []any{&r.value, &r.height, &r.minedHeight, &r.credited, &r.txid} {
+		if err := binary.Read(br, binary.LittleEndian, v); err != nil {
+			return err
+		}
+	}
+	if br.Len()%chainhash.HashSize != 0 {
+		return errors.New("invalid peg-in record")
+	}
+	r.outputIds = make([]chainhash.Hash, br.Len()/chainhash.HashSize)
+	for i := range r.outputIds {
+		br.Read(r.outputIds[i][:])
+	}
+	return nil
+}
+
+func (s *Server) putPegin(kernelHash *chainhash.Hash, r *peginRecord) error {
+	return s.update(func(dbtx walletdb.ReadWriteTx) error {
+		bucket, err := s.writeBucket(dbtx, peginsBucket)
+		if err != nil {
+			return err
+		}
+		return bucket.Put(kernelHash[:], r.serialize())
+	})
+}
+
+// putPegins records the peg-ins of a transaction that has been
+// broadcast, along with the MWEB outputs of the transaction.
+func (s *Server) putPegins(tx *wire.MsgTx) error {
+	_, height, err := s.cs.BlockHeaders.ChainTip()
+	if err != nil {
+		return err
+	}
+	var outputIds []chainhash.Hash
+	for _, output := range tx.Mweb.TxBody.Outputs {
+		outputIds = append(outputIds, *output.Hash())
+	}
+	for _, kernel := range tx.Mweb.TxBody.Kernels {
+		if kernel.Pegin == 0 {
+			continue
+		}
+		err = s.putPegin(kernel.Hash(), &peginRecord{
+			value: kernel.Pegin, height: height, outputIds: outputIds,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *Server) PeginStatus(ctx context.Context,
+	req *proto.PeginStatusRequest) (*proto.PeginStatusResponse, error) {
+
+	var kernelHash []byte
+	if req.KernelHash != "" {
+		var err error
+		kernelHash, err = hex.DecodeString(req.KernelHash)
+		if err != nil {
+			return nil, err
+		}
+		if len(kernelHash) != chainhash.HashSize {
+			return nil, errors.New("invalid kernel hash")
+		}
+	}
+
+	records := map[chainhash.Hash]*peginRecord{}
+	err := s.view(func(dbtx walletdb.ReadTx) error {
+		bucket, err := s.readBucket(dbtx, peginsBucket)
+		if bucket == nil {
+			return err
+		}
+		return bucket.ForEach(func(k, v []byte) error {
+			if kernelHash != nil && !bytes.Equal(k, kernelHash) {
+				return nil
+			}
+			r := &peginRecord{}
+			records[chainhash.Hash(k)] = r
+			return r.deserialize(v)
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	if kernelHash != nil && len(records) == 0 {
+		return nil, errors.New("peg-in not found, only peg-ins " +
+			"broadcast through this daemon are tracked")
+	}
+
+	resp := &proto.PeginStatusResponse{}
+	for kernelHash, r := range records {
+		status, err := s.peginStatus(ctx, &kernelHash, r)
+		if err != nil {
+			return nil, err
+		}
+		resp.Pegin = append(resp.Pegin, status)
+	}
+	return resp, nil
+}
+
+func (s *Server) peginStatus(ctx context.Context,
+	kernelHash *chainhash.Hash, r *peginRecord) (*proto.PeginStatus, error) {
+
+	pegin := mweb.NewPegin(r.value, kernelHash)
+	status := &proto.PeginStatus{
+		KernelHash: hex.EncodeToString(kernelHash[:]),
+		Value:      r.value,
+		PkScript:   pegin.PkScript,
+	}
+	for _, outputId := range r.outputIds {
+		status.OutputId = append(status.OutputId, hex.EncodeToString(outputId[:]))
+	}
+
+	if r.minedHeight == 0 {
+		tip, err := s.scanBlocks(ctx, r.height+1, [][]byte{pegin.PkScript},
+			func(height uint32, block *wire.MsgBlock) (bool, error) {
+				for _, tx := range block.Transactions {
+					if findPegin(tx, pegin) {
+						r.minedHeight, r.txid = height, tx.TxHash()
+						return true, nil
+					}
+				}
+				return false, nil
+			})
+		if err != nil {
+			return nil, err
+		}
+		if r.minedHeight > 0 || tip > r.height {
+			r.height = max(r.height, tip)
+			if err = s.putPegin(kernelHash, r); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if r.minedHeight == 0 {
+		inMempool, err := s.inMempool(r.outputIds)
+		if err != nil {
+			return nil, err
+		}
+		if inMempool {
+			status.State = proto.PeginState_PEGIN_MEMPOOL
+		}
+		return status, nil
+	}
+
+	header, err := s.cs.BlockHeaders.FetchHeaderByHeight(r.minedHeight)
+	if err != nil {
+		return nil, err
+	}
+	status.Txid = r.txid.String()
+	status.Height = int32(r.minedHeight)
+	status.BlockHash = header.BlockHash().String()
+
+	status.State = proto.PeginState_PEGIN_MINED
+	if !r.credited {
+		lfs, err := s.cs.MwebCoinDB.GetLeafset()
+		if err != nil {
+			return nil, err
+		}
+		if lfs.Height < r.minedHeight || !s.anyUtxoExists(r.outputIds) {
+			return status, nil
+		}
+		r.credited = true
+		if err = s.putPegin(kernelHash, r); err != nil {
+			return nil, err
+		}
+	}
+	status.State = proto.PeginState_PEGIN_CREDITED
+	return status, nil
+}
+
+// anyUtxoExists reports whether any of the outputs are in the utxo
+// set. The outputs are of the same transaction, so one is enough to
+// show that it was credited even if the others were since spent.
+func (s *Server) anyUtxoExists(outputIds []chainhash.Hash) bool {
+	for _, outputId := range outputIds {
+		if s.cs.MwebUtxoExists(&outputId) {
+			return true
+		}
+	}
+	return false
+}
+
+func findPegin(tx *wire.MsgTx, pegin *wire.TxOut) bool {
+	for _, txOut := range tx.TxOut {
+		if txOut.Value == pegin.Value &&
+			bytes.Equal(txOut.PkScript, pegin.PkScript) {
+			return true
+		}
+	}
+	return false
+}
+
+// inMempool reports whether any of the outputs are in the MWEB mempool.
+func (s *Server) inMempool(outputIds []chainhash.Hash) (found bool, err error) {
+	err = s.view(func(dbtx walletdb.ReadTx) error {
+		bucket, err := s.readBucket(dbtx, mempoolBucket)
+		if bucket == nil {
+			return err
+		}
+		for _, outputId := range outputIds {
+			b, err := bucket.Get(outputId[:])
+			if err != nil {
+				return err
+			}
+			found = found || b != nil
+		}
+		return nil
+	})
+	return
+}
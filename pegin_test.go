@@ -0,0 +1,50 @@
+package mwebd
+
+import (
+	"crypto/rand"
+	"testing"
+
+	"github.com/ltcmweb/ltcd/chaincfg/chainhash"
+	"github.com/ltcmweb/ltcd/ltcutil/mweb"
+	"github.com/ltcmweb/ltcd/wire"
+)
+
+func TestPeginRecord(t *testing.T) {
+	r := &peginRecord{value: 12_345, height: 100, minedHeight: 102, credited: true}
+	rand.Read(r.txid[:])
+	for range 3 {
+		var outputId chainhash.Hash
+		rand.Read(outputId[:])
+		r.outputIds = append(r.outputIds, outputId)
+	}
+
+	r2 := &peginRecord{}
+	if err := r2.deserialize(r.serialize()); err != nil {
+		t.Fatal(err)
+	}
+	if r2.value != r.value || r2.height != r.height ||
+		r2.minedHeight != r.minedHeight || !r2.credited || r2.txid != r.txid ||
+		len(r2.outputIds) != 3 || r2.outputIds[2] != r.outputIds[2] {
+		t.Fatal("record mismatch")
+	}
+
+	if err := r2.deserialize(r.serialize()[:60]); err == nil {
+		t.Fatal("expected truncated record error")
+	}
+}
+
+func TestFindPegin(t *testing.T) {
+	var kernelHash chainhash.Hash
+	rand.Read(kernelHash[:])
+	pegin := mweb.NewPegin(5000, &kernelHash)
+
+	tx := &wire.MsgTx{}
+	tx.AddTxOut(mweb.NewPegin(4000, &kernelHash))
+	if findPegin(tx, pegin) {
+		t.Fatal("peg-in value shouldn't match")
+	}
+	tx.AddTxOut(wire.NewTxOut(pegin.Value, pegin.PkScript))
+	if !findPegin(tx, pegin) {
+		t.Fatal("peg-in not found")
+	}
+}
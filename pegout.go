@@ -0,0 +1,438 @@
+package mwebd
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"time"
+
+	"github.com/ltcmweb/ltcd/chaincfg/chainhash"
+	"github.com/ltcmweb/ltcd/ltcutil/gcs/builder"
+	"github.com/ltcmweb/ltcd/txscript"
+	"github.com/ltcmweb/ltcd/wire"
+	"github.com/ltcmweb/mwebd/proto"
+	"github.com/ltcmweb/neutrino"
+	"github.com/ltcsuite/ltcwallet/walletdb"
+)
+
+var pegoutsBucket = []byte("mweb-pegouts")
+
+// mwebBlockTimeout is how long a peer is given to return an MWEB block.
+const mwebBlockTimeout = 30 * time.Second
+
+// pegoutRecord is a kernel with peg-outs that was broadcast through
+// the daemon, along with the chain height at the time of broadcast.
+// Until the kernel is found, the height that the blocks have been
+// scanned up to is saved so that each block is only scanned once.
+// Once the kernel is found, the mined height, the HogEx and the
+// indexes of its outputs paying out the peg-outs are saved so that
+// the blocks aren't scanned again.
+type pegoutRecord struct {
+	txid          chainhash.Hash
+	height        uint32
+	scannedHeight uint32
+	kernel        *wire.MwebKernel
+	minedHeight   uint32
+	hogexHash     chainhash.Hash
+	indexes       []uint32
+}
+
+func (r *pegoutRecord) serialize() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.Write(r.txid[:])
+	binary.Write(&buf, binary.LittleEndian, r.height)
+	binary.Write(&buf, binary.LittleEndian, r.scannedHeight)
+	if err := r.kernel.Serialize(&buf); err != nil {
+		return nil, err
+	}
+	if r.minedHeight > 0 {
+		binary.Write(&buf, binary.LittleEndian, r.minedHeight)
+		buf.Write(r.hogexHash[:])
+		binary.Write(&buf, binary.LittleEndian, r.indexes)
+	}
+	return buf.Bytes(), nil
+}
+
+func (r *pegoutRecord) deserialize(b []byte) error {
+	br := bytes.NewReader(b)
+	if _, err := br.Read(r.txid[:]); err != nil {
+		return err
+	}
+	if err := binary.Read(br, binary.LittleEndian, &r.height); err != nil {
+		return err
+	}
+	if err := binary.Read(br, binary.LittleEndian, &r.scannedHeight); err != nil {
+		return err
+	}
+	r.kernel = &wire.MwebKernel{}
+	if err := r.kernel.Deserialize(br); err != nil {
+		return err
+	}
+	if br.Len() == 0 {
+		return nil
+	}
+	if err := binary.Read(br, binary.LittleEndian, &r.minedHeight); err != nil {
+		return err
+	}
+	if err := binary.Read(br, binary.LittleEndian, &r.hogexHash); err != nil {
+		return err
+	}
+	if br.Len() != 4*len(r.kernel.Pegouts) {
+		return errors.New("invalid peg-out record")
+	}
+	r.indexes = make([]uint32, len(r.kernel.Pegouts))
+	return binary.Read(br, binary.LittleEndian, r.indexes)
+}
+
+// expectedHeight returns the height of the first block that the
+// kernel can be mined in.
+func (r *pegoutRecord) expectedHeight() uint32 {
+	height := r.height + 1
+	if r.kernel.Features&wire.MwebKernelHeightLockFeatureBit > 0 &&
+		uint32(r.kernel.LockHeight) > height {
+		height = uint32(r.kernel.LockHeight)
+	}
+	return height
+}
+
+// putPegouts records the kernels with peg-outs of a transaction
+// that has been broadcast.
+func (s *Server) putPegouts(tx *wire.MsgTx) error {
+	_, height, err := s.cs.BlockHeaders.ChainTip()
+	if err != nil {
+		return err
+	}
+	var records []*pegoutRecord
+	for _, kernel := range tx.Mweb.TxBody.Kernels {
+		if len(kernel.Pegouts) > 0 {
+			records = append(records, &pegoutRecord{
+				txid: tx.TxHash(), height: height, kernel: kernel,
+			})
+		}
+	}
+	return s.putPegoutRecords(records...)
+}
+
+func (s *Server) putPegoutRecords(records ...*pegoutRecord) error {
+	if len(records) == 0 {
+		return nil
+	}
+	return s.update(func(dbtx walletdb.ReadWriteTx) error {
+		bucket, err := s.writeBucket(dbtx, pegoutsBucket)
+		if err != nil {
+			return err
+		}
+		for _, r := range records {
+			b, err := r.serialize()
+			if err != nil {
+				return err
+			}
+			if err = bucket.Put(r.kernel.Hash()[:], b); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (s *Server) getPegouts(kernelHash,
+	txid *chainhash.Hash) (records []*pegoutRecord, err error) {
+
+	err = s.view(func(dbtx walletdb.ReadTx) error {
+		bucket, err := s.readBucket(dbtx, pegoutsBucket)
+		if bucket == nil {
+			return err
+		}
+		if kernelHash != nil {
+			b, err := bucket.Get(kernelHash[:])
+			if b == nil {
+				return err
+			}
+			r := &pegoutRecord{}
+			records = append(records, r)
+			return r.deserialize(b)
+		}
+		return bucket.ForEach(func(k, v []byte) error {
+			r := &pegoutRecord{}
+			if err := r.deserialize(v); err != nil {
+				return err
+			}
+			if r.txid == *txid {
+				records = append(records, r)
+			}
+			return nil
+		})
+	})
+	return
+}
+
+func (s *Server) PegoutStatus(ctx context.Context,
+	req *proto.PegoutStatusRequest) (*proto.PegoutStatusResponse, error) {
+
+	var kernelHash, txid *chainhash.Hash
+	switch {
+	case req.KernelHash != "":
+		b, err := hex.DecodeString(req.KernelHash)
+		if err != nil {
+			return nil, err
+		}
+		if len(b) != chainhash.HashSize {
+			return nil, errors.New("invalid kernel hash")
+		}
+		kernelHash = (*chainhash.Hash)(b)
+	case req.Txid != "":
+		var err error
+		if txid, err = chainhash.NewHashFromStr(req.Txid); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, errors.New("kernel hash or txid required")
+	}
+
+	records, err := s.getPegouts(kernelHash, txid)
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 {
+		return nil, errors.New("no peg-outs found, only transactions " +
+			"broadcast through this daemon are tracked")
+	}
+
+	resp := &proto.PegoutStatusResponse{}
+	for _, r := range records {
+		status, err := s.pegoutStatus(ctx, r)
+		if err != nil {
+			return nil, err
+		}
+		resp.Kernel = append(resp.Kernel, status)
+	}
+	return resp, nil
+}
+
+// pegoutStatus scans the blocks from the expected height, or from
+// where the last scan left off, to the tip for the kernel and the
+// HogEx paying out its peg-outs.
+func (s *Server) pegoutStatus(ctx context.Context,
+	r *pegoutRecord) (*proto.KernelPegoutStatus, error) {
+
+	kernelHash := r.kernel.Hash()
+	status := &proto.KernelPegoutStatus{
+		KernelHash:     hex.EncodeToString(kernelHash[:]),
+		Txid:           r.txid.String(),
+		ExpectedHeight: int32(r.expectedHeight()),
+	}
+	var pkScripts [][]byte
+	for _, pegout := range r.kernel.Pegouts {
+		status.Pegout = append(status.Pegout, &proto.Pegout{
+			Value:    uint64(pegout.Value),
+			PkScript: pegout.PkScript,
+		})
+		pkScripts = append(pkScripts, pegout.PkScript)
+	}
+
+	if r.minedHeight == 0 {
+		height := max(r.expectedHeight(), r.scannedHeight+1)
+		tip, err := s.scanBlocks(ctx, height, pkScripts,
+			func(height uint32, block *wire.MsgBlock) (bool, error) {
+				hogex := block.Transactions[len(block.Transactions)-1]
+				indexes := matchPegouts(hogex, r.kernel.Pegouts)
+				if indexes == nil {
+					return false, nil
+				}
+
+				// Other kernels may have the same peg-outs, so
+				// the kernel itself must be in the block.
+				mwebBlock, err := s.getMwebBlock(ctx, block)
+				if err != nil {
+					return false, err
+				}
+				if !findKernel(mwebBlock.MwebTransactions, kernelHash) {
+					return false, nil
+				}
+				r.minedHeight, r.hogexHash, r.indexes =
+					height, hogex.TxHash(), indexes
+				return true, nil
+			})
+		if err != nil {
+			return nil, err
+		}
+		if r.minedHeight == 0 {
+			if tip > r.scannedHeight {
+				r.scannedHeight = tip
+				err = s.putPegoutRecords(r)
+			}
+			return status, err
+		}
+		if err = s.putPegoutRecords(r); err != nil {
+			return nil, err
+		}
+	}
+
+	header, err := s.cs.BlockHeaders.FetchHeaderByHeight(r.minedHeight)
+	if err != nil {
+		return nil, err
+	}
+	status.Mined = true
+	status.HogexHeight = int32(r.minedHeight)
+	status.HogexBlockHash = header.BlockHash().String()
+	status.MatureHeight = status.HogexHeight + int32(s.cp.MwebPegoutMaturity)
+	for i, index := range r.indexes {
+		status.Pegout[i].Outpoint =
+			wire.NewOutPoint(&r.hogexHash, index).String()
+	}
+	return status, nil
+}
+
+// scanBlocks calls match on each block from the given height to the
+// tip whose compact filter matches any of pkScripts, until it returns
+// true or an error. The filters are checked first so that only
+// matching blocks are fetched. The height of the tip is returned.
+func (s *Server) scanBlocks(ctx context.Context, height uint32, pkScripts [][]byte,
+	match func(uint32, *wire.MsgBlock) (bool, error)) (uint32, error) {
+
+	_, tip, err := s.cs.BlockHeaders.ChainTip()
+	if err != nil {
+		return 0, err
+	}
+
+	for ; height <= tip; height++ {
+		if err = ctx.Err(); err != nil {
+			return 0, err
+		}
+		header, err := s.cs.BlockHeaders.FetchHeaderByHeight(height)
+		if err != nil {
+			return 0, err
+		}
+		blockHash := header.BlockHash()
+		filter, err := s.cs.GetCFilter(blockHash, wire.GCSFilterRegular)
+		if err != nil {
+			return 0, err
+		}
+		matched, err := filter.MatchAny(builder.DeriveKey(&blockHash), pkScripts)
+		if err != nil {
+			return 0, err
+		}
+		if !matched {
+			continue
+		}
+		block, err := s.cs.GetBlock(blockHash)
+		if err != nil {
+			return 0, err
+		}
+		if found, err := match(height, block.MsgBlock()); found || err != nil {
+			return tip, err
+		}
+	}
+
+	return tip, nil
+}
+
+// getMwebBlock fetches a block along with its MWEB data, which
+// neutrino's GetBlock doesn't request. The block must already have
+// been fetched through neutrino, whose HogEx is used to check the
+// MWEB header returned by the peers. The kernels can't be checked
+// against the header's kernel root without the whole kernel MMR, so
+// a peer that lies about them makes the kernel appear unmined.
+func (s *Server) getMwebBlock(ctx context.Context,
+	block *wire.MsgBlock) (*wire.MsgBlock, error) {
+
+	blockHash := block.BlockHash()
+	hogex := block.Transactions[len(block.Transactions)-1]
+	getData := wire.NewMsgGetData()
+	getData.AddInvVect(wire.NewInvVect(wire.InvTypeMwebBlock, &blockHash))
+
+	for _, sp := range s.cs.Peers() {
+		mwebBlock, err := requestMwebBlock(ctx, sp, getData, &blockHash)
+		if err == nil && verifyMwebBlock(mwebBlock, hogex) == nil {
+			return mwebBlock, nil
+		}
+		if err = ctx.Err(); err != nil {
+			return nil, err
+		}
+	}
+	return nil, errors.New("no peer returned the MWEB block " + blockHash.String())
+}
+
+func requestMwebBlock(ctx context.Context, sp *neutrino.ServerPeer,
+	getData *wire.MsgGetData, blockHash *chainhash.Hash) (*wire.MsgBlock, error) {
+
+	msgChan, cancel := sp.SubscribeRecvMsg()
+	defer cancel()
+	sp.QueueMessageWithEncoding(getData, nil, wire.WitnessEncoding)
+
+	timeout := time.After(mwebBlockTimeout)
+	for {
+		select {
+		case msg := <-msgChan:
+			switch msg := msg.(type) {
+			case *wire.MsgBlock:
+				if msg.BlockHash() == *blockHash {
+					return msg, nil
+				}
+			case *wire.MsgNotFound:
+				return nil, errors.New("block not found")
+			}
+		case <-sp.OnDisconnect():
+			return nil, errors.New("peer disconnected")
+		case <-timeout:
+			return nil, errors.New("timed out")
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// verifyMwebBlock checks that the MWEB header of the block is the one
+// committed to by the HogAddr of the given HogEx.
+func verifyMwebBlock(block *wire.MsgBlock, hogex *wire.MsgTx) error {
+	if block.MwebHeader == nil || block.MwebTransactions == nil {
+		return errors.New("block has no MWEB data")
+	}
+	script, err := txscript.NewScriptBuilder().
+		AddOp(txscript.MwebHogAddrWitnessVersion + txscript.OP_1 - 1).
+		AddData(block.MwebHeader.Hash()[:]).Script()
+	if err != nil {
+		return err
+	}
+	if len(hogex.TxOut) == 0 || !bytes.Equal(hogex.TxOut[0].PkScript, script) {
+		return errors.New("HogAddr mismatch")
+	}
+	return nil
+}
+
+func findKernel(txBody *wire.MwebTxBody, kernelHash *chainhash.Hash) bool {
+	for _, kernel := range txBody.Kernels {
+		if *kernel.Hash() == *kernelHash {
+			return true
+		}
+	}
+	return false
+}
+
+// matchPegouts returns the indexes of the HogEx outputs paying out
+// the peg-outs, or nil if any of them are missing. The first output
+// of the HogEx is the new HogAddr and is skipped.
+func matchPegouts(hogex *wire.MsgTx, pegouts []*wire.TxOut) []uint32 {
+	var (
+		indexes []uint32
+		used    = map[int]bool{}
+	)
+	for _, pegout := range pegouts {
+		found := false
+		for i := 1; i < len(hogex.TxOut) && !found; i++ {
+			txOut := hogex.TxOut[i]
+			if !used[i] && txOut.Value == pegout.Value &&
+				bytes.Equal(txOut.PkScript, pegout.PkScript) {
+				used[i] = true
+				indexes = append(indexes, uint32(i))
+				found = true
+			}
+		}
+		if !found {
+			return nil
+		}
+	}
+	return indexes
+}
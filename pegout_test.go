@@ -0,0 +1,117 @@
+package mwebd
+
+import (
+	"testing"
+
+	"github.com/ltcmweb/ltcd/chaincfg/chainhash"
+	"github.com/ltcmweb/ltcd/txscript"
+	"github.com/ltcmweb/ltcd/wire"
+)
+
+func TestMatchPegouts(t *testing.T) {
+	pkScript1 := append([]byte{0, 20}, make([]byte, 20)...)
+	pkScript2 := append([]byte{0, 32}, make([]byte, 32)...)
+	pegouts := []*wire.TxOut{
+		wire.NewTxOut(5000, pkScript1),
+		wire.NewTxOut(5000, pkScript1),
+		wire.NewTxOut(7000, pkScript2),
+	}
+
+	hogex := &wire.MsgTx{}
+	hogex.AddTxOut(wire.NewTxOut(5000, pkScript1))
+	hogex.AddTxOut(wire.NewTxOut(7000, pkScript2))
+	hogex.AddTxOut(wire.NewTxOut(5000, pkScript1))
+	if matchPegouts(hogex, pegouts) != nil {
+		t.Fatal("HogAddr output shouldn't match a peg-out")
+	}
+
+	hogex.AddTxOut(wire.NewTxOut(5000, pkScript1))
+	indexes := matchPegouts(hogex, pegouts)
+	if len(indexes) != 3 ||
+		indexes[0] != 2 || indexes[1] != 3 || indexes[2] != 1 {
+		t.Fatal("unexpected indexes", indexes)
+	}
+}
+
+func TestPegoutRecord(t *testing.T) {
+	tx := newPartialTx(t)
+	kernel := tx.Mweb.TxBody.Kernels[0]
+	kernel.Features |= wire.MwebKernelPegoutFeatureBit
+	kernel.Pegouts = []*wire.TxOut{wire.NewTxOut(1000, []byte{0x51})}
+
+	r := &pegoutRecord{
+		txid: tx.TxHash(), height: 100, scannedHeight: 103, kernel: kernel,
+	}
+	b, err := r.serialize()
+	if err != nil {
+		t.Fatal(err)
+	}
+	r2 := &pegoutRecord{}
+	if err = r2.deserialize(b); err != nil {
+		t.Fatal(err)
+	}
+	if r2.txid != r.txid || r2.height != r.height ||
+		r2.scannedHeight != r.scannedHeight ||
+		*r2.kernel.Hash() != *kernel.Hash() {
+		t.Fatal("record mismatch")
+	}
+	if r2.expectedHeight() != 101 {
+		t.Fatal("expected next block")
+	}
+
+	r2.kernel.Features |= wire.MwebKernelHeightLockFeatureBit
+	r2.kernel.LockHeight = 150
+	if r2.expectedHeight() != 150 {
+		t.Fatal("expected lock height")
+	}
+
+	// Records of mined kernels keep where they were paid out.
+	r.minedHeight, r.hogexHash, r.indexes = 105, chainhash.Hash{1}, []uint32{2}
+	if b, err = r.serialize(); err != nil {
+		t.Fatal(err)
+	}
+	r2 = &pegoutRecord{}
+	if err = r2.deserialize(b); err != nil {
+		t.Fatal(err)
+	}
+	if r2.minedHeight != 105 || r2.hogexHash != r.hogexHash ||
+		len(r2.indexes) != 1 || r2.indexes[0] != 2 {
+		t.Fatal("mined record mismatch")
+	}
+	if err = r2.deserialize(b[:len(b)-1]); err == nil {
+		t.Fatal("expected error for truncated record")
+	}
+}
+
+func TestVerifyMwebBlock(t *testing.T) {
+	tx := newPartialTx(t)
+	block := &wire.MsgBlock{
+		MwebHeader:       &wire.MwebHeader{Height: 100},
+		MwebTransactions: tx.Mweb.TxBody,
+	}
+	hogAddr, err := txscript.NewScriptBuilder().
+		AddOp(txscript.OP_8).AddData(block.MwebHeader.Hash()[:]).Script()
+	if err != nil {
+		t.Fatal(err)
+	}
+	hogex := &wire.MsgTx{}
+	hogex.AddTxOut(wire.NewTxOut(1000, hogAddr))
+	if err = verifyMwebBlock(block, hogex); err != nil {
+		t.Fatal(err)
+	}
+	if !findKernel(block.MwebTransactions, tx.Mweb.TxBody.Kernels[0].Hash()) {
+		t.Fatal("expected kernel in block")
+	}
+	if findKernel(block.MwebTransactions, &chainhash.Hash{}) {
+		t.Fatal("unexpected kernel in block")
+	}
+
+	block.MwebHeader.Height++
+	if verifyMwebBlock(block, hogex) == nil {
+		t.Fatal("expected HogAddr mismatch")
+	}
+	block.MwebHeader = nil
+	if verifyMwebBlock(block, hogex) == nil {
+		t.Fatal("expected missing MWEB data error")
+	}
+}
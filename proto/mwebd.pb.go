@@ -21,6 +21,231 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+type AddressType int32
+
+const (
+	AddressType_ADDRESS_UNKNOWN AddressType = 0
+	AddressType_ADDRESS_P2PKH   AddressType = 1
+	AddressType_ADDRESS_P2SH    AddressType = 2
+	AddressType_ADDRESS_P2WPKH  AddressType = 3
+	AddressType_ADDRESS_P2WSH   AddressType = 4
+	AddressType_ADDRESS_P2TR    AddressType = 5
+	AddressType_ADDRESS_MWEB    AddressType = 6
+)
+
+// Enum value maps for AddressType.
+var (
+	AddressType_name = map[int32]string{
+		0: "ADDRESS_UNKNOWN",
+		1: "ADDRESS_P2PKH",
+		2: "ADDRESS_P2SH",
+		3: "ADDRESS_P2WPKH",
+		4: "ADDRESS_P2WSH",
+		5: "ADDRESS_P2TR",
+		6: "ADDRESS_MWEB",
+	}
+	AddressType_value = map[string]int32{
+		"ADDRESS_UNKNOWN": 0,
+		"ADDRESS_P2PKH":   1,
+		"ADDRESS_P2SH":    2,
+		"ADDRESS_P2WPKH":  3,
+		"ADDRESS_P2WSH":   4,
+		"ADDRESS_P2TR":    5,
+		"ADDRESS_MWEB":    6,
+	}
+)
+
+func (x AddressType) Enum() *AddressType {
+	p := new(AddressType)
+	*p = x
+	return p
+}
+
+func (x AddressType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (AddressType) Descriptor() protoreflect.EnumDescriptor {
+	return file_mwebd_proto_enumTypes[0].Descriptor()
+}
+
+func (AddressType) Type() protoreflect.EnumType {
+	return &file_mwebd_proto_enumTypes[0]
+}
+
+func (x AddressType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use AddressType.Descriptor instead.
+func (AddressType) EnumDescriptor() ([]byte, []int) {
+	return file_mwebd_proto_rawDescGZIP(), []int{0}
+}
+
+type PeginPolicy int32
+
+const (
+	// A peg-in is created as required to fund any shortfall.
+	PeginPolicy_PEGIN_ALLOW PeginPolicy = 0
+	// An error is returned if a peg-in would be required.
+	PeginPolicy_PEGIN_FORBID PeginPolicy = 1
+	// An error is returned unless the peg-in required is exactly
+	// pegin_amount. This allows the caller to first review the
+	// peg-in from a dry run.
+	PeginPolicy_PEGIN_EXACT PeginPolicy = 2
+)
+
+// Enum value maps for PeginPolicy.
+var (
+	PeginPolicy_name = map[int32]string{
+		0: "PEGIN_ALLOW",
+		1: "PEGIN_FORBID",
+		2: "PEGIN_EXACT",
+	}
+	PeginPolicy_value = map[string]int32{
+		"PEGIN_ALLOW":  0,
+		"PEGIN_FORBID": 1,
+		"PEGIN_EXACT":  2,
+	}
+)
+
+func (x PeginPolicy) Enum() *PeginPolicy {
+	p := new(PeginPolicy)
+	*p = x
+	return p
+}
+
+func (x PeginPolicy) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (PeginPolicy) Descriptor() protoreflect.EnumDescriptor {
+	return file_mwebd_proto_enumTypes[1].Descriptor()
+}
+
+func (PeginPolicy) Type() protoreflect.EnumType {
+	return &file_mwebd_proto_enumTypes[1]
+}
+
+func (x PeginPolicy) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use PeginPolicy.Descriptor instead.
+func (PeginPolicy) EnumDescriptor() ([]byte, []int) {
+	return file_mwebd_proto_rawDescGZIP(), []int{1}
+}
+
+type PeginState int32
+
+const (
+	// The peg-in transaction hasn't been seen yet.
+	PeginState_PEGIN_PENDING PeginState = 0
+	// The MWEB outputs of the transaction are in the mempool.
+	PeginState_PEGIN_MEMPOOL PeginState = 1
+	// The peg-in output has been mined in the transparent chain,
+	// but the MWEB utxo set hasn't been synced to that height yet.
+	PeginState_PEGIN_MINED PeginState = 2
+	// The MWEB outputs have been seen in the utxo set.
+	PeginState_PEGIN_CREDITED PeginState = 3
+)
+
+// Enum value maps for PeginState.
+var (
+	PeginState_name = map[int32]string{
+		0: "PEGIN_PENDING",
+		1: "PEGIN_MEMPOOL",
+		2: "PEGIN_MINED",
+		3: "PEGIN_CREDITED",
+	}
+	PeginState_value = map[string]int32{
+		"PEGIN_PENDING":  0,
+		"PEGIN_MEMPOOL":  1,
+		"PEGIN_MINED":    2,
+		"PEGIN_CREDITED": 3,
+	}
+)
+
+func (x PeginState) Enum() *PeginState {
+	p := new(PeginState)
+	*p = x
+	return p
+}
+
+func (x PeginState) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (PeginState) Descriptor() protoreflect.EnumDescriptor {
+	return file_mwebd_proto_enumTypes[2].Descriptor()
+}
+
+func (PeginState) Type() protoreflect.EnumType {
+	return &file_mwebd_proto_enumTypes[2]
+}
+
+func (x PeginState) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use PeginState.Descriptor instead.
+func (PeginState) EnumDescriptor() ([]byte, []int) {
+	return file_mwebd_proto_rawDescGZIP(), []int{2}
+}
+
+type CoinswapState int32
+
+const (
+	// The swap was submitted and is waiting for the nodes to
+	// perform it, or is waiting to be submitted.
+	CoinswapState_COINSWAP_PENDING CoinswapState = 0
+	// The output of the swap has been seen.
+	CoinswapState_COINSWAP_COMPLETED CoinswapState = 1
+	// Every route failed, or the utxo was spent otherwise.
+	CoinswapState_COINSWAP_FAILED CoinswapState = 2
+)
+
+// Enum value maps for CoinswapState.
+var (
+	CoinswapState_name = map[int32]string{
+		0: "COINSWAP_PENDING",
+		1: "COINSWAP_COMPLETED",
+		2: "COINSWAP_FAILED",
+	}
+	CoinswapState_value = map[string]int32{
+		"COINSWAP_PENDING":   0,
+		"COINSWAP_COMPLETED": 1,
+		"COINSWAP_FAILED":    2,
+	}
+)
+
+func (x CoinswapState) Enum() *CoinswapState {
+	p := new(CoinswapState)
+	*p = x
+	return p
+}
+
+func (x CoinswapState) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (CoinswapState) Descriptor() protoreflect.EnumDescriptor {
+	return file_mwebd_proto_enumTypes[3].Descriptor()
+}
+
+func (CoinswapState) Type() protoreflect.EnumType {
+	return &file_mwebd_proto_enumTypes[3]
+}
+
+func (x CoinswapState) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use CoinswapState.Descriptor instead.
+func (CoinswapState) EnumDescriptor() ([]byte, []int) {
+	return file_mwebd_proto_rawDescGZIP(), []int{3}
+}
+
 type StatusRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	unknownFields protoimpl.UnknownFields
@@ -66,7 +291,9 @@ type StatusResponse struct {
 	// The height at which the MWEB utxo set is synced to.
 	MwebUtxosHeight int32 `protobuf:"varint,3,opt,name=mweb_utxos_height,json=mwebUtxosHeight,proto3" json:"mweb_utxos_height,omitempty"`
 	// The timestamp of the latest block.
-	BlockTime     uint32 `protobuf:"varint,4,opt,name=block_time,json=blockTime,proto3" json:"block_time,omitempty"`
+	BlockTime uint32 `protobuf:"varint,4,opt,name=block_time,json=blockTime,proto3" json:"block_time,omitempty"`
+	// Whether the database is encrypted and waiting to be unlocked.
+	Locked        bool `protobuf:"varint,5,opt,name=locked,proto3" json:"locked,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -129,6 +356,13 @@ func (x *StatusResponse) GetBlockTime() uint32 {
 	return 0
 }
 
+func (x *StatusResponse) GetLocked() bool {
+	if x != nil {
+		return x.Locked
+	}
+	return false
+}
+
 type UtxosRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// The block height from which to start fetching utxos from.
@@ -138,9 +372,12 @@ type UtxosRequest struct {
 	FromHeight int32 `protobuf:"varint,1,opt,name=from_height,json=fromHeight,proto3" json:"from_height,omitempty"`
 	// The scan secret or view key represents the account for
 	// which utxos should be streamed.
-	ScanSecret    []byte `protobuf:"bytes,2,opt,name=scan_secret,json=scanSecret,proto3" json:"scan_secret,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	ScanSecret []byte `protobuf:"bytes,2,opt,name=scan_secret,json=scanSecret,proto3" json:"scan_secret,omitempty"`
+	// An MWEB output descriptor for the account, as an alternative
+	// to the raw keys. See sign.Descriptor for the syntax.
+	AccountDescriptor string `protobuf:"bytes,3,opt,name=account_descriptor,json=accountDescriptor,proto3" json:"account_descriptor,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
 }
 
 func (x *UtxosRequest) Reset() {
@@ -187,6 +424,13 @@ func (x *UtxosRequest) GetScanSecret() []byte {
 	return nil
 }
 
+func (x *UtxosRequest) GetAccountDescriptor() string {
+	if x != nil {
+		return x.AccountDescriptor
+	}
+	return ""
+}
+
 type Utxo struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// The block height of the utxo, or 0 for unconfirmed.
@@ -282,9 +526,12 @@ type AddressRequest struct {
 	// The public key of the spend secret for the account. The spend
 	// key is required for spending utxos but is also required
 	// for generating addresses.
-	SpendPubkey   []byte `protobuf:"bytes,4,opt,name=spend_pubkey,json=spendPubkey,proto3" json:"spend_pubkey,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	SpendPubkey []byte `protobuf:"bytes,4,opt,name=spend_pubkey,json=spendPubkey,proto3" json:"spend_pubkey,omitempty"`
+	// An MWEB output descriptor for the account, as an alternative
+	// to the raw keys. See sign.Descriptor for the syntax.
+	AccountDescriptor string `protobuf:"bytes,5,opt,name=account_descriptor,json=accountDescriptor,proto3" json:"account_descriptor,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
 }
 
 func (x *AddressRequest) Reset() {
@@ -345,6 +592,13 @@ func (x *AddressRequest) GetSpendPubkey() []byte {
 	return nil
 }
 
+func (x *AddressRequest) GetAccountDescriptor() string {
+	if x != nil {
+		return x.AccountDescriptor
+	}
+	return ""
+}
+
 type AddressResponse struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// An array of MWEB addresses within the requested range.
@@ -390,27 +644,43 @@ func (x *AddressResponse) GetAddress() []string {
 	return nil
 }
 
-type LedgerApdu struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Data          []byte                 `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
-}
-
-func (x *LedgerApdu) Reset() {
-	*x = LedgerApdu{}
+type KeychainRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// An extended private key. This is either the BIP32 root key,
+	// or the account key at m/1000'/coin'/account' in which case
+	// the account index must either be unset or match the key's.
+	// An xpub can't be used as the scan and spend keys are
+	// hardened children.
+	Xprv string `protobuf:"bytes,1,opt,name=xprv,proto3" json:"xprv,omitempty"`
+	// A BIP39 mnemonic to derive the root key from, as an
+	// alternative to the xprv.
+	Mnemonic string `protobuf:"bytes,2,opt,name=mnemonic,proto3" json:"mnemonic,omitempty"`
+	// The optional BIP39 passphrase for the mnemonic.
+	Passphrase string `protobuf:"bytes,3,opt,name=passphrase,proto3" json:"passphrase,omitempty"`
+	// The index of the account.
+	Account uint32 `protobuf:"varint,4,opt,name=account,proto3" json:"account,omitempty"`
+	// Whether to also return the spend secret. Leave this unset
+	// for watch-only use, where only the scan secret and spend
+	// pubkey are needed.
+	IncludeSpendSecret bool `protobuf:"varint,5,opt,name=include_spend_secret,json=includeSpendSecret,proto3" json:"include_spend_secret,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *KeychainRequest) Reset() {
+	*x = KeychainRequest{}
 	mi := &file_mwebd_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *LedgerApdu) String() string {
+func (x *KeychainRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*LedgerApdu) ProtoMessage() {}
+func (*KeychainRequest) ProtoMessage() {}
 
-func (x *LedgerApdu) ProtoReflect() protoreflect.Message {
+func (x *KeychainRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_mwebd_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -422,40 +692,75 @@ func (x *LedgerApdu) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use LedgerApdu.ProtoReflect.Descriptor instead.
-func (*LedgerApdu) Descriptor() ([]byte, []int) {
+// Deprecated: Use KeychainRequest.ProtoReflect.Descriptor instead.
+func (*KeychainRequest) Descriptor() ([]byte, []int) {
 	return file_mwebd_proto_rawDescGZIP(), []int{6}
 }
 
-func (x *LedgerApdu) GetData() []byte {
+func (x *KeychainRequest) GetXprv() string {
 	if x != nil {
-		return x.Data
+		return x.Xprv
 	}
-	return nil
+	return ""
 }
 
-type SpentRequest struct {
+func (x *KeychainRequest) GetMnemonic() string {
+	if x != nil {
+		return x.Mnemonic
+	}
+	return ""
+}
+
+func (x *KeychainRequest) GetPassphrase() string {
+	if x != nil {
+		return x.Passphrase
+	}
+	return ""
+}
+
+func (x *KeychainRequest) GetAccount() uint32 {
+	if x != nil {
+		return x.Account
+	}
+	return 0
+}
+
+func (x *KeychainRequest) GetIncludeSpendSecret() bool {
+	if x != nil {
+		return x.IncludeSpendSecret
+	}
+	return false
+}
+
+type KeychainResponse struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// An array of output IDs to perform checks for.
-	OutputId      []string `protobuf:"bytes,1,rep,name=output_id,json=outputId,proto3" json:"output_id,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	// The scan secret or view key of the account.
+	ScanSecret []byte `protobuf:"bytes,1,opt,name=scan_secret,json=scanSecret,proto3" json:"scan_secret,omitempty"`
+	// The public key of the spend secret.
+	SpendPubkey []byte `protobuf:"bytes,2,opt,name=spend_pubkey,json=spendPubkey,proto3" json:"spend_pubkey,omitempty"`
+	// The spend secret, if requested.
+	SpendSecret []byte `protobuf:"bytes,3,opt,name=spend_secret,json=spendSecret,proto3" json:"spend_secret,omitempty"`
+	// The MWEB output descriptor of the account. This contains the
+	// spend secret only if it was requested.
+	AccountDescriptor string `protobuf:"bytes,4,opt,name=account_descriptor,json=accountDescriptor,proto3" json:"account_descriptor,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
 }
 
-func (x *SpentRequest) Reset() {
-	*x = SpentRequest{}
+func (x *KeychainResponse) Reset() {
+	*x = KeychainResponse{}
 	mi := &file_mwebd_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *SpentRequest) String() string {
+func (x *KeychainResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SpentRequest) ProtoMessage() {}
+func (*KeychainResponse) ProtoMessage() {}
 
-func (x *SpentRequest) ProtoReflect() protoreflect.Message {
+func (x *KeychainResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_mwebd_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -467,42 +772,73 @@ func (x *SpentRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SpentRequest.ProtoReflect.Descriptor instead.
-func (*SpentRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use KeychainResponse.ProtoReflect.Descriptor instead.
+func (*KeychainResponse) Descriptor() ([]byte, []int) {
 	return file_mwebd_proto_rawDescGZIP(), []int{7}
 }
 
-func (x *SpentRequest) GetOutputId() []string {
+func (x *KeychainResponse) GetScanSecret() []byte {
 	if x != nil {
-		return x.OutputId
+		return x.ScanSecret
 	}
 	return nil
 }
 
-type SpentResponse struct {
+func (x *KeychainResponse) GetSpendPubkey() []byte {
+	if x != nil {
+		return x.SpendPubkey
+	}
+	return nil
+}
+
+func (x *KeychainResponse) GetSpendSecret() []byte {
+	if x != nil {
+		return x.SpendSecret
+	}
+	return nil
+}
+
+func (x *KeychainResponse) GetAccountDescriptor() string {
+	if x != nil {
+		return x.AccountDescriptor
+	}
+	return ""
+}
+
+type ValidateAddressRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// An array of the output IDs that were not found in the
-	// unspent set. This means that the outputs are either
-	// unconfirmed or were spent.
-	OutputId      []string `protobuf:"bytes,1,rep,name=output_id,json=outputId,proto3" json:"output_id,omitempty"`
+	// The address to validate.
+	Address string `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	// The scan secret of an account to check MWEB addresses against.
+	ScanSecret []byte `protobuf:"bytes,2,opt,name=scan_secret,json=scanSecret,proto3" json:"scan_secret,omitempty"`
+	// The spend pubkey of the account. This is needed to find the
+	// index of an address, but not to check if it belongs to the
+	// account.
+	SpendPubkey []byte `protobuf:"bytes,3,opt,name=spend_pubkey,json=spendPubkey,proto3" json:"spend_pubkey,omitempty"`
+	// An MWEB output descriptor for the account, as an alternative
+	// to the raw keys.
+	AccountDescriptor string `protobuf:"bytes,4,opt,name=account_descriptor,json=accountDescriptor,proto3" json:"account_descriptor,omitempty"`
+	// Address indices below this are searched. Defaults to 1000,
+	// and may be at most 100000.
+	MaxIndex      uint32 `protobuf:"varint,5,opt,name=max_index,json=maxIndex,proto3" json:"max_index,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *SpentResponse) Reset() {
-	*x = SpentResponse{}
+func (x *ValidateAddressRequest) Reset() {
+	*x = ValidateAddressRequest{}
 	mi := &file_mwebd_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *SpentResponse) String() string {
+func (x *ValidateAddressRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SpentResponse) ProtoMessage() {}
+func (*ValidateAddressRequest) ProtoMessage() {}
 
-func (x *SpentResponse) ProtoReflect() protoreflect.Message {
+func (x *ValidateAddressRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_mwebd_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -514,60 +850,89 @@ func (x *SpentResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SpentResponse.ProtoReflect.Descriptor instead.
-func (*SpentResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use ValidateAddressRequest.ProtoReflect.Descriptor instead.
+func (*ValidateAddressRequest) Descriptor() ([]byte, []int) {
 	return file_mwebd_proto_rawDescGZIP(), []int{8}
 }
 
-func (x *SpentResponse) GetOutputId() []string {
+func (x *ValidateAddressRequest) GetAddress() string {
 	if x != nil {
-		return x.OutputId
+		return x.Address
+	}
+	return ""
+}
+
+func (x *ValidateAddressRequest) GetScanSecret() []byte {
+	if x != nil {
+		return x.ScanSecret
 	}
 	return nil
 }
 
-type CreateRequest struct {
+func (x *ValidateAddressRequest) GetSpendPubkey() []byte {
+	if x != nil {
+		return x.SpendPubkey
+	}
+	return nil
+}
+
+func (x *ValidateAddressRequest) GetAccountDescriptor() string {
+	if x != nil {
+		return x.AccountDescriptor
+	}
+	return ""
+}
+
+func (x *ValidateAddressRequest) GetMaxIndex() uint32 {
+	if x != nil {
+		return x.MaxIndex
+	}
+	return 0
+}
+
+type ValidateAddressResponse struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// The raw bytes of the serialized transaction. This will be
-	// a template where the non-MWEB inputs will remain unchanged,
-	// and the MWEB inputs are specified by TxIns with the outpoint
-	// hash set to the output ID of the utxo being spent, and the
-	// outpoint index set to the index of the address that the utxo
-	// was received on. MWEB outputs are specified by TxOuts with
-	// the script pubkey set to the serialized scan and spend pubkeys
-	// of the destination MWEB address. Any non-MWEB outputs will be
-	// transformed into MWEB peg-outs. If the transaction doesn't
-	// contain any MWEB i/o then the result will be unchanged.
-	RawTx []byte `protobuf:"bytes,1,opt,name=raw_tx,json=rawTx,proto3" json:"raw_tx,omitempty"`
-	// The scan secret or view key represents the account that
-	// the utxos being spent belong to.
-	ScanSecret []byte `protobuf:"bytes,2,opt,name=scan_secret,json=scanSecret,proto3" json:"scan_secret,omitempty"`
-	// The spend secret is the private key necessary for spending
-	// the utxos belonging to the account.
-	SpendSecret []byte `protobuf:"bytes,3,opt,name=spend_secret,json=spendSecret,proto3" json:"spend_secret,omitempty"`
-	// The fee rate per KB in litoshis.
-	FeeRatePerKb uint64 `protobuf:"varint,4,opt,name=fee_rate_per_kb,json=feeRatePerKb,proto3" json:"fee_rate_per_kb,omitempty"`
-	// Whether to skip MWEB transaction creation. This is useful
-	// for fee estimation.
-	DryRun        bool `protobuf:"varint,5,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`
+	// Whether the address is valid for the daemon's network.
+	Valid bool `protobuf:"varint,1,opt,name=valid,proto3" json:"valid,omitempty"`
+	// Why the address is invalid.
+	Error string      `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	Type  AddressType `protobuf:"varint,3,opt,name=type,proto3,enum=AddressType" json:"type,omitempty"`
+	// The network that the address is for. If this isn't the
+	// daemon's network then wrong_network is set.
+	Network      string `protobuf:"bytes,4,opt,name=network,proto3" json:"network,omitempty"`
+	WrongNetwork bool   `protobuf:"varint,5,opt,name=wrong_network,json=wrongNetwork,proto3" json:"wrong_network,omitempty"`
+	// The script pubkey of the address. For MWEB addresses this is
+	// the serialized scan and spend pubkeys, as used for MWEB
+	// outputs in Create templates.
+	PkScript []byte `protobuf:"bytes,6,opt,name=pk_script,json=pkScript,proto3" json:"pk_script,omitempty"`
+	// The witness version of segwit addresses.
+	WitnessVersion uint32 `protobuf:"varint,7,opt,name=witness_version,json=witnessVersion,proto3" json:"witness_version,omitempty"`
+	// The scan and spend pubkeys of MWEB addresses.
+	ScanPubkey  []byte `protobuf:"bytes,8,opt,name=scan_pubkey,json=scanPubkey,proto3" json:"scan_pubkey,omitempty"`
+	SpendPubkey []byte `protobuf:"bytes,9,opt,name=spend_pubkey,json=spendPubkey,proto3" json:"spend_pubkey,omitempty"`
+	// Whether the MWEB address belongs to the account's scan secret.
+	IsMine bool `protobuf:"varint,10,opt,name=is_mine,json=isMine,proto3" json:"is_mine,omitempty"`
+	// The index of the address in the account. This is only set
+	// if the spend pubkey was given and the index is below max_index.
+	Index         *uint32 `protobuf:"varint,11,opt,name=index,proto3,oneof" json:"index,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CreateRequest) Reset() {
-	*x = CreateRequest{}
+func (x *ValidateAddressResponse) Reset() {
+	*x = ValidateAddressResponse{}
 	mi := &file_mwebd_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CreateRequest) String() string {
+func (x *ValidateAddressResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateRequest) ProtoMessage() {}
+func (*ValidateAddressResponse) ProtoMessage() {}
 
-func (x *CreateRequest) ProtoReflect() protoreflect.Message {
+func (x *ValidateAddressResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_mwebd_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -579,23 +944,321 @@ func (x *CreateRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateRequest.ProtoReflect.Descriptor instead.
-func (*CreateRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use ValidateAddressResponse.ProtoReflect.Descriptor instead.
+func (*ValidateAddressResponse) Descriptor() ([]byte, []int) {
 	return file_mwebd_proto_rawDescGZIP(), []int{9}
 }
 
-func (x *CreateRequest) GetRawTx() []byte {
+func (x *ValidateAddressResponse) GetValid() bool {
 	if x != nil {
-		return x.RawTx
+		return x.Valid
 	}
-	return nil
+	return false
 }
 
-func (x *CreateRequest) GetScanSecret() []byte {
+func (x *ValidateAddressResponse) GetError() string {
 	if x != nil {
-		return x.ScanSecret
+		return x.Error
 	}
-	return nil
+	return ""
+}
+
+func (x *ValidateAddressResponse) GetType() AddressType {
+	if x != nil {
+		return x.Type
+	}
+	return AddressType_ADDRESS_UNKNOWN
+}
+
+func (x *ValidateAddressResponse) GetNetwork() string {
+	if x != nil {
+		return x.Network
+	}
+	return ""
+}
+
+func (x *ValidateAddressResponse) GetWrongNetwork() bool {
+	if x != nil {
+		return x.WrongNetwork
+	}
+	return false
+}
+
+func (x *ValidateAddressResponse) GetPkScript() []byte {
+	if x != nil {
+		return x.PkScript
+	}
+	return nil
+}
+
+func (x *ValidateAddressResponse) GetWitnessVersion() uint32 {
+	if x != nil {
+		return x.WitnessVersion
+	}
+	return 0
+}
+
+func (x *ValidateAddressResponse) GetScanPubkey() []byte {
+	if x != nil {
+		return x.ScanPubkey
+	}
+	return nil
+}
+
+func (x *ValidateAddressResponse) GetSpendPubkey() []byte {
+	if x != nil {
+		return x.SpendPubkey
+	}
+	return nil
+}
+
+func (x *ValidateAddressResponse) GetIsMine() bool {
+	if x != nil {
+		return x.IsMine
+	}
+	return false
+}
+
+func (x *ValidateAddressResponse) GetIndex() uint32 {
+	if x != nil && x.Index != nil {
+		return *x.Index
+	}
+	return 0
+}
+
+type LedgerApdu struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Data          []byte                 `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LedgerApdu) Reset() {
+	*x = LedgerApdu{}
+	mi := &file_mwebd_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LedgerApdu) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LedgerApdu) ProtoMessage() {}
+
+func (x *LedgerApdu) ProtoReflect() protoreflect.Message {
+	mi := &file_mwebd_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LedgerApdu.ProtoReflect.Descriptor instead.
+func (*LedgerApdu) Descriptor() ([]byte, []int) {
+	return file_mwebd_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *LedgerApdu) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type SpentRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// An array of output IDs to perform checks for.
+	OutputId      []string `protobuf:"bytes,1,rep,name=output_id,json=outputId,proto3" json:"output_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SpentRequest) Reset() {
+	*x = SpentRequest{}
+	mi := &file_mwebd_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SpentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SpentRequest) ProtoMessage() {}
+
+func (x *SpentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_mwebd_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SpentRequest.ProtoReflect.Descriptor instead.
+func (*SpentRequest) Descriptor() ([]byte, []int) {
+	return file_mwebd_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *SpentRequest) GetOutputId() []string {
+	if x != nil {
+		return x.OutputId
+	}
+	return nil
+}
+
+type SpentResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// An array of the output IDs that were not found in the
+	// unspent set. This means that the outputs are either
+	// unconfirmed or were spent.
+	OutputId      []string `protobuf:"bytes,1,rep,name=output_id,json=outputId,proto3" json:"output_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SpentResponse) Reset() {
+	*x = SpentResponse{}
+	mi := &file_mwebd_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SpentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SpentResponse) ProtoMessage() {}
+
+func (x *SpentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_mwebd_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SpentResponse.ProtoReflect.Descriptor instead.
+func (*SpentResponse) Descriptor() ([]byte, []int) {
+	return file_mwebd_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *SpentResponse) GetOutputId() []string {
+	if x != nil {
+		return x.OutputId
+	}
+	return nil
+}
+
+type CreateRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The raw bytes of the serialized transaction. This will be
+	// a template where the non-MWEB inputs will remain unchanged,
+	// and the MWEB inputs are specified by TxIns with the outpoint
+	// hash set to the output ID of the utxo being spent, and the
+	// outpoint index set to the index of the address that the utxo
+	// was received on. MWEB outputs are specified by TxOuts with
+	// the script pubkey set to the serialized scan and spend pubkeys
+	// of the destination MWEB address. Any non-MWEB outputs will be
+	// transformed into MWEB peg-outs. If the transaction doesn't
+	// contain any MWEB i/o then the result will be unchanged.
+	//
+	// If the template already contains an MWEB transaction (e.g.
+	// one built by another party) then our inputs, outputs and a
+	// separate kernel are appended to it, so that multiple parties
+	// can each contribute to the same MWEB transaction without
+	// sharing keys. Peg-in outputs belonging to the existing
+	// kernels are passed through unchanged.
+	//
+	// If the lock time of the template is a block height, then it
+	// will also be used as the lock height of the MWEB kernel.
+	RawTx []byte `protobuf:"bytes,1,opt,name=raw_tx,json=rawTx,proto3" json:"raw_tx,omitempty"`
+	// The scan secret or view key represents the account that
+	// the utxos being spent belong to.
+	ScanSecret []byte `protobuf:"bytes,2,opt,name=scan_secret,json=scanSecret,proto3" json:"scan_secret,omitempty"`
+	// The spend secret is the private key necessary for spending
+	// the utxos belonging to the account. It's zeroed once used.
+	SpendSecret []byte `protobuf:"bytes,3,opt,name=spend_secret,json=spendSecret,proto3" json:"spend_secret,omitempty"`
+	// The fee rate per KB in litoshis.
+	FeeRatePerKb uint64 `protobuf:"varint,4,opt,name=fee_rate_per_kb,json=feeRatePerKb,proto3" json:"fee_rate_per_kb,omitempty"`
+	// Whether to skip MWEB transaction creation. This is useful
+	// for fee estimation.
+	DryRun bool `protobuf:"varint,5,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`
+	// Whether to send everything minus fees. The TxOut at
+	// sweep_index will have its value set to whatever remains of
+	// the MWEB inputs after the other outputs and the fee. Rather
+	// than creating a peg-in, an error is returned if the MWEB
+	// inputs are insufficient.
+	Sweep bool `protobuf:"varint,6,opt,name=sweep,proto3" json:"sweep,omitempty"`
+	// The index of the TxOut in the template to sweep to.
+	SweepIndex uint32 `protobuf:"varint,7,opt,name=sweep_index,json=sweepIndex,proto3" json:"sweep_index,omitempty"`
+	// Whether a peg-in may be created to fund any shortfall.
+	PeginPolicy PeginPolicy `protobuf:"varint,8,opt,name=pegin_policy,json=peginPolicy,proto3,enum=PeginPolicy" json:"pegin_policy,omitempty"`
+	// The exact peg-in amount required when using PEGIN_EXACT.
+	PeginAmount uint64 `protobuf:"varint,9,opt,name=pegin_amount,json=peginAmount,proto3" json:"pegin_amount,omitempty"`
+	// An MWEB output descriptor for the account, as an alternative
+	// to the raw keys. See sign.Descriptor for the syntax. The
+	// descriptor must contain the spend secret, as a watch-only
+	// descriptor can't be used to sign.
+	AccountDescriptor string `protobuf:"bytes,10,opt,name=account_descriptor,json=accountDescriptor,proto3" json:"account_descriptor,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *CreateRequest) Reset() {
+	*x = CreateRequest{}
+	mi := &file_mwebd_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateRequest) ProtoMessage() {}
+
+func (x *CreateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_mwebd_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateRequest.ProtoReflect.Descriptor instead.
+func (*CreateRequest) Descriptor() ([]byte, []int) {
+	return file_mwebd_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *CreateRequest) GetRawTx() []byte {
+	if x != nil {
+		return x.RawTx
+	}
+	return nil
+}
+
+func (x *CreateRequest) GetScanSecret() []byte {
+	if x != nil {
+		return x.ScanSecret
+	}
+	return nil
 }
 
 func (x *CreateRequest) GetSpendSecret() []byte {
@@ -605,49 +1268,2781 @@ func (x *CreateRequest) GetSpendSecret() []byte {
 	return nil
 }
 
-func (x *CreateRequest) GetFeeRatePerKb() uint64 {
+func (x *CreateRequest) GetFeeRatePerKb() uint64 {
+	if x != nil {
+		return x.FeeRatePerKb
+	}
+	return 0
+}
+
+func (x *CreateRequest) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
+func (x *CreateRequest) GetSweep() bool {
+	if x != nil {
+		return x.Sweep
+	}
+	return false
+}
+
+func (x *CreateRequest) GetSweepIndex() uint32 {
+	if x != nil {
+		return x.SweepIndex
+	}
+	return 0
+}
+
+func (x *CreateRequest) GetPeginPolicy() PeginPolicy {
+	if x != nil {
+		return x.PeginPolicy
+	}
+	return PeginPolicy_PEGIN_ALLOW
+}
+
+func (x *CreateRequest) GetPeginAmount() uint64 {
+	if x != nil {
+		return x.PeginAmount
+	}
+	return 0
+}
+
+func (x *CreateRequest) GetAccountDescriptor() string {
+	if x != nil {
+		return x.AccountDescriptor
+	}
+	return ""
+}
+
+type CreateResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The raw bytes of the serialized transaction. It will contain
+	// a single TxOut representing the peg-in required. From this
+	// it is possible to determine the addtional fee that was added
+	// by the MWEB transaction.
+	RawTx []byte `protobuf:"bytes,1,opt,name=raw_tx,json=rawTx,proto3" json:"raw_tx,omitempty"`
+	// The output IDs of any utxos created by the transaction,
+	// in the same order as in the template.
+	OutputId []string `protobuf:"bytes,2,rep,name=output_id,json=outputId,proto3" json:"output_id,omitempty"`
+	// A description of the peg-in, if one was required.
+	Pegin         *Pegin `protobuf:"bytes,3,opt,name=pegin,proto3" json:"pegin,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateResponse) Reset() {
+	*x = CreateResponse{}
+	mi := &file_mwebd_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateResponse) ProtoMessage() {}
+
+func (x *CreateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_mwebd_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateResponse.ProtoReflect.Descriptor instead.
+func (*CreateResponse) Descriptor() ([]byte, []int) {
+	return file_mwebd_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *CreateResponse) GetRawTx() []byte {
+	if x != nil {
+		return x.RawTx
+	}
+	return nil
+}
+
+func (x *CreateResponse) GetOutputId() []string {
+	if x != nil {
+		return x.OutputId
+	}
+	return nil
+}
+
+func (x *CreateResponse) GetPegin() *Pegin {
+	if x != nil {
+		return x.Pegin
+	}
+	return nil
+}
+
+type Pegin struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The value of the peg-in output in litoshis.
+	Value uint64 `protobuf:"varint,1,opt,name=value,proto3" json:"value,omitempty"`
+	// The script pubkey of the peg-in output.
+	PkScript []byte `protobuf:"bytes,2,opt,name=pk_script,json=pkScript,proto3" json:"pk_script,omitempty"`
+	// The index of the peg-in output in the transaction.
+	OutputIndex uint32 `protobuf:"varint,3,opt,name=output_index,json=outputIndex,proto3" json:"output_index,omitempty"`
+	// The hash of the MWEB kernel that the peg-in commits to.
+	// This is empty for dry runs.
+	KernelHash string `protobuf:"bytes,4,opt,name=kernel_hash,json=kernelHash,proto3" json:"kernel_hash,omitempty"`
+	// The outpoints of the transparent inputs of the transaction,
+	// which fund the peg-in. This is empty if the template already
+	// contained an MWEB transaction, as the transparent inputs may
+	// then fund the other parties' peg-ins.
+	FundingInput  []string `protobuf:"bytes,5,rep,name=funding_input,json=fundingInput,proto3" json:"funding_input,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Pegin) Reset() {
+	*x = Pegin{}
+	mi := &file_mwebd_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Pegin) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Pegin) ProtoMessage() {}
+
+func (x *Pegin) ProtoReflect() protoreflect.Message {
+	mi := &file_mwebd_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Pegin.ProtoReflect.Descriptor instead.
+func (*Pegin) Descriptor() ([]byte, []int) {
+	return file_mwebd_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *Pegin) GetValue() uint64 {
+	if x != nil {
+		return x.Value
+	}
+	return 0
+}
+
+func (x *Pegin) GetPkScript() []byte {
+	if x != nil {
+		return x.PkScript
+	}
+	return nil
+}
+
+func (x *Pegin) GetOutputIndex() uint32 {
+	if x != nil {
+		return x.OutputIndex
+	}
+	return 0
+}
+
+func (x *Pegin) GetKernelHash() string {
+	if x != nil {
+		return x.KernelHash
+	}
+	return ""
+}
+
+func (x *Pegin) GetFundingInput() []string {
+	if x != nil {
+		return x.FundingInput
+	}
+	return nil
+}
+
+type EstimateFeeRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The raw bytes of the serialized transaction template, as
+	// specified in CreateRequest.
+	RawTx []byte `protobuf:"bytes,1,opt,name=raw_tx,json=rawTx,proto3" json:"raw_tx,omitempty"`
+	// The scan secret or view key represents the account that
+	// the utxos being spent belong to. Only required with raw_tx.
+	ScanSecret []byte `protobuf:"bytes,2,opt,name=scan_secret,json=scanSecret,proto3" json:"scan_secret,omitempty"`
+	// The fee rate per KB in litoshis.
+	FeeRatePerKb uint64 `protobuf:"varint,3,opt,name=fee_rate_per_kb,json=feeRatePerKb,proto3" json:"fee_rate_per_kb,omitempty"`
+	// The PSBT in base64 encoding. This is used instead of raw_tx
+	// if set.
+	PsbtB64       string `protobuf:"bytes,4,opt,name=psbt_b64,json=psbtB64,proto3" json:"psbt_b64,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EstimateFeeRequest) Reset() {
+	*x = EstimateFeeRequest{}
+	mi := &file_mwebd_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EstimateFeeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EstimateFeeRequest) ProtoMessage() {}
+
+func (x *EstimateFeeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_mwebd_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EstimateFeeRequest.ProtoReflect.Descriptor instead.
+func (*EstimateFeeRequest) Descriptor() ([]byte, []int) {
+	return file_mwebd_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *EstimateFeeRequest) GetRawTx() []byte {
+	if x != nil {
+		return x.RawTx
+	}
+	return nil
+}
+
+func (x *EstimateFeeRequest) GetScanSecret() []byte {
+	if x != nil {
+		return x.ScanSecret
+	}
+	return nil
+}
+
+func (x *EstimateFeeRequest) GetFeeRatePerKb() uint64 {
+	if x != nil {
+		return x.FeeRatePerKb
+	}
+	return 0
+}
+
+func (x *EstimateFeeRequest) GetPsbtB64() string {
+	if x != nil {
+		return x.PsbtB64
+	}
+	return ""
+}
+
+type EstimateFeeResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The fee for the weight of the MWEB inputs, outputs and kernels.
+	MwebFee uint64 `protobuf:"varint,1,opt,name=mweb_fee,json=mwebFee,proto3" json:"mweb_fee,omitempty"`
+	// The fee for the size of the peg-out outputs, which are added
+	// to the HogEx transaction.
+	PegoutFee uint64 `protobuf:"varint,2,opt,name=pegout_fee,json=pegoutFee,proto3" json:"pegout_fee,omitempty"`
+	// The fee committed to by the kernel. This is the sum of the
+	// above, unless the MWEB inputs exceed the outputs and there is
+	// no change, in which case the excess is added to the fee.
+	KernelFee uint64 `protobuf:"varint,3,opt,name=kernel_fee,json=kernelFee,proto3" json:"kernel_fee,omitempty"`
+	// The peg-in amount required to fund the transaction.
+	Pegin uint64 `protobuf:"varint,4,opt,name=pegin,proto3" json:"pegin,omitempty"`
+	// The fee for the HogEx input spending the peg-in output. This
+	// is zero if no peg-in is required.
+	HogexFee uint64 `protobuf:"varint,5,opt,name=hogex_fee,json=hogexFee,proto3" json:"hogex_fee,omitempty"`
+	// The total additional fee to be paid by the transaction, i.e.
+	// kernel_fee + hogex_fee.
+	TotalFee      uint64 `protobuf:"varint,6,opt,name=total_fee,json=totalFee,proto3" json:"total_fee,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EstimateFeeResponse) Reset() {
+	*x = EstimateFeeResponse{}
+	mi := &file_mwebd_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EstimateFeeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EstimateFeeResponse) ProtoMessage() {}
+
+func (x *EstimateFeeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_mwebd_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EstimateFeeResponse.ProtoReflect.Descriptor instead.
+func (*EstimateFeeResponse) Descriptor() ([]byte, []int) {
+	return file_mwebd_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *EstimateFeeResponse) GetMwebFee() uint64 {
+	if x != nil {
+		return x.MwebFee
+	}
+	return 0
+}
+
+func (x *EstimateFeeResponse) GetPegoutFee() uint64 {
+	if x != nil {
+		return x.PegoutFee
+	}
+	return 0
+}
+
+func (x *EstimateFeeResponse) GetKernelFee() uint64 {
+	if x != nil {
+		return x.KernelFee
+	}
+	return 0
+}
+
+func (x *EstimateFeeResponse) GetPegin() uint64 {
+	if x != nil {
+		return x.Pegin
+	}
+	return 0
+}
+
+func (x *EstimateFeeResponse) GetHogexFee() uint64 {
+	if x != nil {
+		return x.HogexFee
+	}
+	return 0
+}
+
+func (x *EstimateFeeResponse) GetTotalFee() uint64 {
+	if x != nil {
+		return x.TotalFee
+	}
+	return 0
+}
+
+type PsbtCreateRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The raw bytes of the serialized transaction. If the lock time
+	// is a block height, then it will also be used as the lock height
+	// of any MWEB kernels added to the PSBT.
+	RawTx []byte `protobuf:"bytes,1,opt,name=raw_tx,json=rawTx,proto3" json:"raw_tx,omitempty"`
+	// Witness utxos for each input.
+	WitnessUtxo   []*TxOut `protobuf:"bytes,2,rep,name=witness_utxo,json=witnessUtxo,proto3" json:"witness_utxo,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PsbtCreateRequest) Reset() {
+	*x = PsbtCreateRequest{}
+	mi := &file_mwebd_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PsbtCreateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PsbtCreateRequest) ProtoMessage() {}
+
+func (x *PsbtCreateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_mwebd_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PsbtCreateRequest.ProtoReflect.Descriptor instead.
+func (*PsbtCreateRequest) Descriptor() ([]byte, []int) {
+	return file_mwebd_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *PsbtCreateRequest) GetRawTx() []byte {
+	if x != nil {
+		return x.RawTx
+	}
+	return nil
+}
+
+func (x *PsbtCreateRequest) GetWitnessUtxo() []*TxOut {
+	if x != nil {
+		return x.WitnessUtxo
+	}
+	return nil
+}
+
+type TxOut struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Value         int64                  `protobuf:"varint,1,opt,name=value,proto3" json:"value,omitempty"`
+	PkScript      []byte                 `protobuf:"bytes,2,opt,name=pk_script,json=pkScript,proto3" json:"pk_script,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TxOut) Reset() {
+	*x = TxOut{}
+	mi := &file_mwebd_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TxOut) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TxOut) ProtoMessage() {}
+
+func (x *TxOut) ProtoReflect() protoreflect.Message {
+	mi := &file_mwebd_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TxOut.ProtoReflect.Descriptor instead.
+func (*TxOut) Descriptor() ([]byte, []int) {
+	return file_mwebd_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *TxOut) GetValue() int64 {
+	if x != nil {
+		return x.Value
+	}
+	return 0
+}
+
+func (x *TxOut) GetPkScript() []byte {
+	if x != nil {
+		return x.PkScript
+	}
+	return nil
+}
+
+type PsbtResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The PSBT in base64 encoding.
+	PsbtB64       string `protobuf:"bytes,1,opt,name=psbt_b64,json=psbtB64,proto3" json:"psbt_b64,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PsbtResponse) Reset() {
+	*x = PsbtResponse{}
+	mi := &file_mwebd_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PsbtResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PsbtResponse) ProtoMessage() {}
+
+func (x *PsbtResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_mwebd_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PsbtResponse.ProtoReflect.Descriptor instead.
+func (*PsbtResponse) Descriptor() ([]byte, []int) {
+	return file_mwebd_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *PsbtResponse) GetPsbtB64() string {
+	if x != nil {
+		return x.PsbtB64
+	}
+	return ""
+}
+
+type PsbtAddInputRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The PSBT in base64 encoding.
+	PsbtB64 string `protobuf:"bytes,1,opt,name=psbt_b64,json=psbtB64,proto3" json:"psbt_b64,omitempty"`
+	// The scan secret or view key represents the account that
+	// the utxos being spent belong to.
+	ScanSecret []byte `protobuf:"bytes,2,opt,name=scan_secret,json=scanSecret,proto3" json:"scan_secret,omitempty"`
+	// The output ID of the utxo.
+	OutputId string `protobuf:"bytes,3,opt,name=output_id,json=outputId,proto3" json:"output_id,omitempty"`
+	// The address index of the utxo.
+	AddressIndex uint32 `protobuf:"varint,4,opt,name=address_index,json=addressIndex,proto3" json:"address_index,omitempty"`
+	// The fee rate per KB in litoshis.
+	FeeRatePerKb  uint64 `protobuf:"varint,5,opt,name=fee_rate_per_kb,json=feeRatePerKb,proto3" json:"fee_rate_per_kb,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PsbtAddInputRequest) Reset() {
+	*x = PsbtAddInputRequest{}
+	mi := &file_mwebd_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PsbtAddInputRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PsbtAddInputRequest) ProtoMessage() {}
+
+func (x *PsbtAddInputRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_mwebd_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PsbtAddInputRequest.ProtoReflect.Descriptor instead.
+func (*PsbtAddInputRequest) Descriptor() ([]byte, []int) {
+	return file_mwebd_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *PsbtAddInputRequest) GetPsbtB64() string {
+	if x != nil {
+		return x.PsbtB64
+	}
+	return ""
+}
+
+func (x *PsbtAddInputRequest) GetScanSecret() []byte {
+	if x != nil {
+		return x.ScanSecret
+	}
+	return nil
+}
+
+func (x *PsbtAddInputRequest) GetOutputId() string {
+	if x != nil {
+		return x.OutputId
+	}
+	return ""
+}
+
+func (x *PsbtAddInputRequest) GetAddressIndex() uint32 {
+	if x != nil {
+		return x.AddressIndex
+	}
+	return 0
+}
+
+func (x *PsbtAddInputRequest) GetFeeRatePerKb() uint64 {
+	if x != nil {
+		return x.FeeRatePerKb
+	}
+	return 0
+}
+
+type PsbtAddRecipientRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The PSBT in base64 encoding.
+	PsbtB64   string         `protobuf:"bytes,1,opt,name=psbt_b64,json=psbtB64,proto3" json:"psbt_b64,omitempty"`
+	Recipient *PsbtRecipient `protobuf:"bytes,2,opt,name=recipient,proto3" json:"recipient,omitempty"`
+	// The fee rate per KB in litoshis.
+	FeeRatePerKb uint64 `protobuf:"varint,3,opt,name=fee_rate_per_kb,json=feeRatePerKb,proto3" json:"fee_rate_per_kb,omitempty"`
+	// Whether the recipient should receive whatever remains of
+	// the MWEB inputs after the other outputs and the fee, in which
+	// case the recipient value is ignored. Rather than creating a
+	// peg-in, an error is returned if the MWEB inputs are
+	// insufficient. This should be the last recipient added.
+	Sweep         bool `protobuf:"varint,4,opt,name=sweep,proto3" json:"sweep,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PsbtAddRecipientRequest) Reset() {
+	*x = PsbtAddRecipientRequest{}
+	mi := &file_mwebd_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PsbtAddRecipientRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PsbtAddRecipientRequest) ProtoMessage() {}
+
+func (x *PsbtAddRecipientRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_mwebd_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PsbtAddRecipientRequest.ProtoReflect.Descriptor instead.
+func (*PsbtAddRecipientRequest) Descriptor() ([]byte, []int) {
+	return file_mwebd_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *PsbtAddRecipientRequest) GetPsbtB64() string {
+	if x != nil {
+		return x.PsbtB64
+	}
+	return ""
+}
+
+func (x *PsbtAddRecipientRequest) GetRecipient() *PsbtRecipient {
+	if x != nil {
+		return x.Recipient
+	}
+	return nil
+}
+
+func (x *PsbtAddRecipientRequest) GetFeeRatePerKb() uint64 {
+	if x != nil {
+		return x.FeeRatePerKb
+	}
+	return 0
+}
+
+func (x *PsbtAddRecipientRequest) GetSweep() bool {
+	if x != nil {
+		return x.Sweep
+	}
+	return false
+}
+
+// Inputs and recipients that have been signed, or peg-outs in a
+// signed kernel, can't be removed or updated. The fee and peg-in
+// of the unsigned kernel are adjusted as in PsbtAddRecipient.
+type PsbtRemoveInputRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The PSBT in base64 encoding.
+	PsbtB64 string `protobuf:"bytes,1,opt,name=psbt_b64,json=psbtB64,proto3" json:"psbt_b64,omitempty"`
+	// The index of the input.
+	Index uint32 `protobuf:"varint,2,opt,name=index,proto3" json:"index,omitempty"`
+	// The fee rate per KB in litoshis.
+	FeeRatePerKb  uint64 `protobuf:"varint,3,opt,name=fee_rate_per_kb,json=feeRatePerKb,proto3" json:"fee_rate_per_kb,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PsbtRemoveInputRequest) Reset() {
+	*x = PsbtRemoveInputRequest{}
+	mi := &file_mwebd_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PsbtRemoveInputRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PsbtRemoveInputRequest) ProtoMessage() {}
+
+func (x *PsbtRemoveInputRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_mwebd_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PsbtRemoveInputRequest.ProtoReflect.Descriptor instead.
+func (*PsbtRemoveInputRequest) Descriptor() ([]byte, []int) {
+	return file_mwebd_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *PsbtRemoveInputRequest) GetPsbtB64() string {
+	if x != nil {
+		return x.PsbtB64
+	}
+	return ""
+}
+
+func (x *PsbtRemoveInputRequest) GetIndex() uint32 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *PsbtRemoveInputRequest) GetFeeRatePerKb() uint64 {
+	if x != nil {
+		return x.FeeRatePerKb
+	}
+	return 0
+}
+
+type PsbtRemoveRecipientRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The PSBT in base64 encoding.
+	PsbtB64 string `protobuf:"bytes,1,opt,name=psbt_b64,json=psbtB64,proto3" json:"psbt_b64,omitempty"`
+	// The index of the recipient, in the order returned by
+	// PsbtGetRecipients.
+	Index uint32 `protobuf:"varint,2,opt,name=index,proto3" json:"index,omitempty"`
+	// The fee rate per KB in litoshis.
+	FeeRatePerKb  uint64 `protobuf:"varint,3,opt,name=fee_rate_per_kb,json=feeRatePerKb,proto3" json:"fee_rate_per_kb,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PsbtRemoveRecipientRequest) Reset() {
+	*x = PsbtRemoveRecipientRequest{}
+	mi := &file_mwebd_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PsbtRemoveRecipientRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PsbtRemoveRecipientRequest) ProtoMessage() {}
+
+func (x *PsbtRemoveRecipientRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_mwebd_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PsbtRemoveRecipientRequest.ProtoReflect.Descriptor instead.
+func (*PsbtRemoveRecipientRequest) Descriptor() ([]byte, []int) {
+	return file_mwebd_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *PsbtRemoveRecipientRequest) GetPsbtB64() string {
+	if x != nil {
+		return x.PsbtB64
+	}
+	return ""
+}
+
+func (x *PsbtRemoveRecipientRequest) GetIndex() uint32 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *PsbtRemoveRecipientRequest) GetFeeRatePerKb() uint64 {
+	if x != nil {
+		return x.FeeRatePerKb
+	}
+	return 0
+}
+
+type PsbtUpdateRecipientRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The PSBT in base64 encoding.
+	PsbtB64 string `protobuf:"bytes,1,opt,name=psbt_b64,json=psbtB64,proto3" json:"psbt_b64,omitempty"`
+	// The index of the recipient, in the order returned by
+	// PsbtGetRecipients. If the update changes the recipient between
+	// an MWEB output and a peg-out, then it is moved to the end.
+	Index     uint32         `protobuf:"varint,2,opt,name=index,proto3" json:"index,omitempty"`
+	Recipient *PsbtRecipient `protobuf:"bytes,3,opt,name=recipient,proto3" json:"recipient,omitempty"`
+	// The fee rate per KB in litoshis.
+	FeeRatePerKb  uint64 `protobuf:"varint,4,opt,name=fee_rate_per_kb,json=feeRatePerKb,proto3" json:"fee_rate_per_kb,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PsbtUpdateRecipientRequest) Reset() {
+	*x = PsbtUpdateRecipientRequest{}
+	mi := &file_mwebd_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PsbtUpdateRecipientRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PsbtUpdateRecipientRequest) ProtoMessage() {}
+
+func (x *PsbtUpdateRecipientRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_mwebd_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PsbtUpdateRecipientRequest.ProtoReflect.Descriptor instead.
+func (*PsbtUpdateRecipientRequest) Descriptor() ([]byte, []int) {
+	return file_mwebd_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *PsbtUpdateRecipientRequest) GetPsbtB64() string {
+	if x != nil {
+		return x.PsbtB64
+	}
+	return ""
+}
+
+func (x *PsbtUpdateRecipientRequest) GetIndex() uint32 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *PsbtUpdateRecipientRequest) GetRecipient() *PsbtRecipient {
+	if x != nil {
+		return x.Recipient
+	}
+	return nil
+}
+
+func (x *PsbtUpdateRecipientRequest) GetFeeRatePerKb() uint64 {
+	if x != nil {
+		return x.FeeRatePerKb
+	}
+	return 0
+}
+
+type PsbtGetRecipientsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The PSBT in base64 encoding.
+	PsbtB64       string `protobuf:"bytes,1,opt,name=psbt_b64,json=psbtB64,proto3" json:"psbt_b64,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PsbtGetRecipientsRequest) Reset() {
+	*x = PsbtGetRecipientsRequest{}
+	mi := &file_mwebd_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PsbtGetRecipientsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PsbtGetRecipientsRequest) ProtoMessage() {}
+
+func (x *PsbtGetRecipientsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_mwebd_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PsbtGetRecipientsRequest.ProtoReflect.Descriptor instead.
+func (*PsbtGetRecipientsRequest) Descriptor() ([]byte, []int) {
+	return file_mwebd_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *PsbtGetRecipientsRequest) GetPsbtB64() string {
+	if x != nil {
+		return x.PsbtB64
+	}
+	return ""
+}
+
+type PsbtGetRecipientsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Recipient     []*PsbtRecipient       `protobuf:"bytes,1,rep,name=recipient,proto3" json:"recipient,omitempty"`
+	InputAddress  []string               `protobuf:"bytes,2,rep,name=input_address,json=inputAddress,proto3" json:"input_address,omitempty"`
+	Fee           int64                  `protobuf:"varint,3,opt,name=fee,proto3" json:"fee,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PsbtGetRecipientsResponse) Reset() {
+	*x = PsbtGetRecipientsResponse{}
+	mi := &file_mwebd_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PsbtGetRecipientsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PsbtGetRecipientsResponse) ProtoMessage() {}
+
+func (x *PsbtGetRecipientsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_mwebd_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PsbtGetRecipientsResponse.ProtoReflect.Descriptor instead.
+func (*PsbtGetRecipientsResponse) Descriptor() ([]byte, []int) {
+	return file_mwebd_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *PsbtGetRecipientsResponse) GetRecipient() []*PsbtRecipient {
+	if x != nil {
+		return x.Recipient
+	}
+	return nil
+}
+
+func (x *PsbtGetRecipientsResponse) GetInputAddress() []string {
+	if x != nil {
+		return x.InputAddress
+	}
+	return nil
+}
+
+func (x *PsbtGetRecipientsResponse) GetFee() int64 {
+	if x != nil {
+		return x.Fee
+	}
+	return 0
+}
+
+type PsbtRecipient struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Address       string                 `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	Value         int64                  `protobuf:"varint,2,opt,name=value,proto3" json:"value,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PsbtRecipient) Reset() {
+	*x = PsbtRecipient{}
+	mi := &file_mwebd_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PsbtRecipient) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PsbtRecipient) ProtoMessage() {}
+
+func (x *PsbtRecipient) ProtoReflect() protoreflect.Message {
+	mi := &file_mwebd_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PsbtRecipient.ProtoReflect.Descriptor instead.
+func (*PsbtRecipient) Descriptor() ([]byte, []int) {
+	return file_mwebd_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *PsbtRecipient) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *PsbtRecipient) GetValue() int64 {
+	if x != nil {
+		return x.Value
+	}
+	return 0
+}
+
+type PsbtDecodeRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The PSBT in base64 encoding.
+	PsbtB64       string `protobuf:"bytes,1,opt,name=psbt_b64,json=psbtB64,proto3" json:"psbt_b64,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PsbtDecodeRequest) Reset() {
+	*x = PsbtDecodeRequest{}
+	mi := &file_mwebd_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PsbtDecodeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PsbtDecodeRequest) ProtoMessage() {}
+
+func (x *PsbtDecodeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_mwebd_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PsbtDecodeRequest.ProtoReflect.Descriptor instead.
+func (*PsbtDecodeRequest) Descriptor() ([]byte, []int) {
+	return file_mwebd_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *PsbtDecodeRequest) GetPsbtB64() string {
+	if x != nil {
+		return x.PsbtB64
+	}
+	return ""
+}
+
+// Hashes, keys, commitments and scripts are hex encoded. Fields
+// that aren't present in the PSBT are left empty.
+type PsbtDecodeResponse struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	PsbtVersion      uint32                 `protobuf:"varint,1,opt,name=psbt_version,json=psbtVersion,proto3" json:"psbt_version,omitempty"`
+	TxVersion        int32                  `protobuf:"varint,2,opt,name=tx_version,json=txVersion,proto3" json:"tx_version,omitempty"`
+	FallbackLocktime uint32                 `protobuf:"varint,3,opt,name=fallback_locktime,json=fallbackLocktime,proto3" json:"fallback_locktime,omitempty"`
+	// The MWEB offsets are only set once MWEB components are signed.
+	MwebTxOffset      string               `protobuf:"bytes,4,opt,name=mweb_tx_offset,json=mwebTxOffset,proto3" json:"mweb_tx_offset,omitempty"`
+	MwebStealthOffset string               `protobuf:"bytes,5,opt,name=mweb_stealth_offset,json=mwebStealthOffset,proto3" json:"mweb_stealth_offset,omitempty"`
+	Input             []*PsbtDecodedInput  `protobuf:"bytes,6,rep,name=input,proto3" json:"input,omitempty"`
+	Output            []*PsbtDecodedOutput `protobuf:"bytes,7,rep,name=output,proto3" json:"output,omitempty"`
+	Kernel            []*PsbtDecodedKernel `protobuf:"bytes,8,rep,name=kernel,proto3" json:"kernel,omitempty"`
+	// The fee paid by the transaction, as in PsbtGetRecipients.
+	Fee int64 `protobuf:"varint,9,opt,name=fee,proto3" json:"fee,omitempty"`
+	// A JSON rendering of the above fields.
+	Json          string `protobuf:"bytes,10,opt,name=json,proto3" json:"json,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PsbtDecodeResponse) Reset() {
+	*x = PsbtDecodeResponse{}
+	mi := &file_mwebd_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PsbtDecodeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PsbtDecodeResponse) ProtoMessage() {}
+
+func (x *PsbtDecodeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_mwebd_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PsbtDecodeResponse.ProtoReflect.Descriptor instead.
+func (*PsbtDecodeResponse) Descriptor() ([]byte, []int) {
+	return file_mwebd_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *PsbtDecodeResponse) GetPsbtVersion() uint32 {
+	if x != nil {
+		return x.PsbtVersion
+	}
+	return 0
+}
+
+func (x *PsbtDecodeResponse) GetTxVersion() int32 {
+	if x != nil {
+		return x.TxVersion
+	}
+	return 0
+}
+
+func (x *PsbtDecodeResponse) GetFallbackLocktime() uint32 {
+	if x != nil {
+		return x.FallbackLocktime
+	}
+	return 0
+}
+
+func (x *PsbtDecodeResponse) GetMwebTxOffset() string {
+	if x != nil {
+		return x.MwebTxOffset
+	}
+	return ""
+}
+
+func (x *PsbtDecodeResponse) GetMwebStealthOffset() string {
+	if x != nil {
+		return x.MwebStealthOffset
+	}
+	return ""
+}
+
+func (x *PsbtDecodeResponse) GetInput() []*PsbtDecodedInput {
+	if x != nil {
+		return x.Input
+	}
+	return nil
+}
+
+func (x *PsbtDecodeResponse) GetOutput() []*PsbtDecodedOutput {
+	if x != nil {
+		return x.Output
+	}
+	return nil
+}
+
+func (x *PsbtDecodeResponse) GetKernel() []*PsbtDecodedKernel {
+	if x != nil {
+		return x.Kernel
+	}
+	return nil
+}
+
+func (x *PsbtDecodeResponse) GetFee() int64 {
+	if x != nil {
+		return x.Fee
+	}
+	return 0
+}
+
+func (x *PsbtDecodeResponse) GetJson() string {
+	if x != nil {
+		return x.Json
+	}
+	return ""
+}
+
+type PsbtDecodedInput struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Mweb  bool                   `protobuf:"varint,1,opt,name=mweb,proto3" json:"mweb,omitempty"`
+	// The outpoint (txid:index) of a non-MWEB input.
+	Prevout  string `protobuf:"bytes,2,opt,name=prevout,proto3" json:"prevout,omitempty"`
+	Sequence uint32 `protobuf:"varint,3,opt,name=sequence,proto3" json:"sequence,omitempty"`
+	// The utxo being spent. For MWEB inputs the address is empty.
+	Value                 int64  `protobuf:"varint,4,opt,name=value,proto3" json:"value,omitempty"`
+	PkScript              string `protobuf:"bytes,5,opt,name=pk_script,json=pkScript,proto3" json:"pk_script,omitempty"`
+	Address               string `protobuf:"bytes,6,opt,name=address,proto3" json:"address,omitempty"`
+	PartialSigs           uint32 `protobuf:"varint,7,opt,name=partial_sigs,json=partialSigs,proto3" json:"partial_sigs,omitempty"`
+	Finalized             bool   `protobuf:"varint,8,opt,name=finalized,proto3" json:"finalized,omitempty"`
+	MwebOutputId          string `protobuf:"bytes,9,opt,name=mweb_output_id,json=mwebOutputId,proto3" json:"mweb_output_id,omitempty"`
+	MwebAddressIndex      uint32 `protobuf:"varint,10,opt,name=mweb_address_index,json=mwebAddressIndex,proto3" json:"mweb_address_index,omitempty"`
+	HasMwebSharedSecret   bool   `protobuf:"varint,11,opt,name=has_mweb_shared_secret,json=hasMwebSharedSecret,proto3" json:"has_mweb_shared_secret,omitempty"`
+	MwebKeyExchangePubkey string `protobuf:"bytes,12,opt,name=mweb_key_exchange_pubkey,json=mwebKeyExchangePubkey,proto3" json:"mweb_key_exchange_pubkey,omitempty"`
+	MwebCommit            string `protobuf:"bytes,13,opt,name=mweb_commit,json=mwebCommit,proto3" json:"mweb_commit,omitempty"`
+	MwebOutputPubkey      string `protobuf:"bytes,14,opt,name=mweb_output_pubkey,json=mwebOutputPubkey,proto3" json:"mweb_output_pubkey,omitempty"`
+	MwebInputPubkey       string `protobuf:"bytes,15,opt,name=mweb_input_pubkey,json=mwebInputPubkey,proto3" json:"mweb_input_pubkey,omitempty"`
+	MwebSigned            bool   `protobuf:"varint,16,opt,name=mweb_signed,json=mwebSigned,proto3" json:"mweb_signed,omitempty"`
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
+}
+
+func (x *PsbtDecodedInput) Reset() {
+	*x = PsbtDecodedInput{}
+	mi := &file_mwebd_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PsbtDecodedInput) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PsbtDecodedInput) ProtoMessage() {}
+
+func (x *PsbtDecodedInput) ProtoReflect() protoreflect.Message {
+	mi := &file_mwebd_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PsbtDecodedInput.ProtoReflect.Descriptor instead.
+func (*PsbtDecodedInput) Descriptor() ([]byte, []int) {
+	return file_mwebd_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *PsbtDecodedInput) GetMweb() bool {
+	if x != nil {
+		return x.Mweb
+	}
+	return false
+}
+
+func (x *PsbtDecodedInput) GetPrevout() string {
+	if x != nil {
+		return x.Prevout
+	}
+	return ""
+}
+
+func (x *PsbtDecodedInput) GetSequence() uint32 {
+	if x != nil {
+		return x.Sequence
+	}
+	return 0
+}
+
+func (x *PsbtDecodedInput) GetValue() int64 {
+	if x != nil {
+		return x.Value
+	}
+	return 0
+}
+
+func (x *PsbtDecodedInput) GetPkScript() string {
+	if x != nil {
+		return x.PkScript
+	}
+	return ""
+}
+
+func (x *PsbtDecodedInput) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *PsbtDecodedInput) GetPartialSigs() uint32 {
+	if x != nil {
+		return x.PartialSigs
+	}
+	return 0
+}
+
+func (x *PsbtDecodedInput) GetFinalized() bool {
+	if x != nil {
+		return x.Finalized
+	}
+	return false
+}
+
+func (x *PsbtDecodedInput) GetMwebOutputId() string {
+	if x != nil {
+		return x.MwebOutputId
+	}
+	return ""
+}
+
+func (x *PsbtDecodedInput) GetMwebAddressIndex() uint32 {
+	if x != nil {
+		return x.MwebAddressIndex
+	}
+	return 0
+}
+
+func (x *PsbtDecodedInput) GetHasMwebSharedSecret() bool {
+	if x != nil {
+		return x.HasMwebSharedSecret
+	}
+	return false
+}
+
+func (x *PsbtDecodedInput) GetMwebKeyExchangePubkey() string {
+	if x != nil {
+		return x.MwebKeyExchangePubkey
+	}
+	return ""
+}
+
+func (x *PsbtDecodedInput) GetMwebCommit() string {
+	if x != nil {
+		return x.MwebCommit
+	}
+	return ""
+}
+
+func (x *PsbtDecodedInput) GetMwebOutputPubkey() string {
+	if x != nil {
+		return x.MwebOutputPubkey
+	}
+	return ""
+}
+
+func (x *PsbtDecodedInput) GetMwebInputPubkey() string {
+	if x != nil {
+		return x.MwebInputPubkey
+	}
+	return ""
+}
+
+func (x *PsbtDecodedInput) GetMwebSigned() bool {
+	if x != nil {
+		return x.MwebSigned
+	}
+	return false
+}
+
+type PsbtDecodedOutput struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Mweb     bool                   `protobuf:"varint,1,opt,name=mweb,proto3" json:"mweb,omitempty"`
+	Amount   int64                  `protobuf:"varint,2,opt,name=amount,proto3" json:"amount,omitempty"`
+	PkScript string                 `protobuf:"bytes,3,opt,name=pk_script,json=pkScript,proto3" json:"pk_script,omitempty"`
+	// The address of the recipient. This is only known for MWEB
+	// outputs until they are signed.
+	Address          string `protobuf:"bytes,4,opt,name=address,proto3" json:"address,omitempty"`
+	MwebOutputCommit string `protobuf:"bytes,5,opt,name=mweb_output_commit,json=mwebOutputCommit,proto3" json:"mweb_output_commit,omitempty"`
+	MwebSenderPubkey string `protobuf:"bytes,6,opt,name=mweb_sender_pubkey,json=mwebSenderPubkey,proto3" json:"mweb_sender_pubkey,omitempty"`
+	MwebOutputPubkey string `protobuf:"bytes,7,opt,name=mweb_output_pubkey,json=mwebOutputPubkey,proto3" json:"mweb_output_pubkey,omitempty"`
+	MwebSigned       bool   `protobuf:"varint,8,opt,name=mweb_signed,json=mwebSigned,proto3" json:"mweb_signed,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *PsbtDecodedOutput) Reset() {
+	*x = PsbtDecodedOutput{}
+	mi := &file_mwebd_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PsbtDecodedOutput) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PsbtDecodedOutput) ProtoMessage() {}
+
+func (x *PsbtDecodedOutput) ProtoReflect() protoreflect.Message {
+	mi := &file_mwebd_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PsbtDecodedOutput.ProtoReflect.Descriptor instead.
+func (*PsbtDecodedOutput) Descriptor() ([]byte, []int) {
+	return file_mwebd_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *PsbtDecodedOutput) GetMweb() bool {
+	if x != nil {
+		return x.Mweb
+	}
+	return false
+}
+
+func (x *PsbtDecodedOutput) GetAmount() int64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *PsbtDecodedOutput) GetPkScript() string {
+	if x != nil {
+		return x.PkScript
+	}
+	return ""
+}
+
+func (x *PsbtDecodedOutput) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *PsbtDecodedOutput) GetMwebOutputCommit() string {
+	if x != nil {
+		return x.MwebOutputCommit
+	}
+	return ""
+}
+
+func (x *PsbtDecodedOutput) GetMwebSenderPubkey() string {
+	if x != nil {
+		return x.MwebSenderPubkey
+	}
+	return ""
+}
+
+func (x *PsbtDecodedOutput) GetMwebOutputPubkey() string {
+	if x != nil {
+		return x.MwebOutputPubkey
+	}
+	return ""
+}
+
+func (x *PsbtDecodedOutput) GetMwebSigned() bool {
+	if x != nil {
+		return x.MwebSigned
+	}
+	return false
+}
+
+type PsbtDecodedKernel struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Features      uint32                 `protobuf:"varint,1,opt,name=features,proto3" json:"features,omitempty"`
+	Fee           int64                  `protobuf:"varint,2,opt,name=fee,proto3" json:"fee,omitempty"`
+	PeginAmount   int64                  `protobuf:"varint,3,opt,name=pegin_amount,json=peginAmount,proto3" json:"pegin_amount,omitempty"`
+	Pegout        []*PsbtRecipient       `protobuf:"bytes,4,rep,name=pegout,proto3" json:"pegout,omitempty"`
+	LockHeight    int32                  `protobuf:"varint,5,opt,name=lock_height,json=lockHeight,proto3" json:"lock_height,omitempty"`
+	Excess        string                 `protobuf:"bytes,6,opt,name=excess,proto3" json:"excess,omitempty"`
+	StealthExcess string                 `protobuf:"bytes,7,opt,name=stealth_excess,json=stealthExcess,proto3" json:"stealth_excess,omitempty"`
+	Signed        bool                   `protobuf:"varint,8,opt,name=signed,proto3" json:"signed,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PsbtDecodedKernel) Reset() {
+	*x = PsbtDecodedKernel{}
+	mi := &file_mwebd_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PsbtDecodedKernel) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PsbtDecodedKernel) ProtoMessage() {}
+
+func (x *PsbtDecodedKernel) ProtoReflect() protoreflect.Message {
+	mi := &file_mwebd_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PsbtDecodedKernel.ProtoReflect.Descriptor instead.
+func (*PsbtDecodedKernel) Descriptor() ([]byte, []int) {
+	return file_mwebd_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *PsbtDecodedKernel) GetFeatures() uint32 {
+	if x != nil {
+		return x.Features
+	}
+	return 0
+}
+
+func (x *PsbtDecodedKernel) GetFee() int64 {
+	if x != nil {
+		return x.Fee
+	}
+	return 0
+}
+
+func (x *PsbtDecodedKernel) GetPeginAmount() int64 {
+	if x != nil {
+		return x.PeginAmount
+	}
+	return 0
+}
+
+func (x *PsbtDecodedKernel) GetPegout() []*PsbtRecipient {
+	if x != nil {
+		return x.Pegout
+	}
+	return nil
+}
+
+func (x *PsbtDecodedKernel) GetLockHeight() int32 {
+	if x != nil {
+		return x.LockHeight
+	}
+	return 0
+}
+
+func (x *PsbtDecodedKernel) GetExcess() string {
+	if x != nil {
+		return x.Excess
+	}
+	return ""
+}
+
+func (x *PsbtDecodedKernel) GetStealthExcess() string {
+	if x != nil {
+		return x.StealthExcess
+	}
+	return ""
+}
+
+func (x *PsbtDecodedKernel) GetSigned() bool {
+	if x != nil {
+		return x.Signed
+	}
+	return false
+}
+
+type PsbtSignRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The PSBT in base64 encoding.
+	PsbtB64 string `protobuf:"bytes,1,opt,name=psbt_b64,json=psbtB64,proto3" json:"psbt_b64,omitempty"`
+	// The scan secret or view key represents the account that
+	// the utxos being spent belong to.
+	ScanSecret []byte `protobuf:"bytes,2,opt,name=scan_secret,json=scanSecret,proto3" json:"scan_secret,omitempty"`
+	// The spend secret is the private key necessary for spending
+	// the utxos belonging to the account.
+	SpendSecret   []byte `protobuf:"bytes,3,opt,name=spend_secret,json=spendSecret,proto3" json:"spend_secret,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PsbtSignRequest) Reset() {
+	*x = PsbtSignRequest{}
+	mi := &file_mwebd_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PsbtSignRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PsbtSignRequest) ProtoMessage() {}
+
+func (x *PsbtSignRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_mwebd_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PsbtSignRequest.ProtoReflect.Descriptor instead.
+func (*PsbtSignRequest) Descriptor() ([]byte, []int) {
+	return file_mwebd_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *PsbtSignRequest) GetPsbtB64() string {
+	if x != nil {
+		return x.PsbtB64
+	}
+	return ""
+}
+
+func (x *PsbtSignRequest) GetScanSecret() []byte {
+	if x != nil {
+		return x.ScanSecret
+	}
+	return nil
+}
+
+func (x *PsbtSignRequest) GetSpendSecret() []byte {
+	if x != nil {
+		return x.SpendSecret
+	}
+	return nil
+}
+
+type PsbtSignNonMwebRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The PSBT in base64 encoding.
+	PsbtB64 string `protobuf:"bytes,1,opt,name=psbt_b64,json=psbtB64,proto3" json:"psbt_b64,omitempty"`
+	// The private key necessary for spending the input.
+	PrivKey []byte `protobuf:"bytes,2,opt,name=priv_key,json=privKey,proto3" json:"priv_key,omitempty"`
+	// The index of the input to sign.
+	Index uint32 `protobuf:"varint,3,opt,name=index,proto3" json:"index,omitempty"`
+	// An extended private key (master key) used instead of priv_key.
+	// The key is derived using the BIP32 derivation paths of the
+	// input that have a matching master key fingerprint.
+	Xprv          string `protobuf:"bytes,4,opt,name=xprv,proto3" json:"xprv,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PsbtSignNonMwebRequest) Reset() {
+	*x = PsbtSignNonMwebRequest{}
+	mi := &file_mwebd_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PsbtSignNonMwebRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PsbtSignNonMwebRequest) ProtoMessage() {}
+
+func (x *PsbtSignNonMwebRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_mwebd_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PsbtSignNonMwebRequest.ProtoReflect.Descriptor instead.
+func (*PsbtSignNonMwebRequest) Descriptor() ([]byte, []int) {
+	return file_mwebd_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *PsbtSignNonMwebRequest) GetPsbtB64() string {
+	if x != nil {
+		return x.PsbtB64
+	}
+	return ""
+}
+
+func (x *PsbtSignNonMwebRequest) GetPrivKey() []byte {
+	if x != nil {
+		return x.PrivKey
+	}
+	return nil
+}
+
+func (x *PsbtSignNonMwebRequest) GetIndex() uint32 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *PsbtSignNonMwebRequest) GetXprv() string {
+	if x != nil {
+		return x.Xprv
+	}
+	return ""
+}
+
+type PsbtCombineRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The PSBTs in base64 encoding.
+	PsbtB64       []string `protobuf:"bytes,1,rep,name=psbt_b64,json=psbtB64,proto3" json:"psbt_b64,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PsbtCombineRequest) Reset() {
+	*x = PsbtCombineRequest{}
+	mi := &file_mwebd_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PsbtCombineRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PsbtCombineRequest) ProtoMessage() {}
+
+func (x *PsbtCombineRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_mwebd_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PsbtCombineRequest.ProtoReflect.Descriptor instead.
+func (*PsbtCombineRequest) Descriptor() ([]byte, []int) {
+	return file_mwebd_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *PsbtCombineRequest) GetPsbtB64() []string {
+	if x != nil {
+		return x.PsbtB64
+	}
+	return nil
+}
+
+type PsbtAnalyzeRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The PSBT in base64 encoding.
+	PsbtB64       string `protobuf:"bytes,1,opt,name=psbt_b64,json=psbtB64,proto3" json:"psbt_b64,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PsbtAnalyzeRequest) Reset() {
+	*x = PsbtAnalyzeRequest{}
+	mi := &file_mwebd_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PsbtAnalyzeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PsbtAnalyzeRequest) ProtoMessage() {}
+
+func (x *PsbtAnalyzeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_mwebd_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PsbtAnalyzeRequest.ProtoReflect.Descriptor instead.
+func (*PsbtAnalyzeRequest) Descriptor() ([]byte, []int) {
+	return file_mwebd_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *PsbtAnalyzeRequest) GetPsbtB64() string {
+	if x != nil {
+		return x.PsbtB64
+	}
+	return ""
+}
+
+type PsbtAnalyzeResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Input []*PsbtInputAnalysis   `protobuf:"bytes,1,rep,name=input,proto3" json:"input,omitempty"`
+	// Indexes of the MWEB outputs that haven't been signed.
+	UnsignedMwebOutput []uint32 `protobuf:"varint,2,rep,packed,name=unsigned_mweb_output,json=unsignedMwebOutput,proto3" json:"unsigned_mweb_output,omitempty"`
+	// Indexes of the kernels that haven't been signed.
+	UnsignedKernel []uint32 `protobuf:"varint,3,rep,packed,name=unsigned_kernel,json=unsignedKernel,proto3" json:"unsigned_kernel,omitempty"`
+	// The role that should process the PSBT next, one of
+	// "updater", "signer", "finalizer" or "extractor".
+	Next          string `protobuf:"bytes,4,opt,name=next,proto3" json:"next,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PsbtAnalyzeResponse) Reset() {
+	*x = PsbtAnalyzeResponse{}
+	mi := &file_mwebd_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PsbtAnalyzeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PsbtAnalyzeResponse) ProtoMessage() {}
+
+func (x *PsbtAnalyzeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_mwebd_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PsbtAnalyzeResponse.ProtoReflect.Descriptor instead.
+func (*PsbtAnalyzeResponse) Descriptor() ([]byte, []int) {
+	return file_mwebd_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *PsbtAnalyzeResponse) GetInput() []*PsbtInputAnalysis {
+	if x != nil {
+		return x.Input
+	}
+	return nil
+}
+
+func (x *PsbtAnalyzeResponse) GetUnsignedMwebOutput() []uint32 {
+	if x != nil {
+		return x.UnsignedMwebOutput
+	}
+	return nil
+}
+
+func (x *PsbtAnalyzeResponse) GetUnsignedKernel() []uint32 {
+	if x != nil {
+		return x.UnsignedKernel
+	}
+	return nil
+}
+
+func (x *PsbtAnalyzeResponse) GetNext() string {
+	if x != nil {
+		return x.Next
+	}
+	return ""
+}
+
+type PsbtInputAnalysis struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Mweb  bool                   `protobuf:"varint,1,opt,name=mweb,proto3" json:"mweb,omitempty"`
+	// Whether the utxo being spent is known. Non-MWEB inputs need
+	// this to be signed.
+	HasUtxo bool `protobuf:"varint,2,opt,name=has_utxo,json=hasUtxo,proto3" json:"has_utxo,omitempty"`
+	// Whether the input has any signatures. MWEB inputs are
+	// finalized as soon as they're signed.
+	Signed        bool `protobuf:"varint,3,opt,name=signed,proto3" json:"signed,omitempty"`
+	Finalized     bool `protobuf:"varint,4,opt,name=finalized,proto3" json:"finalized,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PsbtInputAnalysis) Reset() {
+	*x = PsbtInputAnalysis{}
+	mi := &file_mwebd_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PsbtInputAnalysis) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PsbtInputAnalysis) ProtoMessage() {}
+
+func (x *PsbtInputAnalysis) ProtoReflect() protoreflect.Message {
+	mi := &file_mwebd_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PsbtInputAnalysis.ProtoReflect.Descriptor instead.
+func (*PsbtInputAnalysis) Descriptor() ([]byte, []int) {
+	return file_mwebd_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *PsbtInputAnalysis) GetMweb() bool {
+	if x != nil {
+		return x.Mweb
+	}
+	return false
+}
+
+func (x *PsbtInputAnalysis) GetHasUtxo() bool {
+	if x != nil {
+		return x.HasUtxo
+	}
+	return false
+}
+
+func (x *PsbtInputAnalysis) GetSigned() bool {
+	if x != nil {
+		return x.Signed
+	}
+	return false
+}
+
+func (x *PsbtInputAnalysis) GetFinalized() bool {
+	if x != nil {
+		return x.Finalized
+	}
+	return false
+}
+
+type PsbtFinalizeRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The PSBT in base64 encoding.
+	PsbtB64       string `protobuf:"bytes,1,opt,name=psbt_b64,json=psbtB64,proto3" json:"psbt_b64,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PsbtFinalizeRequest) Reset() {
+	*x = PsbtFinalizeRequest{}
+	mi := &file_mwebd_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PsbtFinalizeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PsbtFinalizeRequest) ProtoMessage() {}
+
+func (x *PsbtFinalizeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_mwebd_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PsbtFinalizeRequest.ProtoReflect.Descriptor instead.
+func (*PsbtFinalizeRequest) Descriptor() ([]byte, []int) {
+	return file_mwebd_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *PsbtFinalizeRequest) GetPsbtB64() string {
+	if x != nil {
+		return x.PsbtB64
+	}
+	return ""
+}
+
+type PsbtExtractRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The PSBT in base64 encoding.
+	PsbtB64 string `protobuf:"bytes,1,opt,name=psbt_b64,json=psbtB64,proto3" json:"psbt_b64,omitempty"`
+	// Extract the unsigned transaction.
+	Unsigned      bool `protobuf:"varint,2,opt,name=unsigned,proto3" json:"unsigned,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PsbtExtractRequest) Reset() {
+	*x = PsbtExtractRequest{}
+	mi := &file_mwebd_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PsbtExtractRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PsbtExtractRequest) ProtoMessage() {}
+
+func (x *PsbtExtractRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_mwebd_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PsbtExtractRequest.ProtoReflect.Descriptor instead.
+func (*PsbtExtractRequest) Descriptor() ([]byte, []int) {
+	return file_mwebd_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *PsbtExtractRequest) GetPsbtB64() string {
+	if x != nil {
+		return x.PsbtB64
+	}
+	return ""
+}
+
+func (x *PsbtExtractRequest) GetUnsigned() bool {
+	if x != nil {
+		return x.Unsigned
+	}
+	return false
+}
+
+type BroadcastRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The raw bytes of the serialized transaction.
+	RawTx         []byte `protobuf:"bytes,1,opt,name=raw_tx,json=rawTx,proto3" json:"raw_tx,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BroadcastRequest) Reset() {
+	*x = BroadcastRequest{}
+	mi := &file_mwebd_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BroadcastRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BroadcastRequest) ProtoMessage() {}
+
+func (x *BroadcastRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_mwebd_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BroadcastRequest.ProtoReflect.Descriptor instead.
+func (*BroadcastRequest) Descriptor() ([]byte, []int) {
+	return file_mwebd_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *BroadcastRequest) GetRawTx() []byte {
+	if x != nil {
+		return x.RawTx
+	}
+	return nil
+}
+
+type BroadcastResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The transaction ID.
+	Txid          string `protobuf:"bytes,1,opt,name=txid,proto3" json:"txid,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BroadcastResponse) Reset() {
+	*x = BroadcastResponse{}
+	mi := &file_mwebd_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BroadcastResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BroadcastResponse) ProtoMessage() {}
+
+func (x *BroadcastResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_mwebd_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BroadcastResponse.ProtoReflect.Descriptor instead.
+func (*BroadcastResponse) Descriptor() ([]byte, []int) {
+	return file_mwebd_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *BroadcastResponse) GetTxid() string {
+	if x != nil {
+		return x.Txid
+	}
+	return ""
+}
+
+type PegoutStatusRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The hash of the kernel in hex. Either this or txid is required.
+	KernelHash string `protobuf:"bytes,1,opt,name=kernel_hash,json=kernelHash,proto3" json:"kernel_hash,omitempty"`
+	// The transaction ID, in which case all of the transaction's
+	// kernels with peg-outs are reported.
+	Txid          string `protobuf:"bytes,2,opt,name=txid,proto3" json:"txid,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PegoutStatusRequest) Reset() {
+	*x = PegoutStatusRequest{}
+	mi := &file_mwebd_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PegoutStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PegoutStatusRequest) ProtoMessage() {}
+
+func (x *PegoutStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_mwebd_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PegoutStatusRequest.ProtoReflect.Descriptor instead.
+func (*PegoutStatusRequest) Descriptor() ([]byte, []int) {
+	return file_mwebd_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *PegoutStatusRequest) GetKernelHash() string {
+	if x != nil {
+		return x.KernelHash
+	}
+	return ""
+}
+
+func (x *PegoutStatusRequest) GetTxid() string {
+	if x != nil {
+		return x.Txid
+	}
+	return ""
+}
+
+type PegoutStatusResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Kernel        []*KernelPegoutStatus  `protobuf:"bytes,1,rep,name=kernel,proto3" json:"kernel,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PegoutStatusResponse) Reset() {
+	*x = PegoutStatusResponse{}
+	mi := &file_mwebd_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PegoutStatusResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PegoutStatusResponse) ProtoMessage() {}
+
+func (x *PegoutStatusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_mwebd_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PegoutStatusResponse.ProtoReflect.Descriptor instead.
+func (*PegoutStatusResponse) Descriptor() ([]byte, []int) {
+	return file_mwebd_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *PegoutStatusResponse) GetKernel() []*KernelPegoutStatus {
+	if x != nil {
+		return x.Kernel
+	}
+	return nil
+}
+
+type KernelPegoutStatus struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The hash of the kernel in hex.
+	KernelHash string `protobuf:"bytes,1,opt,name=kernel_hash,json=kernelHash,proto3" json:"kernel_hash,omitempty"`
+	// The ID of the transaction containing the kernel.
+	Txid string `protobuf:"bytes,2,opt,name=txid,proto3" json:"txid,omitempty"`
+	// Whether the kernel has been mined.
+	Mined bool `protobuf:"varint,3,opt,name=mined,proto3" json:"mined,omitempty"`
+	// The earliest height of the block whose HogEx can pay out the
+	// peg-outs, taking into account the kernel's lock height.
+	ExpectedHeight int32 `protobuf:"varint,4,opt,name=expected_height,json=expectedHeight,proto3" json:"expected_height,omitempty"`
+	// The height and hash of the block whose HogEx paid out the
+	// peg-outs. Only set when mined.
+	HogexHeight    int32     `protobuf:"varint,5,opt,name=hogex_height,json=hogexHeight,proto3" json:"hogex_height,omitempty"`
+	HogexBlockHash string    `protobuf:"bytes,6,opt,name=hogex_block_hash,json=hogexBlockHash,proto3" json:"hogex_block_hash,omitempty"`
+	Pegout         []*Pegout `protobuf:"bytes,7,rep,name=pegout,proto3" json:"pegout,omitempty"`
+	// The height of the first block in which the peg-out outputs can
+	// be spent, after the chain's peg-out maturity. Only set when
+	// mined.
+	MatureHeight  int32 `protobuf:"varint,8,opt,name=mature_height,json=matureHeight,proto3" json:"mature_height,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *KernelPegoutStatus) Reset() {
+	*x = KernelPegoutStatus{}
+	mi := &file_mwebd_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *KernelPegoutStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KernelPegoutStatus) ProtoMessage() {}
+
+func (x *KernelPegoutStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_mwebd_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KernelPegoutStatus.ProtoReflect.Descriptor instead.
+func (*KernelPegoutStatus) Descriptor() ([]byte, []int) {
+	return file_mwebd_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *KernelPegoutStatus) GetKernelHash() string {
+	if x != nil {
+		return x.KernelHash
+	}
+	return ""
+}
+
+func (x *KernelPegoutStatus) GetTxid() string {
+	if x != nil {
+		return x.Txid
+	}
+	return ""
+}
+
+func (x *KernelPegoutStatus) GetMined() bool {
+	if x != nil {
+		return x.Mined
+	}
+	return false
+}
+
+func (x *KernelPegoutStatus) GetExpectedHeight() int32 {
+	if x != nil {
+		return x.ExpectedHeight
+	}
+	return 0
+}
+
+func (x *KernelPegoutStatus) GetHogexHeight() int32 {
+	if x != nil {
+		return x.HogexHeight
+	}
+	return 0
+}
+
+func (x *KernelPegoutStatus) GetHogexBlockHash() string {
+	if x != nil {
+		return x.HogexBlockHash
+	}
+	return ""
+}
+
+func (x *KernelPegoutStatus) GetPegout() []*Pegout {
+	if x != nil {
+		return x.Pegout
+	}
+	return nil
+}
+
+func (x *KernelPegoutStatus) GetMatureHeight() int32 {
+	if x != nil {
+		return x.MatureHeight
+	}
+	return 0
+}
+
+type Pegout struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Value    uint64                 `protobuf:"varint,1,opt,name=value,proto3" json:"value,omitempty"`
+	PkScript []byte                 `protobuf:"bytes,2,opt,name=pk_script,json=pkScript,proto3" json:"pk_script,omitempty"`
+	// The transparent outpoint (txid:index) created in the HogEx.
+	// Only set when mined.
+	Outpoint      string `protobuf:"bytes,3,opt,name=outpoint,proto3" json:"outpoint,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Pegout) Reset() {
+	*x = Pegout{}
+	mi := &file_mwebd_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Pegout) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Pegout) ProtoMessage() {}
+
+func (x *Pegout) ProtoReflect() protoreflect.Message {
+	mi := &file_mwebd_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Pegout.ProtoReflect.Descriptor instead.
+func (*Pegout) Descriptor() ([]byte, []int) {
+	return file_mwebd_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *Pegout) GetValue() uint64 {
+	if x != nil {
+		return x.Value
+	}
+	return 0
+}
+
+func (x *Pegout) GetPkScript() []byte {
+	if x != nil {
+		return x.PkScript
+	}
+	return nil
+}
+
+func (x *Pegout) GetOutpoint() string {
+	if x != nil {
+		return x.Outpoint
+	}
+	return ""
+}
+
+type PeginStatusRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The hash of the peg-in kernel in hex, as returned in
+	// CreateResponse. If empty, all tracked peg-ins are returned.
+	// Peg-ins are tracked once the transaction is broadcast.
+	KernelHash    string `protobuf:"bytes,1,opt,name=kernel_hash,json=kernelHash,proto3" json:"kernel_hash,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PeginStatusRequest) Reset() {
+	*x = PeginStatusRequest{}
+	mi := &file_mwebd_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PeginStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PeginStatusRequest) ProtoMessage() {}
+
+func (x *PeginStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_mwebd_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PeginStatusRequest.ProtoReflect.Descriptor instead.
+func (*PeginStatusRequest) Descriptor() ([]byte, []int) {
+	return file_mwebd_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *PeginStatusRequest) GetKernelHash() string {
+	if x != nil {
+		return x.KernelHash
+	}
+	return ""
+}
+
+type PeginStatusResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Pegin         []*PeginStatus         `protobuf:"bytes,1,rep,name=pegin,proto3" json:"pegin,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PeginStatusResponse) Reset() {
+	*x = PeginStatusResponse{}
+	mi := &file_mwebd_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PeginStatusResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PeginStatusResponse) ProtoMessage() {}
+
+func (x *PeginStatusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_mwebd_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PeginStatusResponse.ProtoReflect.Descriptor instead.
+func (*PeginStatusResponse) Descriptor() ([]byte, []int) {
+	return file_mwebd_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *PeginStatusResponse) GetPegin() []*PeginStatus {
+	if x != nil {
+		return x.Pegin
+	}
+	return nil
+}
+
+type PeginStatus struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The hash of the peg-in kernel in hex.
+	KernelHash string     `protobuf:"bytes,1,opt,name=kernel_hash,json=kernelHash,proto3" json:"kernel_hash,omitempty"`
+	State      PeginState `protobuf:"varint,2,opt,name=state,proto3,enum=PeginState" json:"state,omitempty"`
+	// The peg-in output, as created by Create.
+	Value    uint64 `protobuf:"varint,3,opt,name=value,proto3" json:"value,omitempty"`
+	PkScript []byte `protobuf:"bytes,4,opt,name=pk_script,json=pkScript,proto3" json:"pk_script,omitempty"`
+	// Output IDs of the MWEB outputs of the peg-in transaction.
+	OutputId []string `protobuf:"bytes,5,rep,name=output_id,json=outputId,proto3" json:"output_id,omitempty"`
+	// The transaction ID and block containing the peg-in output.
+	// Only set once mined.
+	Txid          string `protobuf:"bytes,6,opt,name=txid,proto3" json:"txid,omitempty"`
+	Height        int32  `protobuf:"varint,7,opt,name=height,proto3" json:"height,omitempty"`
+	BlockHash     string `protobuf:"bytes,8,opt,name=block_hash,json=blockHash,proto3" json:"block_hash,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PeginStatus) Reset() {
+	*x = PeginStatus{}
+	mi := &file_mwebd_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PeginStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PeginStatus) ProtoMessage() {}
+
+func (x *PeginStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_mwebd_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PeginStatus.ProtoReflect.Descriptor instead.
+func (*PeginStatus) Descriptor() ([]byte, []int) {
+	return file_mwebd_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *PeginStatus) GetKernelHash() string {
+	if x != nil {
+		return x.KernelHash
+	}
+	return ""
+}
+
+func (x *PeginStatus) GetState() PeginState {
+	if x != nil {
+		return x.State
+	}
+	return PeginState_PEGIN_PENDING
+}
+
+func (x *PeginStatus) GetValue() uint64 {
+	if x != nil {
+		return x.Value
+	}
+	return 0
+}
+
+func (x *PeginStatus) GetPkScript() []byte {
+	if x != nil {
+		return x.PkScript
+	}
+	return nil
+}
+
+func (x *PeginStatus) GetOutputId() []string {
+	if x != nil {
+		return x.OutputId
+	}
+	return nil
+}
+
+func (x *PeginStatus) GetTxid() string {
+	if x != nil {
+		return x.Txid
+	}
+	return ""
+}
+
+func (x *PeginStatus) GetHeight() int32 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+func (x *PeginStatus) GetBlockHash() string {
+	if x != nil {
+		return x.BlockHash
+	}
+	return ""
+}
+
+type CoinswapRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The scan secret or view key represents the account that
+	// the utxo belongs to.
+	ScanSecret []byte `protobuf:"bytes,1,opt,name=scan_secret,json=scanSecret,proto3" json:"scan_secret,omitempty"`
+	// The spend secret is the private key necessary for spending
+	// the utxos belonging to the account.
+	SpendSecret []byte `protobuf:"bytes,2,opt,name=spend_secret,json=spendSecret,proto3" json:"spend_secret,omitempty"`
+	// Output ID of the utxo to request a coinswap for.
+	OutputId string `protobuf:"bytes,3,opt,name=output_id,json=outputId,proto3" json:"output_id,omitempty"`
+	// Address index of the utxo.
+	AddrIndex uint32 `protobuf:"varint,4,opt,name=addr_index,json=addrIndex,proto3" json:"addr_index,omitempty"`
+	// The maximum total fee to pay the nodes, in litoshis. If the
+	// fee for the route through every alive node is higher then the
+	// request is refused. Zero means no maximum.
+	MaxFee uint64 `protobuf:"varint,5,opt,name=max_fee,json=maxFee,proto3" json:"max_fee,omitempty"`
+	// The minimum number of nodes to route the swap through. The
+	// request is refused if fewer nodes are alive, and retries
+	// won't use shorter routes.
+	MinHops       uint32 `protobuf:"varint,6,opt,name=min_hops,json=minHops,proto3" json:"min_hops,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CoinswapRequest) Reset() {
+	*x = CoinswapRequest{}
+	mi := &file_mwebd_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CoinswapRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CoinswapRequest) ProtoMessage() {}
+
+func (x *CoinswapRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_mwebd_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CoinswapRequest.ProtoReflect.Descriptor instead.
+func (*CoinswapRequest) Descriptor() ([]byte, []int) {
+	return file_mwebd_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *CoinswapRequest) GetScanSecret() []byte {
+	if x != nil {
+		return x.ScanSecret
+	}
+	return nil
+}
+
+func (x *CoinswapRequest) GetSpendSecret() []byte {
+	if x != nil {
+		return x.SpendSecret
+	}
+	return nil
+}
+
+func (x *CoinswapRequest) GetOutputId() string {
 	if x != nil {
-		return x.FeeRatePerKb
+		return x.OutputId
+	}
+	return ""
+}
+
+func (x *CoinswapRequest) GetAddrIndex() uint32 {
+	if x != nil {
+		return x.AddrIndex
 	}
 	return 0
 }
 
-func (x *CreateRequest) GetDryRun() bool {
+func (x *CoinswapRequest) GetMaxFee() uint64 {
 	if x != nil {
-		return x.DryRun
+		return x.MaxFee
 	}
-	return false
+	return 0
 }
 
-type CreateResponse struct {
+func (x *CoinswapRequest) GetMinHops() uint32 {
+	if x != nil {
+		return x.MinHops
+	}
+	return 0
+}
+
+type CoinswapResponse struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// The raw bytes of the serialized transaction. It will contain
-	// a single TxOut representing the peg-in required. From this
-	// it is possible to determine the addtional fee that was added
-	// by the MWEB transaction.
-	RawTx []byte `protobuf:"bytes,1,opt,name=raw_tx,json=rawTx,proto3" json:"raw_tx,omitempty"`
-	// The output IDs of any utxos created by the transaction,
-	// in the same order as in the template.
-	OutputId      []string `protobuf:"bytes,2,rep,name=output_id,json=outputId,proto3" json:"output_id,omitempty"`
+	// Output ID of the utxo created by the transaction. This changes
+	// if the swap is resubmitted, see CoinswapStatus. Empty if the
+	// submission failed and the swap is waiting to be resubmitted.
+	OutputId      string `protobuf:"bytes,1,opt,name=output_id,json=outputId,proto3" json:"output_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CreateResponse) Reset() {
-	*x = CreateResponse{}
-	mi := &file_mwebd_proto_msgTypes[10]
+func (x *CoinswapResponse) Reset() {
+	*x = CoinswapResponse{}
+	mi := &file_mwebd_proto_msgTypes[52]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CreateResponse) String() string {
+func (x *CoinswapResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CoinswapResponse) ProtoMessage() {}
+
+func (x *CoinswapResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_mwebd_proto_msgTypes[52]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CoinswapResponse.ProtoReflect.Descriptor instead.
+func (*CoinswapResponse) Descriptor() ([]byte, []int) {
+	return file_mwebd_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *CoinswapResponse) GetOutputId() string {
+	if x != nil {
+		return x.OutputId
+	}
+	return ""
+}
+
+type CoinswapBatchRequest struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	ScanSecret  []byte                 `protobuf:"bytes,1,opt,name=scan_secret,json=scanSecret,proto3" json:"scan_secret,omitempty"`
+	SpendSecret []byte                 `protobuf:"bytes,2,opt,name=spend_secret,json=spendSecret,proto3" json:"spend_secret,omitempty"`
+	Inputs      []*CoinswapBatchInput  `protobuf:"bytes,3,rep,name=inputs,proto3" json:"inputs,omitempty"`
+	// The maximum fee and minimum hops for each swap, as in
+	// CoinswapRequest.
+	MaxFee        uint64 `protobuf:"varint,4,opt,name=max_fee,json=maxFee,proto3" json:"max_fee,omitempty"`
+	MinHops       uint32 `protobuf:"varint,5,opt,name=min_hops,json=minHops,proto3" json:"min_hops,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CoinswapBatchRequest) Reset() {
+	*x = CoinswapBatchRequest{}
+	mi := &file_mwebd_proto_msgTypes[53]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CoinswapBatchRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateResponse) ProtoMessage() {}
+func (*CoinswapBatchRequest) ProtoMessage() {}
 
-func (x *CreateResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_mwebd_proto_msgTypes[10]
+func (x *CoinswapBatchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_mwebd_proto_msgTypes[53]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -658,50 +4053,69 @@ func (x *CreateResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateResponse.ProtoReflect.Descriptor instead.
-func (*CreateResponse) Descriptor() ([]byte, []int) {
-	return file_mwebd_proto_rawDescGZIP(), []int{10}
+// Deprecated: Use CoinswapBatchRequest.ProtoReflect.Descriptor instead.
+func (*CoinswapBatchRequest) Descriptor() ([]byte, []int) {
+	return file_mwebd_proto_rawDescGZIP(), []int{53}
 }
 
-func (x *CreateResponse) GetRawTx() []byte {
+func (x *CoinswapBatchRequest) GetScanSecret() []byte {
 	if x != nil {
-		return x.RawTx
+		return x.ScanSecret
 	}
 	return nil
 }
 
-func (x *CreateResponse) GetOutputId() []string {
+func (x *CoinswapBatchRequest) GetSpendSecret() []byte {
 	if x != nil {
-		return x.OutputId
+		return x.SpendSecret
 	}
 	return nil
 }
 
-type PsbtCreateRequest struct {
-	state protoimpl.MessageState `protogen:"open.v1"`
-	// The raw bytes of the serialized transaction.
-	RawTx []byte `protobuf:"bytes,1,opt,name=raw_tx,json=rawTx,proto3" json:"raw_tx,omitempty"`
-	// Witness utxos for each input.
-	WitnessUtxo   []*TxOut `protobuf:"bytes,2,rep,name=witness_utxo,json=witnessUtxo,proto3" json:"witness_utxo,omitempty"`
+func (x *CoinswapBatchRequest) GetInputs() []*CoinswapBatchInput {
+	if x != nil {
+		return x.Inputs
+	}
+	return nil
+}
+
+func (x *CoinswapBatchRequest) GetMaxFee() uint64 {
+	if x != nil {
+		return x.MaxFee
+	}
+	return 0
+}
+
+func (x *CoinswapBatchRequest) GetMinHops() uint32 {
+	if x != nil {
+		return x.MinHops
+	}
+	return 0
+}
+
+type CoinswapBatchInput struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OutputId      string                 `protobuf:"bytes,1,opt,name=output_id,json=outputId,proto3" json:"output_id,omitempty"`
+	AddrIndex     uint32                 `protobuf:"varint,2,opt,name=addr_index,json=addrIndex,proto3" json:"addr_index,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *PsbtCreateRequest) Reset() {
-	*x = PsbtCreateRequest{}
-	mi := &file_mwebd_proto_msgTypes[11]
+func (x *CoinswapBatchInput) Reset() {
+	*x = CoinswapBatchInput{}
+	mi := &file_mwebd_proto_msgTypes[54]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *PsbtCreateRequest) String() string {
+func (x *CoinswapBatchInput) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PsbtCreateRequest) ProtoMessage() {}
+func (*CoinswapBatchInput) ProtoMessage() {}
 
-func (x *PsbtCreateRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_mwebd_proto_msgTypes[11]
+func (x *CoinswapBatchInput) ProtoReflect() protoreflect.Message {
+	mi := &file_mwebd_proto_msgTypes[54]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -712,48 +4126,48 @@ func (x *PsbtCreateRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PsbtCreateRequest.ProtoReflect.Descriptor instead.
-func (*PsbtCreateRequest) Descriptor() ([]byte, []int) {
-	return file_mwebd_proto_rawDescGZIP(), []int{11}
+// Deprecated: Use CoinswapBatchInput.ProtoReflect.Descriptor instead.
+func (*CoinswapBatchInput) Descriptor() ([]byte, []int) {
+	return file_mwebd_proto_rawDescGZIP(), []int{54}
 }
 
-func (x *PsbtCreateRequest) GetRawTx() []byte {
+func (x *CoinswapBatchInput) GetOutputId() string {
 	if x != nil {
-		return x.RawTx
+		return x.OutputId
 	}
-	return nil
+	return ""
 }
 
-func (x *PsbtCreateRequest) GetWitnessUtxo() []*TxOut {
+func (x *CoinswapBatchInput) GetAddrIndex() uint32 {
 	if x != nil {
-		return x.WitnessUtxo
+		return x.AddrIndex
 	}
-	return nil
+	return 0
 }
 
-type TxOut struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Value         int64                  `protobuf:"varint,1,opt,name=value,proto3" json:"value,omitempty"`
-	PkScript      []byte                 `protobuf:"bytes,2,opt,name=pk_script,json=pkScript,proto3" json:"pk_script,omitempty"`
+type CoinswapBatchResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The results in the same order as the inputs.
+	Results       []*CoinswapBatchResult `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *TxOut) Reset() {
-	*x = TxOut{}
-	mi := &file_mwebd_proto_msgTypes[12]
+func (x *CoinswapBatchResponse) Reset() {
+	*x = CoinswapBatchResponse{}
+	mi := &file_mwebd_proto_msgTypes[55]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *TxOut) String() string {
+func (x *CoinswapBatchResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*TxOut) ProtoMessage() {}
+func (*CoinswapBatchResponse) ProtoMessage() {}
 
-func (x *TxOut) ProtoReflect() protoreflect.Message {
-	mi := &file_mwebd_proto_msgTypes[12]
+func (x *CoinswapBatchResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_mwebd_proto_msgTypes[55]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -764,48 +4178,47 @@ func (x *TxOut) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use TxOut.ProtoReflect.Descriptor instead.
-func (*TxOut) Descriptor() ([]byte, []int) {
-	return file_mwebd_proto_rawDescGZIP(), []int{12}
-}
-
-func (x *TxOut) GetValue() int64 {
-	if x != nil {
-		return x.Value
-	}
-	return 0
+// Deprecated: Use CoinswapBatchResponse.ProtoReflect.Descriptor instead.
+func (*CoinswapBatchResponse) Descriptor() ([]byte, []int) {
+	return file_mwebd_proto_rawDescGZIP(), []int{55}
 }
 
-func (x *TxOut) GetPkScript() []byte {
+func (x *CoinswapBatchResponse) GetResults() []*CoinswapBatchResult {
 	if x != nil {
-		return x.PkScript
+		return x.Results
 	}
 	return nil
 }
 
-type PsbtResponse struct {
+type CoinswapBatchResult struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// The PSBT in base64 encoding.
-	PsbtB64       string `protobuf:"bytes,1,opt,name=psbt_b64,json=psbtB64,proto3" json:"psbt_b64,omitempty"`
+	// Output ID of the utxo that the swap will create, if it was
+	// scheduled. This changes if the swap is submitted through
+	// another route, see CoinswapStatus.
+	OutputId string `protobuf:"bytes,1,opt,name=output_id,json=outputId,proto3" json:"output_id,omitempty"`
+	// Why the swap couldn't be scheduled.
+	Error string `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	// Unix timestamp of when the swap will be submitted.
+	ScheduledTime int64 `protobuf:"varint,3,opt,name=scheduled_time,json=scheduledTime,proto3" json:"scheduled_time,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *PsbtResponse) Reset() {
-	*x = PsbtResponse{}
-	mi := &file_mwebd_proto_msgTypes[13]
+func (x *CoinswapBatchResult) Reset() {
+	*x = CoinswapBatchResult{}
+	mi := &file_mwebd_proto_msgTypes[56]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *PsbtResponse) String() string {
+func (x *CoinswapBatchResult) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PsbtResponse) ProtoMessage() {}
+func (*CoinswapBatchResult) ProtoMessage() {}
 
-func (x *PsbtResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_mwebd_proto_msgTypes[13]
+func (x *CoinswapBatchResult) ProtoReflect() protoreflect.Message {
+	mi := &file_mwebd_proto_msgTypes[56]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -816,50 +4229,68 @@ func (x *PsbtResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PsbtResponse.ProtoReflect.Descriptor instead.
-func (*PsbtResponse) Descriptor() ([]byte, []int) {
-	return file_mwebd_proto_rawDescGZIP(), []int{13}
+// Deprecated: Use CoinswapBatchResult.ProtoReflect.Descriptor instead.
+func (*CoinswapBatchResult) Descriptor() ([]byte, []int) {
+	return file_mwebd_proto_rawDescGZIP(), []int{56}
 }
 
-func (x *PsbtResponse) GetPsbtB64() string {
+func (x *CoinswapBatchResult) GetOutputId() string {
 	if x != nil {
-		return x.PsbtB64
+		return x.OutputId
 	}
 	return ""
 }
 
-type PsbtAddInputRequest struct {
-	state protoimpl.MessageState `protogen:"open.v1"`
-	// The PSBT in base64 encoding.
-	PsbtB64 string `protobuf:"bytes,1,opt,name=psbt_b64,json=psbtB64,proto3" json:"psbt_b64,omitempty"`
-	// The scan secret or view key represents the account that
-	// the utxos being spent belong to.
-	ScanSecret []byte `protobuf:"bytes,2,opt,name=scan_secret,json=scanSecret,proto3" json:"scan_secret,omitempty"`
-	// The output ID of the utxo.
-	OutputId string `protobuf:"bytes,3,opt,name=output_id,json=outputId,proto3" json:"output_id,omitempty"`
-	// The address index of the utxo.
-	AddressIndex uint32 `protobuf:"varint,4,opt,name=address_index,json=addressIndex,proto3" json:"address_index,omitempty"`
-	// The fee rate per KB in litoshis.
-	FeeRatePerKb  uint64 `protobuf:"varint,5,opt,name=fee_rate_per_kb,json=feeRatePerKb,proto3" json:"fee_rate_per_kb,omitempty"`
+func (x *CoinswapBatchResult) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *CoinswapBatchResult) GetScheduledTime() int64 {
+	if x != nil {
+		return x.ScheduledTime
+	}
+	return 0
+}
+
+type CoinswapAutoMixRequest struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	ScanSecret  []byte                 `protobuf:"bytes,1,opt,name=scan_secret,json=scanSecret,proto3" json:"scan_secret,omitempty"`
+	SpendSecret []byte                 `protobuf:"bytes,2,opt,name=spend_secret,json=spendSecret,proto3" json:"spend_secret,omitempty"`
+	// Only utxos of at least this value are swapped.
+	MinValue uint64 `protobuf:"varint,3,opt,name=min_value,json=minValue,proto3" json:"min_value,omitempty"`
+	// The number of daily swap rounds that the swaps are spread
+	// over, which is also how long the registration lasts. Defaults
+	// to 7, and may be at most 30.
+	Rounds uint32 `protobuf:"varint,4,opt,name=rounds,proto3" json:"rounds,omitempty"`
+	// The maximum fee and minimum hops for each swap, as in
+	// CoinswapRequest.
+	MaxFee  uint64 `protobuf:"varint,5,opt,name=max_fee,json=maxFee,proto3" json:"max_fee,omitempty"`
+	MinHops uint32 `protobuf:"varint,6,opt,name=min_hops,json=minHops,proto3" json:"min_hops,omitempty"`
+	// Remove the account's registration. Swaps already submitted
+	// are still tracked.
+	Disable       bool `protobuf:"varint,7,opt,name=disable,proto3" json:"disable,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *PsbtAddInputRequest) Reset() {
-	*x = PsbtAddInputRequest{}
-	mi := &file_mwebd_proto_msgTypes[14]
+func (x *CoinswapAutoMixRequest) Reset() {
+	*x = CoinswapAutoMixRequest{}
+	mi := &file_mwebd_proto_msgTypes[57]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *PsbtAddInputRequest) String() string {
+func (x *CoinswapAutoMixRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PsbtAddInputRequest) ProtoMessage() {}
+func (*CoinswapAutoMixRequest) ProtoMessage() {}
 
-func (x *PsbtAddInputRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_mwebd_proto_msgTypes[14]
+func (x *CoinswapAutoMixRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_mwebd_proto_msgTypes[57]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -870,72 +4301,86 @@ func (x *PsbtAddInputRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PsbtAddInputRequest.ProtoReflect.Descriptor instead.
-func (*PsbtAddInputRequest) Descriptor() ([]byte, []int) {
-	return file_mwebd_proto_rawDescGZIP(), []int{14}
+// Deprecated: Use CoinswapAutoMixRequest.ProtoReflect.Descriptor instead.
+func (*CoinswapAutoMixRequest) Descriptor() ([]byte, []int) {
+	return file_mwebd_proto_rawDescGZIP(), []int{57}
 }
 
-func (x *PsbtAddInputRequest) GetPsbtB64() string {
+func (x *CoinswapAutoMixRequest) GetScanSecret() []byte {
 	if x != nil {
-		return x.PsbtB64
+		return x.ScanSecret
 	}
-	return ""
+	return nil
 }
 
-func (x *PsbtAddInputRequest) GetScanSecret() []byte {
+func (x *CoinswapAutoMixRequest) GetSpendSecret() []byte {
 	if x != nil {
-		return x.ScanSecret
+		return x.SpendSecret
 	}
 	return nil
 }
 
-func (x *PsbtAddInputRequest) GetOutputId() string {
+func (x *CoinswapAutoMixRequest) GetMinValue() uint64 {
 	if x != nil {
-		return x.OutputId
+		return x.MinValue
 	}
-	return ""
+	return 0
 }
 
-func (x *PsbtAddInputRequest) GetAddressIndex() uint32 {
+func (x *CoinswapAutoMixRequest) GetRounds() uint32 {
 	if x != nil {
-		return x.AddressIndex
+		return x.Rounds
 	}
 	return 0
 }
 
-func (x *PsbtAddInputRequest) GetFeeRatePerKb() uint64 {
+func (x *CoinswapAutoMixRequest) GetMaxFee() uint64 {
 	if x != nil {
-		return x.FeeRatePerKb
+		return x.MaxFee
 	}
 	return 0
 }
 
-type PsbtAddRecipientRequest struct {
+func (x *CoinswapAutoMixRequest) GetMinHops() uint32 {
+	if x != nil {
+		return x.MinHops
+	}
+	return 0
+}
+
+func (x *CoinswapAutoMixRequest) GetDisable() bool {
+	if x != nil {
+		return x.Disable
+	}
+	return false
+}
+
+type CoinswapAutoMixResponse struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// The PSBT in base64 encoding.
-	PsbtB64   string         `protobuf:"bytes,1,opt,name=psbt_b64,json=psbtB64,proto3" json:"psbt_b64,omitempty"`
-	Recipient *PsbtRecipient `protobuf:"bytes,2,opt,name=recipient,proto3" json:"recipient,omitempty"`
-	// The fee rate per KB in litoshis.
-	FeeRatePerKb  uint64 `protobuf:"varint,3,opt,name=fee_rate_per_kb,json=feeRatePerKb,proto3" json:"fee_rate_per_kb,omitempty"`
+	// The utxos waiting to be swapped.
+	Scheduled []*CoinswapScheduled `protobuf:"bytes,1,rep,name=scheduled,proto3" json:"scheduled,omitempty"`
+	// Unix timestamp of when the registration expires, after which
+	// new utxos aren't scheduled.
+	ExpiresTime   int64 `protobuf:"varint,2,opt,name=expires_time,json=expiresTime,proto3" json:"expires_time,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *PsbtAddRecipientRequest) Reset() {
-	*x = PsbtAddRecipientRequest{}
-	mi := &file_mwebd_proto_msgTypes[15]
+func (x *CoinswapAutoMixResponse) Reset() {
+	*x = CoinswapAutoMixResponse{}
+	mi := &file_mwebd_proto_msgTypes[58]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *PsbtAddRecipientRequest) String() string {
+func (x *CoinswapAutoMixResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PsbtAddRecipientRequest) ProtoMessage() {}
+func (*CoinswapAutoMixResponse) ProtoMessage() {}
 
-func (x *PsbtAddRecipientRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_mwebd_proto_msgTypes[15]
+func (x *CoinswapAutoMixResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_mwebd_proto_msgTypes[58]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -946,55 +4391,53 @@ func (x *PsbtAddRecipientRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PsbtAddRecipientRequest.ProtoReflect.Descriptor instead.
-func (*PsbtAddRecipientRequest) Descriptor() ([]byte, []int) {
-	return file_mwebd_proto_rawDescGZIP(), []int{15}
-}
-
-func (x *PsbtAddRecipientRequest) GetPsbtB64() string {
-	if x != nil {
-		return x.PsbtB64
-	}
-	return ""
+// Deprecated: Use CoinswapAutoMixResponse.ProtoReflect.Descriptor instead.
+func (*CoinswapAutoMixResponse) Descriptor() ([]byte, []int) {
+	return file_mwebd_proto_rawDescGZIP(), []int{58}
 }
 
-func (x *PsbtAddRecipientRequest) GetRecipient() *PsbtRecipient {
+func (x *CoinswapAutoMixResponse) GetScheduled() []*CoinswapScheduled {
 	if x != nil {
-		return x.Recipient
+		return x.Scheduled
 	}
 	return nil
 }
 
-func (x *PsbtAddRecipientRequest) GetFeeRatePerKb() uint64 {
+func (x *CoinswapAutoMixResponse) GetExpiresTime() int64 {
 	if x != nil {
-		return x.FeeRatePerKb
+		return x.ExpiresTime
 	}
 	return 0
 }
 
-type PsbtGetRecipientsRequest struct {
-	state protoimpl.MessageState `protogen:"open.v1"`
-	// The PSBT in base64 encoding.
-	PsbtB64       string `protobuf:"bytes,1,opt,name=psbt_b64,json=psbtB64,proto3" json:"psbt_b64,omitempty"`
+type CoinswapScheduled struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	OutputId string                 `protobuf:"bytes,1,opt,name=output_id,json=outputId,proto3" json:"output_id,omitempty"`
+	Value    uint64                 `protobuf:"varint,2,opt,name=value,proto3" json:"value,omitempty"`
+	// Unix timestamp of when the swap will be submitted.
+	ScheduledTime int64 `protobuf:"varint,3,opt,name=scheduled_time,json=scheduledTime,proto3" json:"scheduled_time,omitempty"`
+	// Why the last submission failed. It's retried in the next
+	// round.
+	Error         string `protobuf:"bytes,4,opt,name=error,proto3" json:"error,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *PsbtGetRecipientsRequest) Reset() {
-	*x = PsbtGetRecipientsRequest{}
-	mi := &file_mwebd_proto_msgTypes[16]
+func (x *CoinswapScheduled) Reset() {
+	*x = CoinswapScheduled{}
+	mi := &file_mwebd_proto_msgTypes[59]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *PsbtGetRecipientsRequest) String() string {
+func (x *CoinswapScheduled) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PsbtGetRecipientsRequest) ProtoMessage() {}
+func (*CoinswapScheduled) ProtoMessage() {}
 
-func (x *PsbtGetRecipientsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_mwebd_proto_msgTypes[16]
+func (x *CoinswapScheduled) ProtoReflect() protoreflect.Message {
+	mi := &file_mwebd_proto_msgTypes[59]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1005,42 +4448,62 @@ func (x *PsbtGetRecipientsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PsbtGetRecipientsRequest.ProtoReflect.Descriptor instead.
-func (*PsbtGetRecipientsRequest) Descriptor() ([]byte, []int) {
-	return file_mwebd_proto_rawDescGZIP(), []int{16}
+// Deprecated: Use CoinswapScheduled.ProtoReflect.Descriptor instead.
+func (*CoinswapScheduled) Descriptor() ([]byte, []int) {
+	return file_mwebd_proto_rawDescGZIP(), []int{59}
 }
 
-func (x *PsbtGetRecipientsRequest) GetPsbtB64() string {
+func (x *CoinswapScheduled) GetOutputId() string {
 	if x != nil {
-		return x.PsbtB64
+		return x.OutputId
 	}
 	return ""
 }
 
-type PsbtGetRecipientsResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Recipient     []*PsbtRecipient       `protobuf:"bytes,1,rep,name=recipient,proto3" json:"recipient,omitempty"`
-	InputAddress  []string               `protobuf:"bytes,2,rep,name=input_address,json=inputAddress,proto3" json:"input_address,omitempty"`
-	Fee           int64                  `protobuf:"varint,3,opt,name=fee,proto3" json:"fee,omitempty"`
+func (x *CoinswapScheduled) GetValue() uint64 {
+	if x != nil {
+		return x.Value
+	}
+	return 0
+}
+
+func (x *CoinswapScheduled) GetScheduledTime() int64 {
+	if x != nil {
+		return x.ScheduledTime
+	}
+	return 0
+}
+
+func (x *CoinswapScheduled) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+type CoinswapStatusRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Output ID of the utxo that was swapped.
+	OutputId      string `protobuf:"bytes,1,opt,name=output_id,json=outputId,proto3" json:"output_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *PsbtGetRecipientsResponse) Reset() {
-	*x = PsbtGetRecipientsResponse{}
-	mi := &file_mwebd_proto_msgTypes[17]
+func (x *CoinswapStatusRequest) Reset() {
+	*x = CoinswapStatusRequest{}
+	mi := &file_mwebd_proto_msgTypes[60]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *PsbtGetRecipientsResponse) String() string {
+func (x *CoinswapStatusRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PsbtGetRecipientsResponse) ProtoMessage() {}
+func (*CoinswapStatusRequest) ProtoMessage() {}
 
-func (x *PsbtGetRecipientsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_mwebd_proto_msgTypes[17]
+func (x *CoinswapStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_mwebd_proto_msgTypes[60]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1051,55 +4514,39 @@ func (x *PsbtGetRecipientsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PsbtGetRecipientsResponse.ProtoReflect.Descriptor instead.
-func (*PsbtGetRecipientsResponse) Descriptor() ([]byte, []int) {
-	return file_mwebd_proto_rawDescGZIP(), []int{17}
-}
-
-func (x *PsbtGetRecipientsResponse) GetRecipient() []*PsbtRecipient {
-	if x != nil {
-		return x.Recipient
-	}
-	return nil
-}
-
-func (x *PsbtGetRecipientsResponse) GetInputAddress() []string {
-	if x != nil {
-		return x.InputAddress
-	}
-	return nil
+// Deprecated: Use CoinswapStatusRequest.ProtoReflect.Descriptor instead.
+func (*CoinswapStatusRequest) Descriptor() ([]byte, []int) {
+	return file_mwebd_proto_rawDescGZIP(), []int{60}
 }
 
-func (x *PsbtGetRecipientsResponse) GetFee() int64 {
+func (x *CoinswapStatusRequest) GetOutputId() string {
 	if x != nil {
-		return x.Fee
+		return x.OutputId
 	}
-	return 0
+	return ""
 }
 
-type PsbtRecipient struct {
+type CoinswapListRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Address       string                 `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
-	Value         int64                  `protobuf:"varint,2,opt,name=value,proto3" json:"value,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *PsbtRecipient) Reset() {
-	*x = PsbtRecipient{}
-	mi := &file_mwebd_proto_msgTypes[18]
+func (x *CoinswapListRequest) Reset() {
+	*x = CoinswapListRequest{}
+	mi := &file_mwebd_proto_msgTypes[61]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *PsbtRecipient) String() string {
+func (x *CoinswapListRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PsbtRecipient) ProtoMessage() {}
+func (*CoinswapListRequest) ProtoMessage() {}
 
-func (x *PsbtRecipient) ProtoReflect() protoreflect.Message {
-	mi := &file_mwebd_proto_msgTypes[18]
+func (x *CoinswapListRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_mwebd_proto_msgTypes[61]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1110,54 +4557,33 @@ func (x *PsbtRecipient) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PsbtRecipient.ProtoReflect.Descriptor instead.
-func (*PsbtRecipient) Descriptor() ([]byte, []int) {
-	return file_mwebd_proto_rawDescGZIP(), []int{18}
-}
-
-func (x *PsbtRecipient) GetAddress() string {
-	if x != nil {
-		return x.Address
-	}
-	return ""
-}
-
-func (x *PsbtRecipient) GetValue() int64 {
-	if x != nil {
-		return x.Value
-	}
-	return 0
+// Deprecated: Use CoinswapListRequest.ProtoReflect.Descriptor instead.
+func (*CoinswapListRequest) Descriptor() ([]byte, []int) {
+	return file_mwebd_proto_rawDescGZIP(), []int{61}
 }
 
-type PsbtSignRequest struct {
-	state protoimpl.MessageState `protogen:"open.v1"`
-	// The PSBT in base64 encoding.
-	PsbtB64 string `protobuf:"bytes,1,opt,name=psbt_b64,json=psbtB64,proto3" json:"psbt_b64,omitempty"`
-	// The scan secret or view key represents the account that
-	// the utxos being spent belong to.
-	ScanSecret []byte `protobuf:"bytes,2,opt,name=scan_secret,json=scanSecret,proto3" json:"scan_secret,omitempty"`
-	// The spend secret is the private key necessary for spending
-	// the utxos belonging to the account.
-	SpendSecret   []byte `protobuf:"bytes,3,opt,name=spend_secret,json=spendSecret,proto3" json:"spend_secret,omitempty"`
+type CoinswapListResponse struct {
+	state         protoimpl.MessageState    `protogen:"open.v1"`
+	Coinswap      []*CoinswapStatusResponse `protobuf:"bytes,1,rep,name=coinswap,proto3" json:"coinswap,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *PsbtSignRequest) Reset() {
-	*x = PsbtSignRequest{}
-	mi := &file_mwebd_proto_msgTypes[19]
+func (x *CoinswapListResponse) Reset() {
+	*x = CoinswapListResponse{}
+	mi := &file_mwebd_proto_msgTypes[62]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *PsbtSignRequest) String() string {
+func (x *CoinswapListResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PsbtSignRequest) ProtoMessage() {}
+func (*CoinswapListResponse) ProtoMessage() {}
 
-func (x *PsbtSignRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_mwebd_proto_msgTypes[19]
+func (x *CoinswapListResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_mwebd_proto_msgTypes[62]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1168,59 +4594,61 @@ func (x *PsbtSignRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PsbtSignRequest.ProtoReflect.Descriptor instead.
-func (*PsbtSignRequest) Descriptor() ([]byte, []int) {
-	return file_mwebd_proto_rawDescGZIP(), []int{19}
-}
-
-func (x *PsbtSignRequest) GetPsbtB64() string {
-	if x != nil {
-		return x.PsbtB64
-	}
-	return ""
-}
-
-func (x *PsbtSignRequest) GetScanSecret() []byte {
-	if x != nil {
-		return x.ScanSecret
-	}
-	return nil
+// Deprecated: Use CoinswapListResponse.ProtoReflect.Descriptor instead.
+func (*CoinswapListResponse) Descriptor() ([]byte, []int) {
+	return file_mwebd_proto_rawDescGZIP(), []int{62}
 }
 
-func (x *PsbtSignRequest) GetSpendSecret() []byte {
+func (x *CoinswapListResponse) GetCoinswap() []*CoinswapStatusResponse {
 	if x != nil {
-		return x.SpendSecret
+		return x.Coinswap
 	}
 	return nil
 }
 
-type PsbtSignNonMwebRequest struct {
+type CoinswapStatusResponse struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// The PSBT in base64 encoding.
-	PsbtB64 string `protobuf:"bytes,1,opt,name=psbt_b64,json=psbtB64,proto3" json:"psbt_b64,omitempty"`
-	// The private key necessary for spending the input.
-	PrivKey []byte `protobuf:"bytes,2,opt,name=priv_key,json=privKey,proto3" json:"priv_key,omitempty"`
-	// The index of the input to sign.
-	Index         uint32 `protobuf:"varint,3,opt,name=index,proto3" json:"index,omitempty"`
+	// Output ID of the utxo that was swapped.
+	OutputId string        `protobuf:"bytes,1,opt,name=output_id,json=outputId,proto3" json:"output_id,omitempty"`
+	State    CoinswapState `protobuf:"varint,2,opt,name=state,proto3,enum=CoinswapState" json:"state,omitempty"`
+	// Why the swap failed.
+	Error string `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	// Output ID of the utxo created by the swap, for the latest
+	// submission.
+	SwapOutputId string `protobuf:"bytes,4,opt,name=swap_output_id,json=swapOutputId,proto3" json:"swap_output_id,omitempty"`
+	// The URL of the node that the swap was submitted to, and the
+	// number of nodes in the route.
+	EntryNode string `protobuf:"bytes,5,opt,name=entry_node,json=entryNode,proto3" json:"entry_node,omitempty"`
+	Hops      uint32 `protobuf:"varint,6,opt,name=hops,proto3" json:"hops,omitempty"`
+	// The number of times the swap was submitted.
+	Attempts uint32 `protobuf:"varint,7,opt,name=attempts,proto3" json:"attempts,omitempty"`
+	// Unix timestamps of the latest submission, and of when the
+	// swap is rescheduled through another route if it hasn't
+	// completed.
+	SubmittedTime int64 `protobuf:"varint,8,opt,name=submitted_time,json=submittedTime,proto3" json:"submitted_time,omitempty"`
+	DeadlineTime  int64 `protobuf:"varint,9,opt,name=deadline_time,json=deadlineTime,proto3" json:"deadline_time,omitempty"`
+	// Unix timestamp of when the swap will be submitted, if it's
+	// waiting to be.
+	ScheduledTime int64 `protobuf:"varint,10,opt,name=scheduled_time,json=scheduledTime,proto3" json:"scheduled_time,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *PsbtSignNonMwebRequest) Reset() {
-	*x = PsbtSignNonMwebRequest{}
-	mi := &file_mwebd_proto_msgTypes[20]
+func (x *CoinswapStatusResponse) Reset() {
+	*x = CoinswapStatusResponse{}
+	mi := &file_mwebd_proto_msgTypes[63]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *PsbtSignNonMwebRequest) String() string {
+func (x *CoinswapStatusResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PsbtSignNonMwebRequest) ProtoMessage() {}
+func (*CoinswapStatusResponse) ProtoMessage() {}
 
-func (x *PsbtSignNonMwebRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_mwebd_proto_msgTypes[20]
+func (x *CoinswapStatusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_mwebd_proto_msgTypes[63]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1231,109 +4659,103 @@ func (x *PsbtSignNonMwebRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PsbtSignNonMwebRequest.ProtoReflect.Descriptor instead.
-func (*PsbtSignNonMwebRequest) Descriptor() ([]byte, []int) {
-	return file_mwebd_proto_rawDescGZIP(), []int{20}
+// Deprecated: Use CoinswapStatusResponse.ProtoReflect.Descriptor instead.
+func (*CoinswapStatusResponse) Descriptor() ([]byte, []int) {
+	return file_mwebd_proto_rawDescGZIP(), []int{63}
 }
 
-func (x *PsbtSignNonMwebRequest) GetPsbtB64() string {
+func (x *CoinswapStatusResponse) GetOutputId() string {
 	if x != nil {
-		return x.PsbtB64
+		return x.OutputId
 	}
 	return ""
 }
 
-func (x *PsbtSignNonMwebRequest) GetPrivKey() []byte {
+func (x *CoinswapStatusResponse) GetState() CoinswapState {
 	if x != nil {
-		return x.PrivKey
+		return x.State
 	}
-	return nil
+	return CoinswapState_COINSWAP_PENDING
 }
 
-func (x *PsbtSignNonMwebRequest) GetIndex() uint32 {
+func (x *CoinswapStatusResponse) GetError() string {
 	if x != nil {
-		return x.Index
+		return x.Error
 	}
-	return 0
+	return ""
 }
 
-type PsbtExtractRequest struct {
-	state protoimpl.MessageState `protogen:"open.v1"`
-	// The PSBT in base64 encoding.
-	PsbtB64 string `protobuf:"bytes,1,opt,name=psbt_b64,json=psbtB64,proto3" json:"psbt_b64,omitempty"`
-	// Extract the unsigned transaction.
-	Unsigned      bool `protobuf:"varint,2,opt,name=unsigned,proto3" json:"unsigned,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *CoinswapStatusResponse) GetSwapOutputId() string {
+	if x != nil {
+		return x.SwapOutputId
+	}
+	return ""
 }
 
-func (x *PsbtExtractRequest) Reset() {
-	*x = PsbtExtractRequest{}
-	mi := &file_mwebd_proto_msgTypes[21]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
+func (x *CoinswapStatusResponse) GetEntryNode() string {
+	if x != nil {
+		return x.EntryNode
+	}
+	return ""
 }
 
-func (x *PsbtExtractRequest) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *CoinswapStatusResponse) GetHops() uint32 {
+	if x != nil {
+		return x.Hops
+	}
+	return 0
 }
 
-func (*PsbtExtractRequest) ProtoMessage() {}
-
-func (x *PsbtExtractRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_mwebd_proto_msgTypes[21]
+func (x *CoinswapStatusResponse) GetAttempts() uint32 {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.Attempts
 	}
-	return mi.MessageOf(x)
+	return 0
 }
 
-// Deprecated: Use PsbtExtractRequest.ProtoReflect.Descriptor instead.
-func (*PsbtExtractRequest) Descriptor() ([]byte, []int) {
-	return file_mwebd_proto_rawDescGZIP(), []int{21}
+func (x *CoinswapStatusResponse) GetSubmittedTime() int64 {
+	if x != nil {
+		return x.SubmittedTime
+	}
+	return 0
 }
 
-func (x *PsbtExtractRequest) GetPsbtB64() string {
+func (x *CoinswapStatusResponse) GetDeadlineTime() int64 {
 	if x != nil {
-		return x.PsbtB64
+		return x.DeadlineTime
 	}
-	return ""
+	return 0
 }
 
-func (x *PsbtExtractRequest) GetUnsigned() bool {
+func (x *CoinswapStatusResponse) GetScheduledTime() int64 {
 	if x != nil {
-		return x.Unsigned
+		return x.ScheduledTime
 	}
-	return false
+	return 0
 }
 
-type BroadcastRequest struct {
-	state protoimpl.MessageState `protogen:"open.v1"`
-	// The raw bytes of the serialized transaction.
-	RawTx         []byte `protobuf:"bytes,1,opt,name=raw_tx,json=rawTx,proto3" json:"raw_tx,omitempty"`
+type UnlockRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Passphrase    string                 `protobuf:"bytes,1,opt,name=passphrase,proto3" json:"passphrase,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *BroadcastRequest) Reset() {
-	*x = BroadcastRequest{}
-	mi := &file_mwebd_proto_msgTypes[22]
+func (x *UnlockRequest) Reset() {
+	*x = UnlockRequest{}
+	mi := &file_mwebd_proto_msgTypes[64]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *BroadcastRequest) String() string {
+func (x *UnlockRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*BroadcastRequest) ProtoMessage() {}
+func (*UnlockRequest) ProtoMessage() {}
 
-func (x *BroadcastRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_mwebd_proto_msgTypes[22]
+func (x *UnlockRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_mwebd_proto_msgTypes[64]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1344,41 +4766,39 @@ func (x *BroadcastRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use BroadcastRequest.ProtoReflect.Descriptor instead.
-func (*BroadcastRequest) Descriptor() ([]byte, []int) {
-	return file_mwebd_proto_rawDescGZIP(), []int{22}
+// Deprecated: Use UnlockRequest.ProtoReflect.Descriptor instead.
+func (*UnlockRequest) Descriptor() ([]byte, []int) {
+	return file_mwebd_proto_rawDescGZIP(), []int{64}
 }
 
-func (x *BroadcastRequest) GetRawTx() []byte {
+func (x *UnlockRequest) GetPassphrase() string {
 	if x != nil {
-		return x.RawTx
+		return x.Passphrase
 	}
-	return nil
+	return ""
 }
 
-type BroadcastResponse struct {
-	state protoimpl.MessageState `protogen:"open.v1"`
-	// The transaction ID.
-	Txid          string `protobuf:"bytes,1,opt,name=txid,proto3" json:"txid,omitempty"`
+type UnlockResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *BroadcastResponse) Reset() {
-	*x = BroadcastResponse{}
-	mi := &file_mwebd_proto_msgTypes[23]
+func (x *UnlockResponse) Reset() {
+	*x = UnlockResponse{}
+	mi := &file_mwebd_proto_msgTypes[65]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *BroadcastResponse) String() string {
+func (x *UnlockResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*BroadcastResponse) ProtoMessage() {}
+func (*UnlockResponse) ProtoMessage() {}
 
-func (x *BroadcastResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_mwebd_proto_msgTypes[23]
+func (x *UnlockResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_mwebd_proto_msgTypes[65]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1389,49 +4809,36 @@ func (x *BroadcastResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use BroadcastResponse.ProtoReflect.Descriptor instead.
-func (*BroadcastResponse) Descriptor() ([]byte, []int) {
-	return file_mwebd_proto_rawDescGZIP(), []int{23}
-}
-
-func (x *BroadcastResponse) GetTxid() string {
-	if x != nil {
-		return x.Txid
-	}
-	return ""
+// Deprecated: Use UnlockResponse.ProtoReflect.Descriptor instead.
+func (*UnlockResponse) Descriptor() ([]byte, []int) {
+	return file_mwebd_proto_rawDescGZIP(), []int{65}
 }
 
-type CoinswapRequest struct {
-	state protoimpl.MessageState `protogen:"open.v1"`
-	// The scan secret or view key represents the account that
-	// the utxo belongs to.
-	ScanSecret []byte `protobuf:"bytes,1,opt,name=scan_secret,json=scanSecret,proto3" json:"scan_secret,omitempty"`
-	// The spend secret is the private key necessary for spending
-	// the utxos belonging to the account.
-	SpendSecret []byte `protobuf:"bytes,2,opt,name=spend_secret,json=spendSecret,proto3" json:"spend_secret,omitempty"`
-	// Output ID of the utxo to request a coinswap for.
-	OutputId string `protobuf:"bytes,3,opt,name=output_id,json=outputId,proto3" json:"output_id,omitempty"`
-	// Address index of the utxo.
-	AddrIndex     uint32 `protobuf:"varint,4,opt,name=addr_index,json=addrIndex,proto3" json:"addr_index,omitempty"`
+type RotateKeyRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OldPassphrase string                 `protobuf:"bytes,1,opt,name=old_passphrase,json=oldPassphrase,proto3" json:"old_passphrase,omitempty"`
+	// May be the same as the old passphrase, to only replace the key
+	// that the data is encrypted with.
+	NewPassphrase string `protobuf:"bytes,2,opt,name=new_passphrase,json=newPassphrase,proto3" json:"new_passphrase,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CoinswapRequest) Reset() {
-	*x = CoinswapRequest{}
-	mi := &file_mwebd_proto_msgTypes[24]
+func (x *RotateKeyRequest) Reset() {
+	*x = RotateKeyRequest{}
+	mi := &file_mwebd_proto_msgTypes[66]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CoinswapRequest) String() string {
+func (x *RotateKeyRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CoinswapRequest) ProtoMessage() {}
+func (*RotateKeyRequest) ProtoMessage() {}
 
-func (x *CoinswapRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_mwebd_proto_msgTypes[24]
+func (x *RotateKeyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_mwebd_proto_msgTypes[66]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1442,62 +4849,46 @@ func (x *CoinswapRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CoinswapRequest.ProtoReflect.Descriptor instead.
-func (*CoinswapRequest) Descriptor() ([]byte, []int) {
-	return file_mwebd_proto_rawDescGZIP(), []int{24}
-}
-
-func (x *CoinswapRequest) GetScanSecret() []byte {
-	if x != nil {
-		return x.ScanSecret
-	}
-	return nil
-}
-
-func (x *CoinswapRequest) GetSpendSecret() []byte {
-	if x != nil {
-		return x.SpendSecret
-	}
-	return nil
+// Deprecated: Use RotateKeyRequest.ProtoReflect.Descriptor instead.
+func (*RotateKeyRequest) Descriptor() ([]byte, []int) {
+	return file_mwebd_proto_rawDescGZIP(), []int{66}
 }
 
-func (x *CoinswapRequest) GetOutputId() string {
+func (x *RotateKeyRequest) GetOldPassphrase() string {
 	if x != nil {
-		return x.OutputId
+		return x.OldPassphrase
 	}
 	return ""
 }
 
-func (x *CoinswapRequest) GetAddrIndex() uint32 {
+func (x *RotateKeyRequest) GetNewPassphrase() string {
 	if x != nil {
-		return x.AddrIndex
+		return x.NewPassphrase
 	}
-	return 0
+	return ""
 }
 
-type CoinswapResponse struct {
-	state protoimpl.MessageState `protogen:"open.v1"`
-	// Output ID of the utxo created by the transaction.
-	OutputId      string `protobuf:"bytes,1,opt,name=output_id,json=outputId,proto3" json:"output_id,omitempty"`
+type RotateKeyResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CoinswapResponse) Reset() {
-	*x = CoinswapResponse{}
-	mi := &file_mwebd_proto_msgTypes[25]
+func (x *RotateKeyResponse) Reset() {
+	*x = RotateKeyResponse{}
+	mi := &file_mwebd_proto_msgTypes[67]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CoinswapResponse) String() string {
+func (x *RotateKeyResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CoinswapResponse) ProtoMessage() {}
+func (*RotateKeyResponse) ProtoMessage() {}
 
-func (x *CoinswapResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_mwebd_proto_msgTypes[25]
+func (x *RotateKeyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_mwebd_proto_msgTypes[67]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1508,16 +4899,9 @@ func (x *CoinswapResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CoinswapResponse.ProtoReflect.Descriptor instead.
-func (*CoinswapResponse) Descriptor() ([]byte, []int) {
-	return file_mwebd_proto_rawDescGZIP(), []int{25}
-}
-
-func (x *CoinswapResponse) GetOutputId() string {
-	if x != nil {
-		return x.OutputId
-	}
-	return ""
+// Deprecated: Use RotateKeyResponse.ProtoReflect.Descriptor instead.
+func (*RotateKeyResponse) Descriptor() ([]byte, []int) {
+	return file_mwebd_proto_rawDescGZIP(), []int{67}
 }
 
 var File_mwebd_proto protoreflect.FileDescriptor
@@ -1525,51 +4909,120 @@ var File_mwebd_proto protoreflect.FileDescriptor
 const file_mwebd_proto_rawDesc = "" +
 	"\n" +
 	"\vmwebd.proto\"\x0f\n" +
-	"\rStatusRequest\"\xb9\x01\n" +
+	"\rStatusRequest\"\xd1\x01\n" +
 	"\x0eStatusResponse\x12.\n" +
 	"\x13block_header_height\x18\x01 \x01(\x05R\x11blockHeaderHeight\x12,\n" +
 	"\x12mweb_header_height\x18\x02 \x01(\x05R\x10mwebHeaderHeight\x12*\n" +
 	"\x11mweb_utxos_height\x18\x03 \x01(\x05R\x0fmwebUtxosHeight\x12\x1d\n" +
 	"\n" +
-	"block_time\x18\x04 \x01(\rR\tblockTime\"P\n" +
+	"block_time\x18\x04 \x01(\rR\tblockTime\x12\x16\n" +
+	"\x06locked\x18\x05 \x01(\bR\x06locked\"\x7f\n" +
 	"\fUtxosRequest\x12\x1f\n" +
 	"\vfrom_height\x18\x01 \x01(\x05R\n" +
 	"fromHeight\x12\x1f\n" +
 	"\vscan_secret\x18\x02 \x01(\fR\n" +
-	"scanSecret\"\x8a\x01\n" +
+	"scanSecret\x12-\n" +
+	"\x12account_descriptor\x18\x03 \x01(\tR\x11accountDescriptor\"\x8a\x01\n" +
 	"\x04Utxo\x12\x16\n" +
 	"\x06height\x18\x01 \x01(\x05R\x06height\x12\x14\n" +
 	"\x05value\x18\x02 \x01(\x04R\x05value\x12\x18\n" +
 	"\aaddress\x18\x03 \x01(\tR\aaddress\x12\x1b\n" +
 	"\toutput_id\x18\x04 \x01(\tR\boutputId\x12\x1d\n" +
 	"\n" +
-	"block_time\x18\x05 \x01(\rR\tblockTime\"\x8e\x01\n" +
+	"block_time\x18\x05 \x01(\rR\tblockTime\"\xbd\x01\n" +
 	"\x0eAddressRequest\x12\x1d\n" +
 	"\n" +
 	"from_index\x18\x01 \x01(\rR\tfromIndex\x12\x19\n" +
 	"\bto_index\x18\x02 \x01(\rR\atoIndex\x12\x1f\n" +
 	"\vscan_secret\x18\x03 \x01(\fR\n" +
 	"scanSecret\x12!\n" +
-	"\fspend_pubkey\x18\x04 \x01(\fR\vspendPubkey\"+\n" +
+	"\fspend_pubkey\x18\x04 \x01(\fR\vspendPubkey\x12-\n" +
+	"\x12account_descriptor\x18\x05 \x01(\tR\x11accountDescriptor\"+\n" +
 	"\x0fAddressResponse\x12\x18\n" +
-	"\aaddress\x18\x01 \x03(\tR\aaddress\" \n" +
+	"\aaddress\x18\x01 \x03(\tR\aaddress\"\xad\x01\n" +
+	"\x0fKeychainRequest\x12\x12\n" +
+	"\x04xprv\x18\x01 \x01(\tR\x04xprv\x12\x1a\n" +
+	"\bmnemonic\x18\x02 \x01(\tR\bmnemonic\x12\x1e\n" +
+	"\n" +
+	"passphrase\x18\x03 \x01(\tR\n" +
+	"passphrase\x12\x18\n" +
+	"\aaccount\x18\x04 \x01(\rR\aaccount\x120\n" +
+	"\x14include_spend_secret\x18\x05 \x01(\bR\x12includeSpendSecret\"\xa8\x01\n" +
+	"\x10KeychainResponse\x12\x1f\n" +
+	"\vscan_secret\x18\x01 \x01(\fR\n" +
+	"scanSecret\x12!\n" +
+	"\fspend_pubkey\x18\x02 \x01(\fR\vspendPubkey\x12!\n" +
+	"\fspend_secret\x18\x03 \x01(\fR\vspendSecret\x12-\n" +
+	"\x12account_descriptor\x18\x04 \x01(\tR\x11accountDescriptor\"\xc2\x01\n" +
+	"\x16ValidateAddressRequest\x12\x18\n" +
+	"\aaddress\x18\x01 \x01(\tR\aaddress\x12\x1f\n" +
+	"\vscan_secret\x18\x02 \x01(\fR\n" +
+	"scanSecret\x12!\n" +
+	"\fspend_pubkey\x18\x03 \x01(\fR\vspendPubkey\x12-\n" +
+	"\x12account_descriptor\x18\x04 \x01(\tR\x11accountDescriptor\x12\x1b\n" +
+	"\tmax_index\x18\x05 \x01(\rR\bmaxIndex\"\xee\x02\n" +
+	"\x17ValidateAddressResponse\x12\x14\n" +
+	"\x05valid\x18\x01 \x01(\bR\x05valid\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\x12 \n" +
+	"\x04type\x18\x03 \x01(\x0e2\f.AddressTypeR\x04type\x12\x18\n" +
+	"\anetwork\x18\x04 \x01(\tR\anetwork\x12#\n" +
+	"\rwrong_network\x18\x05 \x01(\bR\fwrongNetwork\x12\x1b\n" +
+	"\tpk_script\x18\x06 \x01(\fR\bpkScript\x12'\n" +
+	"\x0fwitness_version\x18\a \x01(\rR\x0ewitnessVersion\x12\x1f\n" +
+	"\vscan_pubkey\x18\b \x01(\fR\n" +
+	"scanPubkey\x12!\n" +
+	"\fspend_pubkey\x18\t \x01(\fR\vspendPubkey\x12\x17\n" +
+	"\ais_mine\x18\n" +
+	" \x01(\bR\x06isMine\x12\x19\n" +
+	"\x05index\x18\v \x01(\rH\x00R\x05index\x88\x01\x01B\b\n" +
+	"\x06_index\" \n" +
 	"\n" +
 	"LedgerApdu\x12\x12\n" +
 	"\x04data\x18\x01 \x01(\fR\x04data\"+\n" +
 	"\fSpentRequest\x12\x1b\n" +
 	"\toutput_id\x18\x01 \x03(\tR\boutputId\",\n" +
 	"\rSpentResponse\x12\x1b\n" +
-	"\toutput_id\x18\x01 \x03(\tR\boutputId\"\xaa\x01\n" +
+	"\toutput_id\x18\x01 \x03(\tR\boutputId\"\xe4\x02\n" +
 	"\rCreateRequest\x12\x15\n" +
 	"\x06raw_tx\x18\x01 \x01(\fR\x05rawTx\x12\x1f\n" +
 	"\vscan_secret\x18\x02 \x01(\fR\n" +
 	"scanSecret\x12!\n" +
 	"\fspend_secret\x18\x03 \x01(\fR\vspendSecret\x12%\n" +
 	"\x0ffee_rate_per_kb\x18\x04 \x01(\x04R\ffeeRatePerKb\x12\x17\n" +
-	"\adry_run\x18\x05 \x01(\bR\x06dryRun\"D\n" +
+	"\adry_run\x18\x05 \x01(\bR\x06dryRun\x12\x14\n" +
+	"\x05sweep\x18\x06 \x01(\bR\x05sweep\x12\x1f\n" +
+	"\vsweep_index\x18\a \x01(\rR\n" +
+	"sweepIndex\x12/\n" +
+	"\fpegin_policy\x18\b \x01(\x0e2\f.PeginPolicyR\vpeginPolicy\x12!\n" +
+	"\fpegin_amount\x18\t \x01(\x04R\vpeginAmount\x12-\n" +
+	"\x12account_descriptor\x18\n" +
+	" \x01(\tR\x11accountDescriptor\"b\n" +
 	"\x0eCreateResponse\x12\x15\n" +
 	"\x06raw_tx\x18\x01 \x01(\fR\x05rawTx\x12\x1b\n" +
-	"\toutput_id\x18\x02 \x03(\tR\boutputId\"U\n" +
+	"\toutput_id\x18\x02 \x03(\tR\boutputId\x12\x1c\n" +
+	"\x05pegin\x18\x03 \x01(\v2\x06.PeginR\x05pegin\"\xa3\x01\n" +
+	"\x05Pegin\x12\x14\n" +
+	"\x05value\x18\x01 \x01(\x04R\x05value\x12\x1b\n" +
+	"\tpk_script\x18\x02 \x01(\fR\bpkScript\x12!\n" +
+	"\foutput_index\x18\x03 \x01(\rR\voutputIndex\x12\x1f\n" +
+	"\vkernel_hash\x18\x04 \x01(\tR\n" +
+	"kernelHash\x12#\n" +
+	"\rfunding_input\x18\x05 \x03(\tR\ffundingInput\"\x8e\x01\n" +
+	"\x12EstimateFeeRequest\x12\x15\n" +
+	"\x06raw_tx\x18\x01 \x01(\fR\x05rawTx\x12\x1f\n" +
+	"\vscan_secret\x18\x02 \x01(\fR\n" +
+	"scanSecret\x12%\n" +
+	"\x0ffee_rate_per_kb\x18\x03 \x01(\x04R\ffeeRatePerKb\x12\x19\n" +
+	"\bpsbt_b64\x18\x04 \x01(\tR\apsbtB64\"\xbe\x01\n" +
+	"\x13EstimateFeeResponse\x12\x19\n" +
+	"\bmweb_fee\x18\x01 \x01(\x04R\amwebFee\x12\x1d\n" +
+	"\n" +
+	"pegout_fee\x18\x02 \x01(\x04R\tpegoutFee\x12\x1d\n" +
+	"\n" +
+	"kernel_fee\x18\x03 \x01(\x04R\tkernelFee\x12\x14\n" +
+	"\x05pegin\x18\x04 \x01(\x04R\x05pegin\x12\x1b\n" +
+	"\thogex_fee\x18\x05 \x01(\x04R\bhogexFee\x12\x1b\n" +
+	"\ttotal_fee\x18\x06 \x01(\x04R\btotalFee\"U\n" +
 	"\x11PsbtCreateRequest\x12\x15\n" +
 	"\x06raw_tx\x18\x01 \x01(\fR\x05rawTx\x12)\n" +
 	"\fwitness_utxo\x18\x02 \x03(\v2\x06.TxOutR\vwitnessUtxo\":\n" +
@@ -1584,11 +5037,25 @@ const file_mwebd_proto_rawDesc = "" +
 	"scanSecret\x12\x1b\n" +
 	"\toutput_id\x18\x03 \x01(\tR\boutputId\x12#\n" +
 	"\raddress_index\x18\x04 \x01(\rR\faddressIndex\x12%\n" +
-	"\x0ffee_rate_per_kb\x18\x05 \x01(\x04R\ffeeRatePerKb\"\x89\x01\n" +
+	"\x0ffee_rate_per_kb\x18\x05 \x01(\x04R\ffeeRatePerKb\"\x9f\x01\n" +
 	"\x17PsbtAddRecipientRequest\x12\x19\n" +
 	"\bpsbt_b64\x18\x01 \x01(\tR\apsbtB64\x12,\n" +
 	"\trecipient\x18\x02 \x01(\v2\x0e.PsbtRecipientR\trecipient\x12%\n" +
-	"\x0ffee_rate_per_kb\x18\x03 \x01(\x04R\ffeeRatePerKb\"5\n" +
+	"\x0ffee_rate_per_kb\x18\x03 \x01(\x04R\ffeeRatePerKb\x12\x14\n" +
+	"\x05sweep\x18\x04 \x01(\bR\x05sweep\"p\n" +
+	"\x16PsbtRemoveInputRequest\x12\x19\n" +
+	"\bpsbt_b64\x18\x01 \x01(\tR\apsbtB64\x12\x14\n" +
+	"\x05index\x18\x02 \x01(\rR\x05index\x12%\n" +
+	"\x0ffee_rate_per_kb\x18\x03 \x01(\x04R\ffeeRatePerKb\"t\n" +
+	"\x1aPsbtRemoveRecipientRequest\x12\x19\n" +
+	"\bpsbt_b64\x18\x01 \x01(\tR\apsbtB64\x12\x14\n" +
+	"\x05index\x18\x02 \x01(\rR\x05index\x12%\n" +
+	"\x0ffee_rate_per_kb\x18\x03 \x01(\x04R\ffeeRatePerKb\"\xa2\x01\n" +
+	"\x1aPsbtUpdateRecipientRequest\x12\x19\n" +
+	"\bpsbt_b64\x18\x01 \x01(\tR\apsbtB64\x12\x14\n" +
+	"\x05index\x18\x02 \x01(\rR\x05index\x12,\n" +
+	"\trecipient\x18\x03 \x01(\v2\x0e.PsbtRecipientR\trecipient\x12%\n" +
+	"\x0ffee_rate_per_kb\x18\x04 \x01(\x04R\ffeeRatePerKb\"5\n" +
 	"\x18PsbtGetRecipientsRequest\x12\x19\n" +
 	"\bpsbt_b64\x18\x01 \x01(\tR\apsbtB64\"\x80\x01\n" +
 	"\x19PsbtGetRecipientsResponse\x12,\n" +
@@ -1597,49 +5064,261 @@ const file_mwebd_proto_rawDesc = "" +
 	"\x03fee\x18\x03 \x01(\x03R\x03fee\"?\n" +
 	"\rPsbtRecipient\x12\x18\n" +
 	"\aaddress\x18\x01 \x01(\tR\aaddress\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\x03R\x05value\"p\n" +
+	"\x05value\x18\x02 \x01(\x03R\x05value\".\n" +
+	"\x11PsbtDecodeRequest\x12\x19\n" +
+	"\bpsbt_b64\x18\x01 \x01(\tR\apsbtB64\"\x80\x03\n" +
+	"\x12PsbtDecodeResponse\x12!\n" +
+	"\fpsbt_version\x18\x01 \x01(\rR\vpsbtVersion\x12\x1d\n" +
+	"\n" +
+	"tx_version\x18\x02 \x01(\x05R\ttxVersion\x12+\n" +
+	"\x11fallback_locktime\x18\x03 \x01(\rR\x10fallbackLocktime\x12$\n" +
+	"\x0emweb_tx_offset\x18\x04 \x01(\tR\fmwebTxOffset\x12.\n" +
+	"\x13mweb_stealth_offset\x18\x05 \x01(\tR\x11mwebStealthOffset\x12'\n" +
+	"\x05input\x18\x06 \x03(\v2\x11.PsbtDecodedInputR\x05input\x12*\n" +
+	"\x06output\x18\a \x03(\v2\x12.PsbtDecodedOutputR\x06output\x12*\n" +
+	"\x06kernel\x18\b \x03(\v2\x12.PsbtDecodedKernelR\x06kernel\x12\x10\n" +
+	"\x03fee\x18\t \x01(\x03R\x03fee\x12\x12\n" +
+	"\x04json\x18\n" +
+	" \x01(\tR\x04json\"\xc8\x04\n" +
+	"\x10PsbtDecodedInput\x12\x12\n" +
+	"\x04mweb\x18\x01 \x01(\bR\x04mweb\x12\x18\n" +
+	"\aprevout\x18\x02 \x01(\tR\aprevout\x12\x1a\n" +
+	"\bsequence\x18\x03 \x01(\rR\bsequence\x12\x14\n" +
+	"\x05value\x18\x04 \x01(\x03R\x05value\x12\x1b\n" +
+	"\tpk_script\x18\x05 \x01(\tR\bpkScript\x12\x18\n" +
+	"\aaddress\x18\x06 \x01(\tR\aaddress\x12!\n" +
+	"\fpartial_sigs\x18\a \x01(\rR\vpartialSigs\x12\x1c\n" +
+	"\tfinalized\x18\b \x01(\bR\tfinalized\x12$\n" +
+	"\x0emweb_output_id\x18\t \x01(\tR\fmwebOutputId\x12,\n" +
+	"\x12mweb_address_index\x18\n" +
+	" \x01(\rR\x10mwebAddressIndex\x123\n" +
+	"\x16has_mweb_shared_secret\x18\v \x01(\bR\x13hasMwebSharedSecret\x127\n" +
+	"\x18mweb_key_exchange_pubkey\x18\f \x01(\tR\x15mwebKeyExchangePubkey\x12\x1f\n" +
+	"\vmweb_commit\x18\r \x01(\tR\n" +
+	"mwebCommit\x12,\n" +
+	"\x12mweb_output_pubkey\x18\x0e \x01(\tR\x10mwebOutputPubkey\x12*\n" +
+	"\x11mweb_input_pubkey\x18\x0f \x01(\tR\x0fmwebInputPubkey\x12\x1f\n" +
+	"\vmweb_signed\x18\x10 \x01(\bR\n" +
+	"mwebSigned\"\xa1\x02\n" +
+	"\x11PsbtDecodedOutput\x12\x12\n" +
+	"\x04mweb\x18\x01 \x01(\bR\x04mweb\x12\x16\n" +
+	"\x06amount\x18\x02 \x01(\x03R\x06amount\x12\x1b\n" +
+	"\tpk_script\x18\x03 \x01(\tR\bpkScript\x12\x18\n" +
+	"\aaddress\x18\x04 \x01(\tR\aaddress\x12,\n" +
+	"\x12mweb_output_commit\x18\x05 \x01(\tR\x10mwebOutputCommit\x12,\n" +
+	"\x12mweb_sender_pubkey\x18\x06 \x01(\tR\x10mwebSenderPubkey\x12,\n" +
+	"\x12mweb_output_pubkey\x18\a \x01(\tR\x10mwebOutputPubkey\x12\x1f\n" +
+	"\vmweb_signed\x18\b \x01(\bR\n" +
+	"mwebSigned\"\x84\x02\n" +
+	"\x11PsbtDecodedKernel\x12\x1a\n" +
+	"\bfeatures\x18\x01 \x01(\rR\bfeatures\x12\x10\n" +
+	"\x03fee\x18\x02 \x01(\x03R\x03fee\x12!\n" +
+	"\fpegin_amount\x18\x03 \x01(\x03R\vpeginAmount\x12&\n" +
+	"\x06pegout\x18\x04 \x03(\v2\x0e.PsbtRecipientR\x06pegout\x12\x1f\n" +
+	"\vlock_height\x18\x05 \x01(\x05R\n" +
+	"lockHeight\x12\x16\n" +
+	"\x06excess\x18\x06 \x01(\tR\x06excess\x12%\n" +
+	"\x0estealth_excess\x18\a \x01(\tR\rstealthExcess\x12\x16\n" +
+	"\x06signed\x18\b \x01(\bR\x06signed\"p\n" +
 	"\x0fPsbtSignRequest\x12\x19\n" +
 	"\bpsbt_b64\x18\x01 \x01(\tR\apsbtB64\x12\x1f\n" +
 	"\vscan_secret\x18\x02 \x01(\fR\n" +
 	"scanSecret\x12!\n" +
-	"\fspend_secret\x18\x03 \x01(\fR\vspendSecret\"d\n" +
+	"\fspend_secret\x18\x03 \x01(\fR\vspendSecret\"x\n" +
 	"\x16PsbtSignNonMwebRequest\x12\x19\n" +
 	"\bpsbt_b64\x18\x01 \x01(\tR\apsbtB64\x12\x19\n" +
 	"\bpriv_key\x18\x02 \x01(\fR\aprivKey\x12\x14\n" +
-	"\x05index\x18\x03 \x01(\rR\x05index\"K\n" +
+	"\x05index\x18\x03 \x01(\rR\x05index\x12\x12\n" +
+	"\x04xprv\x18\x04 \x01(\tR\x04xprv\"/\n" +
+	"\x12PsbtCombineRequest\x12\x19\n" +
+	"\bpsbt_b64\x18\x01 \x03(\tR\apsbtB64\"/\n" +
+	"\x12PsbtAnalyzeRequest\x12\x19\n" +
+	"\bpsbt_b64\x18\x01 \x01(\tR\apsbtB64\"\xae\x01\n" +
+	"\x13PsbtAnalyzeResponse\x12(\n" +
+	"\x05input\x18\x01 \x03(\v2\x12.PsbtInputAnalysisR\x05input\x120\n" +
+	"\x14unsigned_mweb_output\x18\x02 \x03(\rR\x12unsignedMwebOutput\x12'\n" +
+	"\x0funsigned_kernel\x18\x03 \x03(\rR\x0eunsignedKernel\x12\x12\n" +
+	"\x04next\x18\x04 \x01(\tR\x04next\"x\n" +
+	"\x11PsbtInputAnalysis\x12\x12\n" +
+	"\x04mweb\x18\x01 \x01(\bR\x04mweb\x12\x19\n" +
+	"\bhas_utxo\x18\x02 \x01(\bR\ahasUtxo\x12\x16\n" +
+	"\x06signed\x18\x03 \x01(\bR\x06signed\x12\x1c\n" +
+	"\tfinalized\x18\x04 \x01(\bR\tfinalized\"0\n" +
+	"\x13PsbtFinalizeRequest\x12\x19\n" +
+	"\bpsbt_b64\x18\x01 \x01(\tR\apsbtB64\"K\n" +
 	"\x12PsbtExtractRequest\x12\x19\n" +
 	"\bpsbt_b64\x18\x01 \x01(\tR\apsbtB64\x12\x1a\n" +
 	"\bunsigned\x18\x02 \x01(\bR\bunsigned\")\n" +
 	"\x10BroadcastRequest\x12\x15\n" +
 	"\x06raw_tx\x18\x01 \x01(\fR\x05rawTx\"'\n" +
 	"\x11BroadcastResponse\x12\x12\n" +
-	"\x04txid\x18\x01 \x01(\tR\x04txid\"\x91\x01\n" +
+	"\x04txid\x18\x01 \x01(\tR\x04txid\"J\n" +
+	"\x13PegoutStatusRequest\x12\x1f\n" +
+	"\vkernel_hash\x18\x01 \x01(\tR\n" +
+	"kernelHash\x12\x12\n" +
+	"\x04txid\x18\x02 \x01(\tR\x04txid\"C\n" +
+	"\x14PegoutStatusResponse\x12+\n" +
+	"\x06kernel\x18\x01 \x03(\v2\x13.KernelPegoutStatusR\x06kernel\"\x9b\x02\n" +
+	"\x12KernelPegoutStatus\x12\x1f\n" +
+	"\vkernel_hash\x18\x01 \x01(\tR\n" +
+	"kernelHash\x12\x12\n" +
+	"\x04txid\x18\x02 \x01(\tR\x04txid\x12\x14\n" +
+	"\x05mined\x18\x03 \x01(\bR\x05mined\x12'\n" +
+	"\x0fexpected_height\x18\x04 \x01(\x05R\x0eexpectedHeight\x12!\n" +
+	"\fhogex_height\x18\x05 \x01(\x05R\vhogexHeight\x12(\n" +
+	"\x10hogex_block_hash\x18\x06 \x01(\tR\x0ehogexBlockHash\x12\x1f\n" +
+	"\x06pegout\x18\a \x03(\v2\a.PegoutR\x06pegout\x12#\n" +
+	"\rmature_height\x18\b \x01(\x05R\fmatureHeight\"W\n" +
+	"\x06Pegout\x12\x14\n" +
+	"\x05value\x18\x01 \x01(\x04R\x05value\x12\x1b\n" +
+	"\tpk_script\x18\x02 \x01(\fR\bpkScript\x12\x1a\n" +
+	"\boutpoint\x18\x03 \x01(\tR\boutpoint\"5\n" +
+	"\x12PeginStatusRequest\x12\x1f\n" +
+	"\vkernel_hash\x18\x01 \x01(\tR\n" +
+	"kernelHash\"9\n" +
+	"\x13PeginStatusResponse\x12\"\n" +
+	"\x05pegin\x18\x01 \x03(\v2\f.PeginStatusR\x05pegin\"\xec\x01\n" +
+	"\vPeginStatus\x12\x1f\n" +
+	"\vkernel_hash\x18\x01 \x01(\tR\n" +
+	"kernelHash\x12!\n" +
+	"\x05state\x18\x02 \x01(\x0e2\v.PeginStateR\x05state\x12\x14\n" +
+	"\x05value\x18\x03 \x01(\x04R\x05value\x12\x1b\n" +
+	"\tpk_script\x18\x04 \x01(\fR\bpkScript\x12\x1b\n" +
+	"\toutput_id\x18\x05 \x03(\tR\boutputId\x12\x12\n" +
+	"\x04txid\x18\x06 \x01(\tR\x04txid\x12\x16\n" +
+	"\x06height\x18\a \x01(\x05R\x06height\x12\x1d\n" +
+	"\n" +
+	"block_hash\x18\b \x01(\tR\tblockHash\"\xc5\x01\n" +
 	"\x0fCoinswapRequest\x12\x1f\n" +
 	"\vscan_secret\x18\x01 \x01(\fR\n" +
 	"scanSecret\x12!\n" +
 	"\fspend_secret\x18\x02 \x01(\fR\vspendSecret\x12\x1b\n" +
 	"\toutput_id\x18\x03 \x01(\tR\boutputId\x12\x1d\n" +
 	"\n" +
-	"addr_index\x18\x04 \x01(\rR\taddrIndex\"/\n" +
+	"addr_index\x18\x04 \x01(\rR\taddrIndex\x12\x17\n" +
+	"\amax_fee\x18\x05 \x01(\x04R\x06maxFee\x12\x19\n" +
+	"\bmin_hops\x18\x06 \x01(\rR\aminHops\"/\n" +
 	"\x10CoinswapResponse\x12\x1b\n" +
-	"\toutput_id\x18\x01 \x01(\tR\boutputId2\xf1\x05\n" +
+	"\toutput_id\x18\x01 \x01(\tR\boutputId\"\xbb\x01\n" +
+	"\x14CoinswapBatchRequest\x12\x1f\n" +
+	"\vscan_secret\x18\x01 \x01(\fR\n" +
+	"scanSecret\x12!\n" +
+	"\fspend_secret\x18\x02 \x01(\fR\vspendSecret\x12+\n" +
+	"\x06inputs\x18\x03 \x03(\v2\x13.CoinswapBatchInputR\x06inputs\x12\x17\n" +
+	"\amax_fee\x18\x04 \x01(\x04R\x06maxFee\x12\x19\n" +
+	"\bmin_hops\x18\x05 \x01(\rR\aminHops\"P\n" +
+	"\x12CoinswapBatchInput\x12\x1b\n" +
+	"\toutput_id\x18\x01 \x01(\tR\boutputId\x12\x1d\n" +
+	"\n" +
+	"addr_index\x18\x02 \x01(\rR\taddrIndex\"G\n" +
+	"\x15CoinswapBatchResponse\x12.\n" +
+	"\aresults\x18\x01 \x03(\v2\x14.CoinswapBatchResultR\aresults\"o\n" +
+	"\x13CoinswapBatchResult\x12\x1b\n" +
+	"\toutput_id\x18\x01 \x01(\tR\boutputId\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\x12%\n" +
+	"\x0escheduled_time\x18\x03 \x01(\x03R\rscheduledTime\"\xdf\x01\n" +
+	"\x16CoinswapAutoMixRequest\x12\x1f\n" +
+	"\vscan_secret\x18\x01 \x01(\fR\n" +
+	"scanSecret\x12!\n" +
+	"\fspend_secret\x18\x02 \x01(\fR\vspendSecret\x12\x1b\n" +
+	"\tmin_value\x18\x03 \x01(\x04R\bminValue\x12\x16\n" +
+	"\x06rounds\x18\x04 \x01(\rR\x06rounds\x12\x17\n" +
+	"\amax_fee\x18\x05 \x01(\x04R\x06maxFee\x12\x19\n" +
+	"\bmin_hops\x18\x06 \x01(\rR\aminHops\x12\x18\n" +
+	"\adisable\x18\a \x01(\bR\adisable\"n\n" +
+	"\x17CoinswapAutoMixResponse\x120\n" +
+	"\tscheduled\x18\x01 \x03(\v2\x12.CoinswapScheduledR\tscheduled\x12!\n" +
+	"\fexpires_time\x18\x02 \x01(\x03R\vexpiresTime\"\x83\x01\n" +
+	"\x11CoinswapScheduled\x12\x1b\n" +
+	"\toutput_id\x18\x01 \x01(\tR\boutputId\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x04R\x05value\x12%\n" +
+	"\x0escheduled_time\x18\x03 \x01(\x03R\rscheduledTime\x12\x14\n" +
+	"\x05error\x18\x04 \x01(\tR\x05error\"4\n" +
+	"\x15CoinswapStatusRequest\x12\x1b\n" +
+	"\toutput_id\x18\x01 \x01(\tR\boutputId\"\x15\n" +
+	"\x13CoinswapListRequest\"K\n" +
+	"\x14CoinswapListResponse\x123\n" +
+	"\bcoinswap\x18\x01 \x03(\v2\x17.CoinswapStatusResponseR\bcoinswap\"\xd9\x02\n" +
+	"\x16CoinswapStatusResponse\x12\x1b\n" +
+	"\toutput_id\x18\x01 \x01(\tR\boutputId\x12$\n" +
+	"\x05state\x18\x02 \x01(\x0e2\x0e.CoinswapStateR\x05state\x12\x14\n" +
+	"\x05error\x18\x03 \x01(\tR\x05error\x12$\n" +
+	"\x0eswap_output_id\x18\x04 \x01(\tR\fswapOutputId\x12\x1d\n" +
+	"\n" +
+	"entry_node\x18\x05 \x01(\tR\tentryNode\x12\x12\n" +
+	"\x04hops\x18\x06 \x01(\rR\x04hops\x12\x1a\n" +
+	"\battempts\x18\a \x01(\rR\battempts\x12%\n" +
+	"\x0esubmitted_time\x18\b \x01(\x03R\rsubmittedTime\x12#\n" +
+	"\rdeadline_time\x18\t \x01(\x03R\fdeadlineTime\x12%\n" +
+	"\x0escheduled_time\x18\n" +
+	" \x01(\x03R\rscheduledTime\"/\n" +
+	"\rUnlockRequest\x12\x1e\n" +
+	"\n" +
+	"passphrase\x18\x01 \x01(\tR\n" +
+	"passphrase\"\x10\n" +
+	"\x0eUnlockResponse\"`\n" +
+	"\x10RotateKeyRequest\x12%\n" +
+	"\x0eold_passphrase\x18\x01 \x01(\tR\roldPassphrase\x12%\n" +
+	"\x0enew_passphrase\x18\x02 \x01(\tR\rnewPassphrase\"\x13\n" +
+	"\x11RotateKeyResponse*\x92\x01\n" +
+	"\vAddressType\x12\x13\n" +
+	"\x0fADDRESS_UNKNOWN\x10\x00\x12\x11\n" +
+	"\rADDRESS_P2PKH\x10\x01\x12\x10\n" +
+	"\fADDRESS_P2SH\x10\x02\x12\x12\n" +
+	"\x0eADDRESS_P2WPKH\x10\x03\x12\x11\n" +
+	"\rADDRESS_P2WSH\x10\x04\x12\x10\n" +
+	"\fADDRESS_P2TR\x10\x05\x12\x10\n" +
+	"\fADDRESS_MWEB\x10\x06*A\n" +
+	"\vPeginPolicy\x12\x0f\n" +
+	"\vPEGIN_ALLOW\x10\x00\x12\x10\n" +
+	"\fPEGIN_FORBID\x10\x01\x12\x0f\n" +
+	"\vPEGIN_EXACT\x10\x02*W\n" +
+	"\n" +
+	"PeginState\x12\x11\n" +
+	"\rPEGIN_PENDING\x10\x00\x12\x11\n" +
+	"\rPEGIN_MEMPOOL\x10\x01\x12\x0f\n" +
+	"\vPEGIN_MINED\x10\x02\x12\x12\n" +
+	"\x0ePEGIN_CREDITED\x10\x03*R\n" +
+	"\rCoinswapState\x12\x14\n" +
+	"\x10COINSWAP_PENDING\x10\x00\x12\x16\n" +
+	"\x12COINSWAP_COMPLETED\x10\x01\x12\x13\n" +
+	"\x0fCOINSWAP_FAILED\x10\x022\x98\x0e\n" +
 	"\x03Rpc\x12)\n" +
 	"\x06Status\x12\x0e.StatusRequest\x1a\x0f.StatusResponse\x12\x1f\n" +
 	"\x05Utxos\x12\r.UtxosRequest\x1a\x05.Utxo0\x01\x12.\n" +
-	"\tAddresses\x12\x0f.AddressRequest\x1a\x10.AddressResponse\x12&\n" +
+	"\tAddresses\x12\x0f.AddressRequest\x1a\x10.AddressResponse\x12/\n" +
+	"\bKeychain\x12\x10.KeychainRequest\x1a\x11.KeychainResponse\x12D\n" +
+	"\x0fValidateAddress\x12\x17.ValidateAddressRequest\x1a\x18.ValidateAddressResponse\x12&\n" +
 	"\x05Spent\x12\r.SpentRequest\x1a\x0e.SpentResponse\x12)\n" +
-	"\x06Create\x12\x0e.CreateRequest\x1a\x0f.CreateResponse\x12/\n" +
+	"\x06Create\x12\x0e.CreateRequest\x1a\x0f.CreateResponse\x128\n" +
+	"\vEstimateFee\x12\x13.EstimateFeeRequest\x1a\x14.EstimateFeeResponse\x12/\n" +
 	"\n" +
 	"PsbtCreate\x12\x12.PsbtCreateRequest\x1a\r.PsbtResponse\x123\n" +
 	"\fPsbtAddInput\x12\x14.PsbtAddInputRequest\x1a\r.PsbtResponse\x12;\n" +
-	"\x10PsbtAddRecipient\x12\x18.PsbtAddRecipientRequest\x1a\r.PsbtResponse\x12J\n" +
-	"\x11PsbtGetRecipients\x12\x19.PsbtGetRecipientsRequest\x1a\x1a.PsbtGetRecipientsResponse\x12+\n" +
+	"\x10PsbtAddRecipient\x12\x18.PsbtAddRecipientRequest\x1a\r.PsbtResponse\x129\n" +
+	"\x0fPsbtRemoveInput\x12\x17.PsbtRemoveInputRequest\x1a\r.PsbtResponse\x12A\n" +
+	"\x13PsbtRemoveRecipient\x12\x1b.PsbtRemoveRecipientRequest\x1a\r.PsbtResponse\x12A\n" +
+	"\x13PsbtUpdateRecipient\x12\x1b.PsbtUpdateRecipientRequest\x1a\r.PsbtResponse\x12J\n" +
+	"\x11PsbtGetRecipients\x12\x19.PsbtGetRecipientsRequest\x1a\x1a.PsbtGetRecipientsResponse\x125\n" +
+	"\n" +
+	"PsbtDecode\x12\x12.PsbtDecodeRequest\x1a\x13.PsbtDecodeResponse\x12+\n" +
 	"\bPsbtSign\x12\x10.PsbtSignRequest\x1a\r.PsbtResponse\x129\n" +
-	"\x0fPsbtSignNonMweb\x12\x17.PsbtSignNonMwebRequest\x1a\r.PsbtResponse\x123\n" +
+	"\x0fPsbtSignNonMweb\x12\x17.PsbtSignNonMwebRequest\x1a\r.PsbtResponse\x121\n" +
+	"\vPsbtCombine\x12\x13.PsbtCombineRequest\x1a\r.PsbtResponse\x128\n" +
+	"\vPsbtAnalyze\x12\x13.PsbtAnalyzeRequest\x1a\x14.PsbtAnalyzeResponse\x123\n" +
+	"\fPsbtFinalize\x12\x14.PsbtFinalizeRequest\x1a\r.PsbtResponse\x123\n" +
 	"\vPsbtExtract\x12\x13.PsbtExtractRequest\x1a\x0f.CreateResponse\x12*\n" +
 	"\x0eLedgerExchange\x12\v.LedgerApdu\x1a\v.LedgerApdu\x122\n" +
-	"\tBroadcast\x12\x11.BroadcastRequest\x1a\x12.BroadcastResponse\x12/\n" +
-	"\bCoinswap\x12\x10.CoinswapRequest\x1a\x11.CoinswapResponseB Z\x1egithub.com/ltcmweb/mwebd/protob\x06proto3"
+	"\tBroadcast\x12\x11.BroadcastRequest\x1a\x12.BroadcastResponse\x12;\n" +
+	"\fPegoutStatus\x12\x14.PegoutStatusRequest\x1a\x15.PegoutStatusResponse\x128\n" +
+	"\vPeginStatus\x12\x13.PeginStatusRequest\x1a\x14.PeginStatusResponse\x12/\n" +
+	"\bCoinswap\x12\x10.CoinswapRequest\x1a\x11.CoinswapResponse\x12A\n" +
+	"\x0eCoinswapStatus\x12\x16.CoinswapStatusRequest\x1a\x17.CoinswapStatusResponse\x12;\n" +
+	"\fCoinswapList\x12\x14.CoinswapListRequest\x1a\x15.CoinswapListResponse\x12>\n" +
+	"\rCoinswapBatch\x12\x15.CoinswapBatchRequest\x1a\x16.CoinswapBatchResponse\x12D\n" +
+	"\x0fCoinswapAutoMix\x12\x17.CoinswapAutoMixRequest\x1a\x18.CoinswapAutoMixResponse\x12)\n" +
+	"\x06Unlock\x12\x0e.UnlockRequest\x1a\x0f.UnlockResponse\x122\n" +
+	"\tRotateKey\x12\x11.RotateKeyRequest\x1a\x12.RotateKeyResponseB Z\x1egithub.com/ltcmweb/mwebd/protob\x06proto3"
 
 var (
 	file_mwebd_proto_rawDescOnce sync.Once
@@ -1653,74 +5332,175 @@ func file_mwebd_proto_rawDescGZIP() []byte {
 	return file_mwebd_proto_rawDescData
 }
 
-var file_mwebd_proto_msgTypes = make([]protoimpl.MessageInfo, 26)
+var file_mwebd_proto_enumTypes = make([]protoimpl.EnumInfo, 4)
+var file_mwebd_proto_msgTypes = make([]protoimpl.MessageInfo, 68)
 var file_mwebd_proto_goTypes = []any{
-	(*StatusRequest)(nil),             // 0: StatusRequest
-	(*StatusResponse)(nil),            // 1: StatusResponse
-	(*UtxosRequest)(nil),              // 2: UtxosRequest
-	(*Utxo)(nil),                      // 3: Utxo
-	(*AddressRequest)(nil),            // 4: AddressRequest
-	(*AddressResponse)(nil),           // 5: AddressResponse
-	(*LedgerApdu)(nil),                // 6: LedgerApdu
-	(*SpentRequest)(nil),              // 7: SpentRequest
-	(*SpentResponse)(nil),             // 8: SpentResponse
-	(*CreateRequest)(nil),             // 9: CreateRequest
-	(*CreateResponse)(nil),            // 10: CreateResponse
-	(*PsbtCreateRequest)(nil),         // 11: PsbtCreateRequest
-	(*TxOut)(nil),                     // 12: TxOut
-	(*PsbtResponse)(nil),              // 13: PsbtResponse
-	(*PsbtAddInputRequest)(nil),       // 14: PsbtAddInputRequest
-	(*PsbtAddRecipientRequest)(nil),   // 15: PsbtAddRecipientRequest
-	(*PsbtGetRecipientsRequest)(nil),  // 16: PsbtGetRecipientsRequest
-	(*PsbtGetRecipientsResponse)(nil), // 17: PsbtGetRecipientsResponse
-	(*PsbtRecipient)(nil),             // 18: PsbtRecipient
-	(*PsbtSignRequest)(nil),           // 19: PsbtSignRequest
-	(*PsbtSignNonMwebRequest)(nil),    // 20: PsbtSignNonMwebRequest
-	(*PsbtExtractRequest)(nil),        // 21: PsbtExtractRequest
-	(*BroadcastRequest)(nil),          // 22: BroadcastRequest
-	(*BroadcastResponse)(nil),         // 23: BroadcastResponse
-	(*CoinswapRequest)(nil),           // 24: CoinswapRequest
-	(*CoinswapResponse)(nil),          // 25: CoinswapResponse
+	(AddressType)(0),                   // 0: AddressType
+	(PeginPolicy)(0),                   // 1: PeginPolicy
+	(PeginState)(0),                    // 2: PeginState
+	(CoinswapState)(0),                 // 3: CoinswapState
+	(*StatusRequest)(nil),              // 4: StatusRequest
+	(*StatusResponse)(nil),             // 5: StatusResponse
+	(*UtxosRequest)(nil),               // 6: UtxosRequest
+	(*Utxo)(nil),                       // 7: Utxo
+	(*AddressRequest)(nil),             // 8: AddressRequest
+	(*AddressResponse)(nil),            // 9: AddressResponse
+	(*KeychainRequest)(nil),            // 10: KeychainRequest
+	(*KeychainResponse)(nil),           // 11: KeychainResponse
+	(*ValidateAddressRequest)(nil),     // 12: ValidateAddressRequest
+	(*ValidateAddressResponse)(nil),    // 13: ValidateAddressResponse
+	(*LedgerApdu)(nil),                 // 14: LedgerApdu
+	(*SpentRequest)(nil),               // 15: SpentRequest
+	(*SpentResponse)(nil),              // 16: SpentResponse
+	(*CreateRequest)(nil),              // 17: CreateRequest
+	(*CreateResponse)(nil),             // 18: CreateResponse
+	(*Pegin)(nil),                      // 19: Pegin
+	(*EstimateFeeRequest)(nil),         // 20: EstimateFeeRequest
+	(*EstimateFeeResponse)(nil),        // 21: EstimateFeeResponse
+	(*PsbtCreateRequest)(nil),          // 22: PsbtCreateRequest
+	(*TxOut)(nil),                      // 23: TxOut
+	(*PsbtResponse)(nil),               // 24: PsbtResponse
+	(*PsbtAddInputRequest)(nil),        // 25: PsbtAddInputRequest
+	(*PsbtAddRecipientRequest)(nil),    // 26: PsbtAddRecipientRequest
+	(*PsbtRemoveInputRequest)(nil),     // 27: PsbtRemoveInputRequest
+	(*PsbtRemoveRecipientRequest)(nil), // 28: PsbtRemoveRecipientRequest
+	(*PsbtUpdateRecipientRequest)(nil), // 29: PsbtUpdateRecipientRequest
+	(*PsbtGetRecipientsRequest)(nil),   // 30: PsbtGetRecipientsRequest
+	(*PsbtGetRecipientsResponse)(nil),  // 31: PsbtGetRecipientsResponse
+	(*PsbtRecipient)(nil),              // 32: PsbtRecipient
+	(*PsbtDecodeRequest)(nil),          // 33: PsbtDecodeRequest
+	(*PsbtDecodeResponse)(nil),         // 34: PsbtDecodeResponse
+	(*PsbtDecodedInput)(nil),           // 35: PsbtDecodedInput
+	(*PsbtDecodedOutput)(nil),          // 36: PsbtDecodedOutput
+	(*PsbtDecodedKernel)(nil),          // 37: PsbtDecodedKernel
+	(*PsbtSignRequest)(nil),            // 38: PsbtSignRequest
+	(*PsbtSignNonMwebRequest)(nil),     // 39: PsbtSignNonMwebRequest
+	(*PsbtCombineRequest)(nil),         // 40: PsbtCombineRequest
+	(*PsbtAnalyzeRequest)(nil),         // 41: PsbtAnalyzeRequest
+	(*PsbtAnalyzeResponse)(nil),        // 42: PsbtAnalyzeResponse
+	(*PsbtInputAnalysis)(nil),          // 43: PsbtInputAnalysis
+	(*PsbtFinalizeRequest)(nil),        // 44: PsbtFinalizeRequest
+	(*PsbtExtractRequest)(nil),         // 45: PsbtExtractRequest
+	(*BroadcastRequest)(nil),           // 46: BroadcastRequest
+	(*BroadcastResponse)(nil),          // 47: BroadcastResponse
+	(*PegoutStatusRequest)(nil),        // 48: PegoutStatusRequest
+	(*PegoutStatusResponse)(nil),       // 49: PegoutStatusResponse
+	(*KernelPegoutStatus)(nil),         // 50: KernelPegoutStatus
+	(*Pegout)(nil),                     // 51: Pegout
+	(*PeginStatusRequest)(nil),         // 52: PeginStatusRequest
+	(*PeginStatusResponse)(nil),        // 53: PeginStatusResponse
+	(*PeginStatus)(nil),                // 54: PeginStatus
+	(*CoinswapRequest)(nil),            // 55: CoinswapRequest
+	(*CoinswapResponse)(nil),           // 56: CoinswapResponse
+	(*CoinswapBatchRequest)(nil),       // 57: CoinswapBatchRequest
+	(*CoinswapBatchInput)(nil),         // 58: CoinswapBatchInput
+	(*CoinswapBatchResponse)(nil),      // 59: CoinswapBatchResponse
+	(*CoinswapBatchResult)(nil),        // 60: CoinswapBatchResult
+	(*CoinswapAutoMixRequest)(nil),     // 61: CoinswapAutoMixRequest
+	(*CoinswapAutoMixResponse)(nil),    // 62: CoinswapAutoMixResponse
+	(*CoinswapScheduled)(nil),          // 63: CoinswapScheduled
+	(*CoinswapStatusRequest)(nil),      // 64: CoinswapStatusRequest
+	(*CoinswapListRequest)(nil),        // 65: CoinswapListRequest
+	(*CoinswapListResponse)(nil),       // 66: CoinswapListResponse
+	(*CoinswapStatusResponse)(nil),     // 67: CoinswapStatusResponse
+	(*UnlockRequest)(nil),              // 68: UnlockRequest
+	(*UnlockResponse)(nil),             // 69: UnlockResponse
+	(*RotateKeyRequest)(nil),           // 70: RotateKeyRequest
+	(*RotateKeyResponse)(nil),          // 71: RotateKeyResponse
 }
 var file_mwebd_proto_depIdxs = []int32{
-	12, // 0: PsbtCreateRequest.witness_utxo:type_name -> TxOut
-	18, // 1: PsbtAddRecipientRequest.recipient:type_name -> PsbtRecipient
-	18, // 2: PsbtGetRecipientsResponse.recipient:type_name -> PsbtRecipient
-	0,  // 3: Rpc.Status:input_type -> StatusRequest
-	2,  // 4: Rpc.Utxos:input_type -> UtxosRequest
-	4,  // 5: Rpc.Addresses:input_type -> AddressRequest
-	7,  // 6: Rpc.Spent:input_type -> SpentRequest
-	9,  // 7: Rpc.Create:input_type -> CreateRequest
-	11, // 8: Rpc.PsbtCreate:input_type -> PsbtCreateRequest
-	14, // 9: Rpc.PsbtAddInput:input_type -> PsbtAddInputRequest
-	15, // 10: Rpc.PsbtAddRecipient:input_type -> PsbtAddRecipientRequest
-	16, // 11: Rpc.PsbtGetRecipients:input_type -> PsbtGetRecipientsRequest
-	19, // 12: Rpc.PsbtSign:input_type -> PsbtSignRequest
-	20, // 13: Rpc.PsbtSignNonMweb:input_type -> PsbtSignNonMwebRequest
-	21, // 14: Rpc.PsbtExtract:input_type -> PsbtExtractRequest
-	6,  // 15: Rpc.LedgerExchange:input_type -> LedgerApdu
-	22, // 16: Rpc.Broadcast:input_type -> BroadcastRequest
-	24, // 17: Rpc.Coinswap:input_type -> CoinswapRequest
-	1,  // 18: Rpc.Status:output_type -> StatusResponse
-	3,  // 19: Rpc.Utxos:output_type -> Utxo
-	5,  // 20: Rpc.Addresses:output_type -> AddressResponse
-	8,  // 21: Rpc.Spent:output_type -> SpentResponse
-	10, // 22: Rpc.Create:output_type -> CreateResponse
-	13, // 23: Rpc.PsbtCreate:output_type -> PsbtResponse
-	13, // 24: Rpc.PsbtAddInput:output_type -> PsbtResponse
-	13, // 25: Rpc.PsbtAddRecipient:output_type -> PsbtResponse
-	17, // 26: Rpc.PsbtGetRecipients:output_type -> PsbtGetRecipientsResponse
-	13, // 27: Rpc.PsbtSign:output_type -> PsbtResponse
-	13, // 28: Rpc.PsbtSignNonMweb:output_type -> PsbtResponse
-	10, // 29: Rpc.PsbtExtract:output_type -> CreateResponse
-	6,  // 30: Rpc.LedgerExchange:output_type -> LedgerApdu
-	23, // 31: Rpc.Broadcast:output_type -> BroadcastResponse
-	25, // 32: Rpc.Coinswap:output_type -> CoinswapResponse
-	18, // [18:33] is the sub-list for method output_type
-	3,  // [3:18] is the sub-list for method input_type
-	3,  // [3:3] is the sub-list for extension type_name
-	3,  // [3:3] is the sub-list for extension extendee
-	0,  // [0:3] is the sub-list for field type_name
+	0,  // 0: ValidateAddressResponse.type:type_name -> AddressType
+	1,  // 1: CreateRequest.pegin_policy:type_name -> PeginPolicy
+	19, // 2: CreateResponse.pegin:type_name -> Pegin
+	23, // 3: PsbtCreateRequest.witness_utxo:type_name -> TxOut
+	32, // 4: PsbtAddRecipientRequest.recipient:type_name -> PsbtRecipient
+	32, // 5: PsbtUpdateRecipientRequest.recipient:type_name -> PsbtRecipient
+	32, // 6: PsbtGetRecipientsResponse.recipient:type_name -> PsbtRecipient
+	35, // 7: PsbtDecodeResponse.input:type_name -> PsbtDecodedInput
+	36, // 8: PsbtDecodeResponse.output:type_name -> PsbtDecodedOutput
+	37, // 9: PsbtDecodeResponse.kernel:type_name -> PsbtDecodedKernel
+	32, // 10: PsbtDecodedKernel.pegout:type_name -> PsbtRecipient
+	43, // 11: PsbtAnalyzeResponse.input:type_name -> PsbtInputAnalysis
+	50, // 12: PegoutStatusResponse.kernel:type_name -> KernelPegoutStatus
+	51, // 13: KernelPegoutStatus.pegout:type_name -> Pegout
+	54, // 14: PeginStatusResponse.pegin:type_name -> PeginStatus
+	2,  // 15: PeginStatus.state:type_name -> PeginState
+	58, // 16: CoinswapBatchRequest.inputs:type_name -> CoinswapBatchInput
+	60, // 17: CoinswapBatchResponse.results:type_name -> CoinswapBatchResult
+	63, // 18: CoinswapAutoMixResponse.scheduled:type_name -> CoinswapScheduled
+	67, // 19: CoinswapListResponse.coinswap:type_name -> CoinswapStatusResponse
+	3,  // 20: CoinswapStatusResponse.state:type_name -> CoinswapState
+	4,  // 21: Rpc.Status:input_type -> StatusRequest
+	6,  // 22: Rpc.Utxos:input_type -> UtxosRequest
+	8,  // 23: Rpc.Addresses:input_type -> AddressRequest
+	10, // 24: Rpc.Keychain:input_type -> KeychainRequest
+	12, // 25: Rpc.ValidateAddress:input_type -> ValidateAddressRequest
+	15, // 26: Rpc.Spent:input_type -> SpentRequest
+	17, // 27: Rpc.Create:input_type -> CreateRequest
+	20, // 28: Rpc.EstimateFee:input_type -> EstimateFeeRequest
+	22, // 29: Rpc.PsbtCreate:input_type -> PsbtCreateRequest
+	25, // 30: Rpc.PsbtAddInput:input_type -> PsbtAddInputRequest
+	26, // 31: Rpc.PsbtAddRecipient:input_type -> PsbtAddRecipientRequest
+	27, // 32: Rpc.PsbtRemoveInput:input_type -> PsbtRemoveInputRequest
+	28, // 33: Rpc.PsbtRemoveRecipient:input_type -> PsbtRemoveRecipientRequest
+	29, // 34: Rpc.PsbtUpdateRecipient:input_type -> PsbtUpdateRecipientRequest
+	30, // 35: Rpc.PsbtGetRecipients:input_type -> PsbtGetRecipientsRequest
+	33, // 36: Rpc.PsbtDecode:input_type -> PsbtDecodeRequest
+	38, // 37: Rpc.PsbtSign:input_type -> PsbtSignRequest
+	39, // 38: Rpc.PsbtSignNonMweb:input_type -> PsbtSignNonMwebRequest
+	40, // 39: Rpc.PsbtCombine:input_type -> PsbtCombineRequest
+	41, // 40: Rpc.PsbtAnalyze:input_type -> PsbtAnalyzeRequest
+	44, // 41: Rpc.PsbtFinalize:input_type -> PsbtFinalizeRequest
+	45, // 42: Rpc.PsbtExtract:input_type -> PsbtExtractRequest
+	14, // 43: Rpc.LedgerExchange:input_type -> LedgerApdu
+	46, // 44: Rpc.Broadcast:input_type -> BroadcastRequest
+	48, // 45: Rpc.PegoutStatus:input_type -> PegoutStatusRequest
+	52, // 46: Rpc.PeginStatus:input_type -> PeginStatusRequest
+	55, // 47: Rpc.Coinswap:input_type -> CoinswapRequest
+	64, // 48: Rpc.CoinswapStatus:input_type -> CoinswapStatusRequest
+	65, // 49: Rpc.CoinswapList:input_type -> CoinswapListRequest
+	57, // 50: Rpc.CoinswapBatch:input_type -> CoinswapBatchRequest
+	61, // 51: Rpc.CoinswapAutoMix:input_type -> CoinswapAutoMixRequest
+	68, // 52: Rpc.Unlock:input_type -> UnlockRequest
+	70, // 53: Rpc.RotateKey:input_type -> RotateKeyRequest
+	5,  // 54: Rpc.Status:output_type -> StatusResponse
+	7,  // 55: Rpc.Utxos:output_type -> Utxo
+	9,  // 56: Rpc.Addresses:output_type -> AddressResponse
+	11, // 57: Rpc.Keychain:output_type -> KeychainResponse
+	13, // 58: Rpc.ValidateAddress:output_type -> ValidateAddressResponse
+	16, // 59: Rpc.Spent:output_type -> SpentResponse
+	18, // 60: Rpc.Create:output_type -> CreateResponse
+	21, // 61: Rpc.EstimateFee:output_type -> EstimateFeeResponse
+	24, // 62: Rpc.PsbtCreate:output_type -> PsbtResponse
+	24, // 63: Rpc.PsbtAddInput:output_type -> PsbtResponse
+	24, // 64: Rpc.PsbtAddRecipient:output_type -> PsbtResponse
+	24, // 65: Rpc.PsbtRemoveInput:output_type -> PsbtResponse
+	24, // 66: Rpc.PsbtRemoveRecipient:output_type -> PsbtResponse
+	24, // 67: Rpc.PsbtUpdateRecipient:output_type -> PsbtResponse
+	31, // 68: Rpc.PsbtGetRecipients:output_type -> PsbtGetRecipientsResponse
+	34, // 69: Rpc.PsbtDecode:output_type -> PsbtDecodeResponse
+	24, // 70: Rpc.PsbtSign:output_type -> PsbtResponse
+	24, // 71: Rpc.PsbtSignNonMweb:output_type -> PsbtResponse
+	24, // 72: Rpc.PsbtCombine:output_type -> PsbtResponse
+	42, // 73: Rpc.PsbtAnalyze:output_type -> PsbtAnalyzeResponse
+	24, // 74: Rpc.PsbtFinalize:output_type -> PsbtResponse
+	18, // 75: Rpc.PsbtExtract:output_type -> CreateResponse
+	14, // 76: Rpc.LedgerExchange:output_type -> LedgerApdu
+	47, // 77: Rpc.Broadcast:output_type -> BroadcastResponse
+	49, // 78: Rpc.PegoutStatus:output_type -> PegoutStatusResponse
+	53, // 79: Rpc.PeginStatus:output_type -> PeginStatusResponse
+	56, // 80: Rpc.Coinswap:output_type -> CoinswapResponse
+	67, // 81: Rpc.CoinswapStatus:output_type -> CoinswapStatusResponse
+	66, // 82: Rpc.CoinswapList:output_type -> CoinswapListResponse
+	59, // 83: Rpc.CoinswapBatch:output_type -> CoinswapBatchResponse
+	62, // 84: Rpc.CoinswapAutoMix:output_type -> CoinswapAutoMixResponse
+	69, // 85: Rpc.Unlock:output_type -> UnlockResponse
+	71, // 86: Rpc.RotateKey:output_type -> RotateKeyResponse
+	54, // [54:87] is the sub-list for method output_type
+	21, // [21:54] is the sub-list for method input_type
+	21, // [21:21] is the sub-list for extension type_name
+	21, // [21:21] is the sub-list for extension extendee
+	0,  // [0:21] is the sub-list for field type_name
 }
 
 func init() { file_mwebd_proto_init() }
@@ -1728,18 +5508,20 @@ func file_mwebd_proto_init() {
 	if File_mwebd_proto != nil {
 		return
 	}
+	file_mwebd_proto_msgTypes[9].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_mwebd_proto_rawDesc), len(file_mwebd_proto_rawDesc)),
-			NumEnums:      0,
-			NumMessages:   26,
+			NumEnums:      4,
+			NumMessages:   68,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_mwebd_proto_goTypes,
 		DependencyIndexes: file_mwebd_proto_depIdxs,
+		EnumInfos:         file_mwebd_proto_enumTypes,
 		MessageInfos:      file_mwebd_proto_msgTypes,
 	}.Build()
 	File_mwebd_proto = out.File
@@ -19,21 +19,39 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	Rpc_Status_FullMethodName            = "/Rpc/Status"
-	Rpc_Utxos_FullMethodName             = "/Rpc/Utxos"
-	Rpc_Addresses_FullMethodName         = "/Rpc/Addresses"
-	Rpc_Spent_FullMethodName             = "/Rpc/Spent"
-	Rpc_Create_FullMethodName            = "/Rpc/Create"
-	Rpc_PsbtCreate_FullMethodName        = "/Rpc/PsbtCreate"
-	Rpc_PsbtAddInput_FullMethodName      = "/Rpc/PsbtAddInput"
-	Rpc_PsbtAddRecipient_FullMethodName  = "/Rpc/PsbtAddRecipient"
-	Rpc_PsbtGetRecipients_FullMethodName = "/Rpc/PsbtGetRecipients"
-	Rpc_PsbtSign_FullMethodName          = "/Rpc/PsbtSign"
-	Rpc_PsbtSignNonMweb_FullMethodName   = "/Rpc/PsbtSignNonMweb"
-	Rpc_PsbtExtract_FullMethodName       = "/Rpc/PsbtExtract"
-	Rpc_LedgerExchange_FullMethodName    = "/Rpc/LedgerExchange"
-	Rpc_Broadcast_FullMethodName         = "/Rpc/Broadcast"
-	Rpc_Coinswap_FullMethodName          = "/Rpc/Coinswap"
+	Rpc_Status_FullMethodName              = "/Rpc/Status"
+	Rpc_Utxos_FullMethodName               = "/Rpc/Utxos"
+	Rpc_Addresses_FullMethodName           = "/Rpc/Addresses"
+	Rpc_Keychain_FullMethodName            = "/Rpc/Keychain"
+	Rpc_ValidateAddress_FullMethodName     = "/Rpc/ValidateAddress"
+	Rpc_Spent_FullMethodName               = "/Rpc/Spent"
+	Rpc_Create_FullMethodName              = "/Rpc/Create"
+	Rpc_EstimateFee_FullMethodName         = "/Rpc/EstimateFee"
+	Rpc_PsbtCreate_FullMethodName          = "/Rpc/PsbtCreate"
+	Rpc_PsbtAddInput_FullMethodName        = "/Rpc/PsbtAddInput"
+	Rpc_PsbtAddRecipient_FullMethodName    = "/Rpc/PsbtAddRecipient"
+	Rpc_PsbtRemoveInput_FullMethodName     = "/Rpc/PsbtRemoveInput"
+	Rpc_PsbtRemoveRecipient_FullMethodName = "/Rpc/PsbtRemoveRecipient"
+	Rpc_PsbtUpdateRecipient_FullMethodName = "/Rpc/PsbtUpdateRecipient"
+	Rpc_PsbtGetRecipients_FullMethodName   = "/Rpc/PsbtGetRecipients"
+	Rpc_PsbtDecode_FullMethodName          = "/Rpc/PsbtDecode"
+	Rpc_PsbtSign_FullMethodName            = "/Rpc/PsbtSign"
+	Rpc_PsbtSignNonMweb_FullMethodName     = "/Rpc/PsbtSignNonMweb"
+	Rpc_PsbtCombine_FullMethodName         = "/Rpc/PsbtCombine"
+	Rpc_PsbtAnalyze_FullMethodName         = "/Rpc/PsbtAnalyze"
+	Rpc_PsbtFinalize_FullMethodName        = "/Rpc/PsbtFinalize"
+	Rpc_PsbtExtract_FullMethodName         = "/Rpc/PsbtExtract"
+	Rpc_LedgerExchange_FullMethodName      = "/Rpc/LedgerExchange"
+	Rpc_Broadcast_FullMethodName           = "/Rpc/Broadcast"
+	Rpc_PegoutStatus_FullMethodName        = "/Rpc/PegoutStatus"
+	Rpc_PeginStatus_FullMethodName         = "/Rpc/PeginStatus"
+	Rpc_Coinswap_FullMethodName            = "/Rpc/Coinswap"
+	Rpc_CoinswapStatus_FullMethodName      = "/Rpc/CoinswapStatus"
+	Rpc_CoinswapList_FullMethodName        = "/Rpc/CoinswapList"
+	Rpc_CoinswapBatch_FullMethodName       = "/Rpc/CoinswapBatch"
+	Rpc_CoinswapAutoMix_FullMethodName     = "/Rpc/CoinswapAutoMix"
+	Rpc_Unlock_FullMethodName              = "/Rpc/Unlock"
+	Rpc_RotateKey_FullMethodName           = "/Rpc/RotateKey"
 )
 
 // RpcClient is the client API for Rpc service.
@@ -49,6 +67,15 @@ type RpcClient interface {
 	Utxos(ctx context.Context, in *UtxosRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Utxo], error)
 	// Get a batch of MWEB addresses for an account.
 	Addresses(ctx context.Context, in *AddressRequest, opts ...grpc.CallOption) (*AddressResponse, error)
+	// Derive the scan and spend keys of an account from an xprv or
+	// a BIP39 mnemonic. The keys are derived at m/1000'/coin'/account'/0'
+	// and m/1000'/coin'/account'/1', where coin is 2 for mainnet and
+	// 1 for the test networks.
+	Keychain(ctx context.Context, in *KeychainRequest, opts ...grpc.CallOption) (*KeychainResponse, error)
+	// Decode and validate an address, such as one entered by a user
+	// before adding it as a recipient. If an account is given, also
+	// check whether an MWEB address belongs to it.
+	ValidateAddress(ctx context.Context, in *ValidateAddressRequest, opts ...grpc.CallOption) (*ValidateAddressResponse, error)
 	// Check whether MWEB outputs are in the unspent set or not.
 	// This is used to determine when outputs have been spent by
 	// either this or another wallet using the same seed, and to
@@ -57,27 +84,91 @@ type RpcClient interface {
 	Spent(ctx context.Context, in *SpentRequest, opts ...grpc.CallOption) (*SpentResponse, error)
 	// Create the MWEB portion of a transaction.
 	Create(ctx context.Context, in *CreateRequest, opts ...grpc.CallOption) (*CreateResponse, error)
+	// Estimate the fees added by the MWEB portion of a transaction,
+	// given either a Create template or a PSBT.
+	EstimateFee(ctx context.Context, in *EstimateFeeRequest, opts ...grpc.CallOption) (*EstimateFeeResponse, error)
 	// Create a PSBT from a raw transaction.
 	PsbtCreate(ctx context.Context, in *PsbtCreateRequest, opts ...grpc.CallOption) (*PsbtResponse, error)
 	// Add a MWEB input to a PSBT.
 	PsbtAddInput(ctx context.Context, in *PsbtAddInputRequest, opts ...grpc.CallOption) (*PsbtResponse, error)
 	// Add a recipient to a PSBT.
 	PsbtAddRecipient(ctx context.Context, in *PsbtAddRecipientRequest, opts ...grpc.CallOption) (*PsbtResponse, error)
+	// Remove an input from a PSBT.
+	PsbtRemoveInput(ctx context.Context, in *PsbtRemoveInputRequest, opts ...grpc.CallOption) (*PsbtResponse, error)
+	// Remove a recipient from a PSBT.
+	PsbtRemoveRecipient(ctx context.Context, in *PsbtRemoveRecipientRequest, opts ...grpc.CallOption) (*PsbtResponse, error)
+	// Change the address or value of a recipient of a PSBT.
+	PsbtUpdateRecipient(ctx context.Context, in *PsbtUpdateRecipientRequest, opts ...grpc.CallOption) (*PsbtResponse, error)
 	// Get the recipients of a PSBT.
 	PsbtGetRecipients(ctx context.Context, in *PsbtGetRecipientsRequest, opts ...grpc.CallOption) (*PsbtGetRecipientsResponse, error)
+	// Decode every field of a PSBT, including the MWEB fields, for
+	// inspection and debugging. This is similar to decodepsbt in
+	// Litecoin Core.
+	PsbtDecode(ctx context.Context, in *PsbtDecodeRequest, opts ...grpc.CallOption) (*PsbtDecodeResponse, error)
 	// Sign the MWEB portion of a PSBT.
 	PsbtSign(ctx context.Context, in *PsbtSignRequest, opts ...grpc.CallOption) (*PsbtResponse, error)
-	// Sign a non-MWEB input of a PSBT.
+	// Sign a non-MWEB input of a PSBT. P2PKH, P2WPKH, P2SH-P2WPKH,
+	// P2WSH and P2SH-P2WSH multisig and Taproot key spend inputs are
+	// supported. The input is finalized once fully signed.
 	PsbtSignNonMweb(ctx context.Context, in *PsbtSignNonMwebRequest, opts ...grpc.CallOption) (*PsbtResponse, error)
+	// Merge partially signed copies of the same PSBT from multiple
+	// signers. MWEB components must be signed by one signer at a
+	// time, as each signature updates the PSBT's MWEB offsets.
+	PsbtCombine(ctx context.Context, in *PsbtCombineRequest, opts ...grpc.CallOption) (*PsbtResponse, error)
+	// Report what is missing before a PSBT can be extracted.
+	PsbtAnalyze(ctx context.Context, in *PsbtAnalyzeRequest, opts ...grpc.CallOption) (*PsbtAnalyzeResponse, error)
+	// Finalize the signed non-MWEB inputs of a PSBT.
+	PsbtFinalize(ctx context.Context, in *PsbtFinalizeRequest, opts ...grpc.CallOption) (*PsbtResponse, error)
 	// Extract the raw transaction from a signed PSBT.
 	PsbtExtract(ctx context.Context, in *PsbtExtractRequest, opts ...grpc.CallOption) (*CreateResponse, error)
 	// Process APDUs from the Ledger.
 	LedgerExchange(ctx context.Context, in *LedgerApdu, opts ...grpc.CallOption) (*LedgerApdu, error)
 	// Broadcast a transaction to the network. This is provided as
 	// existing broadcast services may not support MWEB transactions.
+	// Transactions with MWEB kernels that are locked until a height
+	// beyond the next block are rejected.
 	Broadcast(ctx context.Context, in *BroadcastRequest, opts ...grpc.CallOption) (*BroadcastResponse, error)
-	// Submit a coinswap request.
+	// Get the status of the peg-outs in a transaction broadcast
+	// through this daemon. Peg-outs are paid by the HogEx transaction
+	// of the block that the kernel is mined in, so this looks for the
+	// peg-out outputs in the HogEx of each block since the broadcast.
+	PegoutStatus(ctx context.Context, in *PegoutStatusRequest, opts ...grpc.CallOption) (*PegoutStatusResponse, error)
+	// Get the status of the peg-ins broadcast through this daemon.
+	// Peg-ins are tracked from when Broadcast sends the transaction
+	// until the MWEB outputs funded by them are credited to the utxo
+	// set.
+	PeginStatus(ctx context.Context, in *PeginStatusRequest, opts ...grpc.CallOption) (*PeginStatusResponse, error)
+	// Submit a coinswap request. The swap is tracked until its output
+	// is seen. If the entry node refuses it, or it doesn't complete
+	// by the day's swap round, it's resubmitted through a different
+	// route at a random time before the next round.
 	Coinswap(ctx context.Context, in *CoinswapRequest, opts ...grpc.CallOption) (*CoinswapResponse, error)
+	// Get the status of a coinswap submitted through this daemon.
+	CoinswapStatus(ctx context.Context, in *CoinswapStatusRequest, opts ...grpc.CallOption) (*CoinswapStatusResponse, error)
+	// List the coinswaps submitted through this daemon.
+	CoinswapList(ctx context.Context, in *CoinswapListRequest, opts ...grpc.CallOption) (*CoinswapListResponse, error)
+	// Schedule coinswaps for several utxos of an account. Each swap
+	// is submitted at its own random time before the next swap round,
+	// so that the entry node doesn't receive them together. A failure
+	// for one doesn't prevent the others from being scheduled.
+	CoinswapBatch(ctx context.Context, in *CoinswapBatchRequest, opts ...grpc.CallOption) (*CoinswapBatchResponse, error)
+	// Register an account for auto-mixing, or update or remove its
+	// registration. Each eligible utxo of the account is swapped at
+	// a random time within the given number of swap rounds. The
+	// registration, including the spend secret, is kept in memory
+	// only, so it has to be repeated if the daemon restarts. It
+	// expires after its rounds, after which new utxos aren't
+	// scheduled and the account is dropped once its scheduled swaps
+	// are submitted.
+	CoinswapAutoMix(ctx context.Context, in *CoinswapAutoMixRequest, opts ...grpc.CallOption) (*CoinswapAutoMixResponse, error)
+	// Unlock an encrypted database with its passphrase. Until then,
+	// the chain isn't synced and calls that use the daemon's own
+	// data fail. The first unlock sets the passphrase, and encrypts
+	// any data that was stored before encryption was enabled.
+	Unlock(ctx context.Context, in *UnlockRequest, opts ...grpc.CallOption) (*UnlockResponse, error)
+	// Change the passphrase of an unlocked database, and re-encrypt
+	// its data under a new key.
+	RotateKey(ctx context.Context, in *RotateKeyRequest, opts ...grpc.CallOption) (*RotateKeyResponse, error)
 }
 
 type rpcClient struct {
@@ -127,6 +218,26 @@ func (c *rpcClient) Addresses(ctx context.Context, in *AddressRequest, opts ...g
 	return out, nil
 }
 
+func (c *rpcClient) Keychain(ctx context.Context, in *KeychainRequest, opts ...grpc.CallOption) (*KeychainResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(KeychainResponse)
+	err := c.cc.Invoke(ctx, Rpc_Keychain_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rpcClient) ValidateAddress(ctx context.Context, in *ValidateAddressRequest, opts ...grpc.CallOption) (*ValidateAddressResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ValidateAddressResponse)
+	err := c.cc.Invoke(ctx, Rpc_ValidateAddress_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *rpcClient) Spent(ctx context.Context, in *SpentRequest, opts ...grpc.CallOption) (*SpentResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(SpentResponse)
@@ -147,6 +258,16 @@ func (c *rpcClient) Create(ctx context.Context, in *CreateRequest, opts ...grpc.
 	return out, nil
 }
 
+func (c *rpcClient) EstimateFee(ctx context.Context, in *EstimateFeeRequest, opts ...grpc.CallOption) (*EstimateFeeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(EstimateFeeResponse)
+	err := c.cc.Invoke(ctx, Rpc_EstimateFee_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *rpcClient) PsbtCreate(ctx context.Context, in *PsbtCreateRequest, opts ...grpc.CallOption) (*PsbtResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(PsbtResponse)
@@ -177,6 +298,36 @@ func (c *rpcClient) PsbtAddRecipient(ctx context.Context, in *PsbtAddRecipientRe
 	return out, nil
 }
 
+func (c *rpcClient) PsbtRemoveInput(ctx context.Context, in *PsbtRemoveInputRequest, opts ...grpc.CallOption) (*PsbtResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PsbtResponse)
+	err := c.cc.Invoke(ctx, Rpc_PsbtRemoveInput_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rpcClient) PsbtRemoveRecipient(ctx context.Context, in *PsbtRemoveRecipientRequest, opts ...grpc.CallOption) (*PsbtResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PsbtResponse)
+	err := c.cc.Invoke(ctx, Rpc_PsbtRemoveRecipient_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rpcClient) PsbtUpdateRecipient(ctx context.Context, in *PsbtUpdateRecipientRequest, opts ...grpc.CallOption) (*PsbtResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PsbtResponse)
+	err := c.cc.Invoke(ctx, Rpc_PsbtUpdateRecipient_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *rpcClient) PsbtGetRecipients(ctx context.Context, in *PsbtGetRecipientsRequest, opts ...grpc.CallOption) (*PsbtGetRecipientsResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(PsbtGetRecipientsResponse)
@@ -187,6 +338,16 @@ func (c *rpcClient) PsbtGetRecipients(ctx context.Context, in *PsbtGetRecipients
 	return out, nil
 }
 
+func (c *rpcClient) PsbtDecode(ctx context.Context, in *PsbtDecodeRequest, opts ...grpc.CallOption) (*PsbtDecodeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PsbtDecodeResponse)
+	err := c.cc.Invoke(ctx, Rpc_PsbtDecode_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *rpcClient) PsbtSign(ctx context.Context, in *PsbtSignRequest, opts ...grpc.CallOption) (*PsbtResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(PsbtResponse)
@@ -207,6 +368,36 @@ func (c *rpcClient) PsbtSignNonMweb(ctx context.Context, in *PsbtSignNonMwebRequ
 	return out, nil
 }
 
+func (c *rpcClient) PsbtCombine(ctx context.Context, in *PsbtCombineRequest, opts ...grpc.CallOption) (*PsbtResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PsbtResponse)
+	err := c.cc.Invoke(ctx, Rpc_PsbtCombine_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rpcClient) PsbtAnalyze(ctx context.Context, in *PsbtAnalyzeRequest, opts ...grpc.CallOption) (*PsbtAnalyzeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PsbtAnalyzeResponse)
+	err := c.cc.Invoke(ctx, Rpc_PsbtAnalyze_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rpcClient) PsbtFinalize(ctx context.Context, in *PsbtFinalizeRequest, opts ...grpc.CallOption) (*PsbtResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PsbtResponse)
+	err := c.cc.Invoke(ctx, Rpc_PsbtFinalize_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *rpcClient) PsbtExtract(ctx context.Context, in *PsbtExtractRequest, opts ...grpc.CallOption) (*CreateResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(CreateResponse)
@@ -237,6 +428,26 @@ func (c *rpcClient) Broadcast(ctx context.Context, in *BroadcastRequest, opts ..
 	return out, nil
 }
 
+func (c *rpcClient) PegoutStatus(ctx context.Context, in *PegoutStatusRequest, opts ...grpc.CallOption) (*PegoutStatusResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PegoutStatusResponse)
+	err := c.cc.Invoke(ctx, Rpc_PegoutStatus_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rpcClient) PeginStatus(ctx context.Context, in *PeginStatusRequest, opts ...grpc.CallOption) (*PeginStatusResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PeginStatusResponse)
+	err := c.cc.Invoke(ctx, Rpc_PeginStatus_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *rpcClient) Coinswap(ctx context.Context, in *CoinswapRequest, opts ...grpc.CallOption) (*CoinswapResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(CoinswapResponse)
@@ -247,6 +458,66 @@ func (c *rpcClient) Coinswap(ctx context.Context, in *CoinswapRequest, opts ...g
 	return out, nil
 }
 
+func (c *rpcClient) CoinswapStatus(ctx context.Context, in *CoinswapStatusRequest, opts ...grpc.CallOption) (*CoinswapStatusResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CoinswapStatusResponse)
+	err := c.cc.Invoke(ctx, Rpc_CoinswapStatus_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rpcClient) CoinswapList(ctx context.Context, in *CoinswapListRequest, opts ...grpc.CallOption) (*CoinswapListResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CoinswapListResponse)
+	err := c.cc.Invoke(ctx, Rpc_CoinswapList_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rpcClient) CoinswapBatch(ctx context.Context, in *CoinswapBatchRequest, opts ...grpc.CallOption) (*CoinswapBatchResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CoinswapBatchResponse)
+	err := c.cc.Invoke(ctx, Rpc_CoinswapBatch_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rpcClient) CoinswapAutoMix(ctx context.Context, in *CoinswapAutoMixRequest, opts ...grpc.CallOption) (*CoinswapAutoMixResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CoinswapAutoMixResponse)
+	err := c.cc.Invoke(ctx, Rpc_CoinswapAutoMix_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rpcClient) Unlock(ctx context.Context, in *UnlockRequest, opts ...grpc.CallOption) (*UnlockResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UnlockResponse)
+	err := c.cc.Invoke(ctx, Rpc_Unlock_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rpcClient) RotateKey(ctx context.Context, in *RotateKeyRequest, opts ...grpc.CallOption) (*RotateKeyResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RotateKeyResponse)
+	err := c.cc.Invoke(ctx, Rpc_RotateKey_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // RpcServer is the server API for Rpc service.
 // All implementations must embed UnimplementedRpcServer
 // for forward compatibility.
@@ -260,6 +531,15 @@ type RpcServer interface {
 	Utxos(*UtxosRequest, grpc.ServerStreamingServer[Utxo]) error
 	// Get a batch of MWEB addresses for an account.
 	Addresses(context.Context, *AddressRequest) (*AddressResponse, error)
+	// Derive the scan and spend keys of an account from an xprv or
+	// a BIP39 mnemonic. The keys are derived at m/1000'/coin'/account'/0'
+	// and m/1000'/coin'/account'/1', where coin is 2 for mainnet and
+	// 1 for the test networks.
+	Keychain(context.Context, *KeychainRequest) (*KeychainResponse, error)
+	// Decode and validate an address, such as one entered by a user
+	// before adding it as a recipient. If an account is given, also
+	// check whether an MWEB address belongs to it.
+	ValidateAddress(context.Context, *ValidateAddressRequest) (*ValidateAddressResponse, error)
 	// Check whether MWEB outputs are in the unspent set or not.
 	// This is used to determine when outputs have been spent by
 	// either this or another wallet using the same seed, and to
@@ -268,27 +548,91 @@ type RpcServer interface {
 	Spent(context.Context, *SpentRequest) (*SpentResponse, error)
 	// Create the MWEB portion of a transaction.
 	Create(context.Context, *CreateRequest) (*CreateResponse, error)
+	// Estimate the fees added by the MWEB portion of a transaction,
+	// given either a Create template or a PSBT.
+	EstimateFee(context.Context, *EstimateFeeRequest) (*EstimateFeeResponse, error)
 	// Create a PSBT from a raw transaction.
 	PsbtCreate(context.Context, *PsbtCreateRequest) (*PsbtResponse, error)
 	// Add a MWEB input to a PSBT.
 	PsbtAddInput(context.Context, *PsbtAddInputRequest) (*PsbtResponse, error)
 	// Add a recipient to a PSBT.
 	PsbtAddRecipient(context.Context, *PsbtAddRecipientRequest) (*PsbtResponse, error)
+	// Remove an input from a PSBT.
+	PsbtRemoveInput(context.Context, *PsbtRemoveInputRequest) (*PsbtResponse, error)
+	// Remove a recipient from a PSBT.
+	PsbtRemoveRecipient(context.Context, *PsbtRemoveRecipientRequest) (*PsbtResponse, error)
+	// Change the address or value of a recipient of a PSBT.
+	PsbtUpdateRecipient(context.Context, *PsbtUpdateRecipientRequest) (*PsbtResponse, error)
 	// Get the recipients of a PSBT.
 	PsbtGetRecipients(context.Context, *PsbtGetRecipientsRequest) (*PsbtGetRecipientsResponse, error)
+	// Decode every field of a PSBT, including the MWEB fields, for
+	// inspection and debugging. This is similar to decodepsbt in
+	// Litecoin Core.
+	PsbtDecode(context.Context, *PsbtDecodeRequest) (*PsbtDecodeResponse, error)
 	// Sign the MWEB portion of a PSBT.
 	PsbtSign(context.Context, *PsbtSignRequest) (*PsbtResponse, error)
-	// Sign a non-MWEB input of a PSBT.
+	// Sign a non-MWEB input of a PSBT. P2PKH, P2WPKH, P2SH-P2WPKH,
+	// P2WSH and P2SH-P2WSH multisig and Taproot key spend inputs are
+	// supported. The input is finalized once fully signed.
 	PsbtSignNonMweb(context.Context, *PsbtSignNonMwebRequest) (*PsbtResponse, error)
+	// Merge partially signed copies of the same PSBT from multiple
+	// signers. MWEB components must be signed by one signer at a
+	// time, as each signature updates the PSBT's MWEB offsets.
+	PsbtCombine(context.Context, *PsbtCombineRequest) (*PsbtResponse, error)
+	// Report what is missing before a PSBT can be extracted.
+	PsbtAnalyze(context.Context, *PsbtAnalyzeRequest) (*PsbtAnalyzeResponse, error)
+	// Finalize the signed non-MWEB inputs of a PSBT.
+	PsbtFinalize(context.Context, *PsbtFinalizeRequest) (*PsbtResponse, error)
 	// Extract the raw transaction from a signed PSBT.
 	PsbtExtract(context.Context, *PsbtExtractRequest) (*CreateResponse, error)
 	// Process APDUs from the Ledger.
 	LedgerExchange(context.Context, *LedgerApdu) (*LedgerApdu, error)
 	// Broadcast a transaction to the network. This is provided as
 	// existing broadcast services may not support MWEB transactions.
+	// Transactions with MWEB kernels that are locked until a height
+	// beyond the next block are rejected.
 	Broadcast(context.Context, *BroadcastRequest) (*BroadcastResponse, error)
-	// Submit a coinswap request.
+	// Get the status of the peg-outs in a transaction broadcast
+	// through this daemon. Peg-outs are paid by the HogEx transaction
+	// of the block that the kernel is mined in, so this looks for the
+	// peg-out outputs in the HogEx of each block since the broadcast.
+	PegoutStatus(context.Context, *PegoutStatusRequest) (*PegoutStatusResponse, error)
+	// Get the status of the peg-ins broadcast through this daemon.
+	// Peg-ins are tracked from when Broadcast sends the transaction
+	// until the MWEB outputs funded by them are credited to the utxo
+	// set.
+	PeginStatus(context.Context, *PeginStatusRequest) (*PeginStatusResponse, error)
+	// Submit a coinswap request. The swap is tracked until its output
+	// is seen. If the entry node refuses it, or it doesn't complete
+	// by the day's swap round, it's resubmitted through a different
+	// route at a random time before the next round.
 	Coinswap(context.Context, *CoinswapRequest) (*CoinswapResponse, error)
+	// Get the status of a coinswap submitted through this daemon.
+	CoinswapStatus(context.Context, *CoinswapStatusRequest) (*CoinswapStatusResponse, error)
+	// List the coinswaps submitted through this daemon.
+	CoinswapList(context.Context, *CoinswapListRequest) (*CoinswapListResponse, error)
+	// Schedule coinswaps for several utxos of an account. Each swap
+	// is submitted at its own random time before the next swap round,
+	// so that the entry node doesn't receive them together. A failure
+	// for one doesn't prevent the others from being scheduled.
+	CoinswapBatch(context.Context, *CoinswapBatchRequest) (*CoinswapBatchResponse, error)
+	// Register an account for auto-mixing, or update or remove its
+	// registration. Each eligible utxo of the account is swapped at
+	// a random time within the given number of swap rounds. The
+	// registration, including the spend secret, is kept in memory
+	// only, so it has to be repeated if the daemon restarts. It
+	// expires after its rounds, after which new utxos aren't
+	// scheduled and the account is dropped once its scheduled swaps
+	// are submitted.
+	CoinswapAutoMix(context.Context, *CoinswapAutoMixRequest) (*CoinswapAutoMixResponse, error)
+	// Unlock an encrypted database with its passphrase. Until then,
+	// the chain isn't synced and calls that use the daemon's own
+	// data fail. The first unlock sets the passphrase, and encrypts
+	// any data that was stored before encryption was enabled.
+	Unlock(context.Context, *UnlockRequest) (*UnlockResponse, error)
+	// Change the passphrase of an unlocked database, and re-encrypt
+	// its data under a new key.
+	RotateKey(context.Context, *RotateKeyRequest) (*RotateKeyResponse, error)
 	mustEmbedUnimplementedRpcServer()
 }
 
@@ -308,12 +652,21 @@ func (UnimplementedRpcServer) Utxos(*UtxosRequest, grpc.ServerStreamingServer[Ut
 func (UnimplementedRpcServer) Addresses(context.Context, *AddressRequest) (*AddressResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Addresses not implemented")
 }
+func (UnimplementedRpcServer) Keychain(context.Context, *KeychainRequest) (*KeychainResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Keychain not implemented")
+}
+func (UnimplementedRpcServer) ValidateAddress(context.Context, *ValidateAddressRequest) (*ValidateAddressResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ValidateAddress not implemented")
+}
 func (UnimplementedRpcServer) Spent(context.Context, *SpentRequest) (*SpentResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Spent not implemented")
 }
 func (UnimplementedRpcServer) Create(context.Context, *CreateRequest) (*CreateResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Create not implemented")
 }
+func (UnimplementedRpcServer) EstimateFee(context.Context, *EstimateFeeRequest) (*EstimateFeeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method EstimateFee not implemented")
+}
 func (UnimplementedRpcServer) PsbtCreate(context.Context, *PsbtCreateRequest) (*PsbtResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method PsbtCreate not implemented")
 }
@@ -323,15 +676,36 @@ func (UnimplementedRpcServer) PsbtAddInput(context.Context, *PsbtAddInputRequest
 func (UnimplementedRpcServer) PsbtAddRecipient(context.Context, *PsbtAddRecipientRequest) (*PsbtResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method PsbtAddRecipient not implemented")
 }
+func (UnimplementedRpcServer) PsbtRemoveInput(context.Context, *PsbtRemoveInputRequest) (*PsbtResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PsbtRemoveInput not implemented")
+}
+func (UnimplementedRpcServer) PsbtRemoveRecipient(context.Context, *PsbtRemoveRecipientRequest) (*PsbtResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PsbtRemoveRecipient not implemented")
+}
+func (UnimplementedRpcServer) PsbtUpdateRecipient(context.Context, *PsbtUpdateRecipientRequest) (*PsbtResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PsbtUpdateRecipient not implemented")
+}
 func (UnimplementedRpcServer) PsbtGetRecipients(context.Context, *PsbtGetRecipientsRequest) (*PsbtGetRecipientsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method PsbtGetRecipients not implemented")
 }
+func (UnimplementedRpcServer) PsbtDecode(context.Context, *PsbtDecodeRequest) (*PsbtDecodeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PsbtDecode not implemented")
+}
 func (UnimplementedRpcServer) PsbtSign(context.Context, *PsbtSignRequest) (*PsbtResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method PsbtSign not implemented")
 }
 func (UnimplementedRpcServer) PsbtSignNonMweb(context.Context, *PsbtSignNonMwebRequest) (*PsbtResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method PsbtSignNonMweb not implemented")
 }
+func (UnimplementedRpcServer) PsbtCombine(context.Context, *PsbtCombineRequest) (*PsbtResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PsbtCombine not implemented")
+}
+func (UnimplementedRpcServer) PsbtAnalyze(context.Context, *PsbtAnalyzeRequest) (*PsbtAnalyzeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PsbtAnalyze not implemented")
+}
+func (UnimplementedRpcServer) PsbtFinalize(context.Context, *PsbtFinalizeRequest) (*PsbtResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PsbtFinalize not implemented")
+}
 func (UnimplementedRpcServer) PsbtExtract(context.Context, *PsbtExtractRequest) (*CreateResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method PsbtExtract not implemented")
 }
@@ -341,9 +715,33 @@ func (UnimplementedRpcServer) LedgerExchange(context.Context, *LedgerApdu) (*Led
 func (UnimplementedRpcServer) Broadcast(context.Context, *BroadcastRequest) (*BroadcastResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Broadcast not implemented")
 }
+func (UnimplementedRpcServer) PegoutStatus(context.Context, *PegoutStatusRequest) (*PegoutStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PegoutStatus not implemented")
+}
+func (UnimplementedRpcServer) PeginStatus(context.Context, *PeginStatusRequest) (*PeginStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PeginStatus not implemented")
+}
 func (UnimplementedRpcServer) Coinswap(context.Context, *CoinswapRequest) (*CoinswapResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Coinswap not implemented")
 }
+func (UnimplementedRpcServer) CoinswapStatus(context.Context, *CoinswapStatusRequest) (*CoinswapStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CoinswapStatus not implemented")
+}
+func (UnimplementedRpcServer) CoinswapList(context.Context, *CoinswapListRequest) (*CoinswapListResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CoinswapList not implemented")
+}
+func (UnimplementedRpcServer) CoinswapBatch(context.Context, *CoinswapBatchRequest) (*CoinswapBatchResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CoinswapBatch not implemented")
+}
+func (UnimplementedRpcServer) CoinswapAutoMix(context.Context, *CoinswapAutoMixRequest) (*CoinswapAutoMixResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CoinswapAutoMix not implemented")
+}
+func (UnimplementedRpcServer) Unlock(context.Context, *UnlockRequest) (*UnlockResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Unlock not implemented")
+}
+func (UnimplementedRpcServer) RotateKey(context.Context, *RotateKeyRequest) (*RotateKeyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RotateKey not implemented")
+}
 func (UnimplementedRpcServer) mustEmbedUnimplementedRpcServer() {}
 func (UnimplementedRpcServer) testEmbeddedByValue()             {}
 
@@ -412,6 +810,42 @@ func _Rpc_Addresses_Handler(srv interface{}, ctx context.Context, dec func(inter
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Rpc_Keychain_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(KeychainRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RpcServer).Keychain(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Rpc_Keychain_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RpcServer).Keychain(ctx, req.(*KeychainRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Rpc_ValidateAddress_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ValidateAddressRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RpcServer).ValidateAddress(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Rpc_ValidateAddress_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RpcServer).ValidateAddress(ctx, req.(*ValidateAddressRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Rpc_Spent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(SpentRequest)
 	if err := dec(in); err != nil {
@@ -448,6 +882,24 @@ func _Rpc_Create_Handler(srv interface{}, ctx context.Context, dec func(interfac
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Rpc_EstimateFee_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EstimateFeeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RpcServer).EstimateFee(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Rpc_EstimateFee_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RpcServer).EstimateFee(ctx, req.(*EstimateFeeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Rpc_PsbtCreate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(PsbtCreateRequest)
 	if err := dec(in); err != nil {
@@ -502,6 +954,60 @@ func _Rpc_PsbtAddRecipient_Handler(srv interface{}, ctx context.Context, dec fun
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Rpc_PsbtRemoveInput_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PsbtRemoveInputRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RpcServer).PsbtRemoveInput(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Rpc_PsbtRemoveInput_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RpcServer).PsbtRemoveInput(ctx, req.(*PsbtRemoveInputRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Rpc_PsbtRemoveRecipient_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PsbtRemoveRecipientRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RpcServer).PsbtRemoveRecipient(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Rpc_PsbtRemoveRecipient_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RpcServer).PsbtRemoveRecipient(ctx, req.(*PsbtRemoveRecipientRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Rpc_PsbtUpdateRecipient_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PsbtUpdateRecipientRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RpcServer).PsbtUpdateRecipient(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Rpc_PsbtUpdateRecipient_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RpcServer).PsbtUpdateRecipient(ctx, req.(*PsbtUpdateRecipientRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Rpc_PsbtGetRecipients_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(PsbtGetRecipientsRequest)
 	if err := dec(in); err != nil {
@@ -520,6 +1026,24 @@ func _Rpc_PsbtGetRecipients_Handler(srv interface{}, ctx context.Context, dec fu
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Rpc_PsbtDecode_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PsbtDecodeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RpcServer).PsbtDecode(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Rpc_PsbtDecode_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RpcServer).PsbtDecode(ctx, req.(*PsbtDecodeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Rpc_PsbtSign_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(PsbtSignRequest)
 	if err := dec(in); err != nil {
@@ -556,6 +1080,60 @@ func _Rpc_PsbtSignNonMweb_Handler(srv interface{}, ctx context.Context, dec func
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Rpc_PsbtCombine_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PsbtCombineRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RpcServer).PsbtCombine(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Rpc_PsbtCombine_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RpcServer).PsbtCombine(ctx, req.(*PsbtCombineRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Rpc_PsbtAnalyze_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PsbtAnalyzeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RpcServer).PsbtAnalyze(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Rpc_PsbtAnalyze_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RpcServer).PsbtAnalyze(ctx, req.(*PsbtAnalyzeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Rpc_PsbtFinalize_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PsbtFinalizeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RpcServer).PsbtFinalize(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Rpc_PsbtFinalize_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RpcServer).PsbtFinalize(ctx, req.(*PsbtFinalizeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Rpc_PsbtExtract_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(PsbtExtractRequest)
 	if err := dec(in); err != nil {
@@ -610,6 +1188,42 @@ func _Rpc_Broadcast_Handler(srv interface{}, ctx context.Context, dec func(inter
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Rpc_PegoutStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PegoutStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RpcServer).PegoutStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Rpc_PegoutStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RpcServer).PegoutStatus(ctx, req.(*PegoutStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Rpc_PeginStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PeginStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RpcServer).PeginStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Rpc_PeginStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RpcServer).PeginStatus(ctx, req.(*PeginStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Rpc_Coinswap_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(CoinswapRequest)
 	if err := dec(in); err != nil {
@@ -628,6 +1242,114 @@ func _Rpc_Coinswap_Handler(srv interface{}, ctx context.Context, dec func(interf
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Rpc_CoinswapStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CoinswapStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RpcServer).CoinswapStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Rpc_CoinswapStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RpcServer).CoinswapStatus(ctx, req.(*CoinswapStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Rpc_CoinswapList_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CoinswapListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RpcServer).CoinswapList(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Rpc_CoinswapList_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RpcServer).CoinswapList(ctx, req.(*CoinswapListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Rpc_CoinswapBatch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CoinswapBatchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RpcServer).CoinswapBatch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Rpc_CoinswapBatch_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RpcServer).CoinswapBatch(ctx, req.(*CoinswapBatchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Rpc_CoinswapAutoMix_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CoinswapAutoMixRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RpcServer).CoinswapAutoMix(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Rpc_CoinswapAutoMix_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RpcServer).CoinswapAutoMix(ctx, req.(*CoinswapAutoMixRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Rpc_Unlock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnlockRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RpcServer).Unlock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Rpc_Unlock_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RpcServer).Unlock(ctx, req.(*UnlockRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Rpc_RotateKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RotateKeyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RpcServer).RotateKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Rpc_RotateKey_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RpcServer).RotateKey(ctx, req.(*RotateKeyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Rpc_ServiceDesc is the grpc.ServiceDesc for Rpc service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -643,6 +1365,14 @@ var Rpc_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Addresses",
 			Handler:    _Rpc_Addresses_Handler,
 		},
+		{
+			MethodName: "Keychain",
+			Handler:    _Rpc_Keychain_Handler,
+		},
+		{
+			MethodName: "ValidateAddress",
+			Handler:    _Rpc_ValidateAddress_Handler,
+		},
 		{
 			MethodName: "Spent",
 			Handler:    _Rpc_Spent_Handler,
@@ -651,6 +1381,10 @@ var Rpc_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Create",
 			Handler:    _Rpc_Create_Handler,
 		},
+		{
+			MethodName: "EstimateFee",
+			Handler:    _Rpc_EstimateFee_Handler,
+		},
 		{
 			MethodName: "PsbtCreate",
 			Handler:    _Rpc_PsbtCreate_Handler,
@@ -663,10 +1397,26 @@ var Rpc_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "PsbtAddRecipient",
 			Handler:    _Rpc_PsbtAddRecipient_Handler,
 		},
+		{
+			MethodName: "PsbtRemoveInput",
+			Handler:    _Rpc_PsbtRemoveInput_Handler,
+		},
+		{
+			MethodName: "PsbtRemoveRecipient",
+			Handler:    _Rpc_PsbtRemoveRecipient_Handler,
+		},
+		{
+			MethodName: "PsbtUpdateRecipient",
+			Handler:    _Rpc_PsbtUpdateRecipient_Handler,
+		},
 		{
 			MethodName: "PsbtGetRecipients",
 			Handler:    _Rpc_PsbtGetRecipients_Handler,
 		},
+		{
+			MethodName: "PsbtDecode",
+			Handler:    _Rpc_PsbtDecode_Handler,
+		},
 		{
 			MethodName: "PsbtSign",
 			Handler:    _Rpc_PsbtSign_Handler,
@@ -675,6 +1425,18 @@ var Rpc_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "PsbtSignNonMweb",
 			Handler:    _Rpc_PsbtSignNonMweb_Handler,
 		},
+		{
+			MethodName: "PsbtCombine",
+			Handler:    _Rpc_PsbtCombine_Handler,
+		},
+		{
+			MethodName: "PsbtAnalyze",
+			Handler:    _Rpc_PsbtAnalyze_Handler,
+		},
+		{
+			MethodName: "PsbtFinalize",
+			Handler:    _Rpc_PsbtFinalize_Handler,
+		},
 		{
 			MethodName: "PsbtExtract",
 			Handler:    _Rpc_PsbtExtract_Handler,
@@ -687,10 +1449,42 @@ var Rpc_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Broadcast",
 			Handler:    _Rpc_Broadcast_Handler,
 		},
+		{
+			MethodName: "PegoutStatus",
+			Handler:    _Rpc_PegoutStatus_Handler,
+		},
+		{
+			MethodName: "PeginStatus",
+			Handler:    _Rpc_PeginStatus_Handler,
+		},
 		{
 			MethodName: "Coinswap",
 			Handler:    _Rpc_Coinswap_Handler,
 		},
+		{
+			MethodName: "CoinswapStatus",
+			Handler:    _Rpc_CoinswapStatus_Handler,
+		},
+		{
+			MethodName: "CoinswapList",
+			Handler:    _Rpc_CoinswapList_Handler,
+		},
+		{
+			MethodName: "CoinswapBatch",
+			Handler:    _Rpc_CoinswapBatch_Handler,
+		},
+		{
+			MethodName: "CoinswapAutoMix",
+			Handler:    _Rpc_CoinswapAutoMix_Handler,
+		},
+		{
+			MethodName: "Unlock",
+			Handler:    _Rpc_Unlock_Handler,
+		},
+		{
+			MethodName: "RotateKey",
+			Handler:    _Rpc_RotateKey_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
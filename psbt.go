@@ -3,7 +3,11 @@ package mwebd
 import (
 	"bytes"
 	"context"
+	"encoding/base64"
 	"encoding/hex"
+	"errors"
+	"fmt"
+	"slices"
 	"strings"
 
 	"github.com/ltcmweb/ltcd/chaincfg/chainhash"
@@ -15,6 +19,7 @@ import (
 	"github.com/ltcmweb/ltcd/wire"
 	"github.com/ltcmweb/mwebd/proto"
 	"github.com/ltcmweb/mwebd/sign"
+	"google.golang.org/protobuf/encoding/protojson"
 )
 
 func (s *Server) PsbtCreate(ctx context.Context,
@@ -34,7 +39,7 @@ func (s *Server) PsbtCreate(ctx context.Context,
 	}
 	for i, txIn := range tx.TxIn {
 		txOut := req.WitnessUtxo[i]
-		p.Inputs = append(p.Inputs, psbt.PInput{
+		p.Inputs = append(p.Inputs, &psbt.PInput{
 			WitnessUtxo:  wire.NewTxOut(txOut.Value, txOut.PkScript),
 			PrevoutHash:  &txIn.PreviousOutPoint.Hash,
 			PrevoutIndex: &txIn.PreviousOutPoint.Index,
@@ -42,7 +47,7 @@ func (s *Server) PsbtCreate(ctx context.Context,
 		})
 	}
 	for _, txOut := range tx.TxOut {
-		p.Outputs = append(p.Outputs, psbt.POutput{
+		p.Outputs = append(p.Outputs, &psbt.POutput{
 			Amount:   ltcutil.Amount(txOut.Value),
 			PKScript: txOut.PkScript,
 		})
@@ -80,7 +85,7 @@ func (s *Server) PsbtAddInput(ctx context.Context,
 
 	amount := ltcutil.Amount(coin.Value)
 
-	p.Inputs = append(p.Inputs, psbt.PInput{
+	p.Inputs = append(p.Inputs, &psbt.PInput{
 		MwebOutputId:          (*chainhash.Hash)(outputId),
 		MwebAddressIndex:      &req.AddressIndex,
 		MwebAmount:            &amount,
@@ -107,12 +112,9 @@ func (s *Server) getKernelIndex(p *psbt.Packet) (index int) {
 		index++
 	}
 	if index == len(p.Kernels) {
-		pKernel := psbt.PKernel{}
-		if p.FallbackLocktime != nil &&
-			*p.FallbackLocktime > 0 &&
-			*p.FallbackLocktime < 500_000_000 {
-			lockHeight := int32(*p.FallbackLocktime)
-			pKernel.LockHeight = &lockHeight
+		pKernel := &psbt.PKernel{}
+		if p.FallbackLocktime != nil {
+			pKernel.LockHeight = kernelLockHeight(*p.FallbackLocktime)
 		}
 		p.Kernels = append(p.Kernels, pKernel)
 	}
@@ -132,19 +134,22 @@ func (s *Server) PsbtAddRecipient(ctx context.Context,
 		return nil, err
 	}
 
-	kernel := &p.Kernels[s.getKernelIndex(p)]
-	if mwebAddr, ok := addr.(*ltcutil.AddressMweb); ok {
-		p.Outputs = append(p.Outputs, psbt.POutput{
-			Amount:         ltcutil.Amount(req.Recipient.Value),
-			StealthAddress: mwebAddr.StealthAddress(),
-		})
-	} else {
-		pkScript, err := txscript.PayToAddrScript(addr)
-		if err != nil {
-			return nil, err
+	value := req.Recipient.Value
+	if req.Sweep {
+		value = 0
+	}
+
+	setValue, err := s.addRecipient(p, addr, value)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Sweep {
+		_, inputs, outputs, fee := s.balanceKernel(p, req.FeeRatePerKb)
+		if inputs <= outputs+fee {
+			return nil, errors.New("insufficient MWEB inputs to sweep")
 		}
-		txOut := wire.NewTxOut(req.Recipient.Value, pkScript)
-		kernel.PegOuts = append(kernel.PegOuts, txOut)
+		setValue(inputs - outputs - fee)
 	}
 
 	s.adjustKernel(p, req.FeeRatePerKb)
@@ -156,8 +161,172 @@ func (s *Server) PsbtAddRecipient(ctx context.Context,
 	return &proto.PsbtResponse{PsbtB64: b64}, nil
 }
 
+// addRecipient adds an MWEB output, or a peg-out to the unsigned
+// kernel, and returns a function for setting its value.
+func (s *Server) addRecipient(p *psbt.Packet, addr ltcutil.Address,
+	value int64) (setValue func(ltcutil.Amount), err error) {
+
+	if mwebAddr, ok := addr.(*ltcutil.AddressMweb); ok {
+		pOutput := &psbt.POutput{
+			Amount:         ltcutil.Amount(value),
+			StealthAddress: mwebAddr.StealthAddress(),
+		}
+		p.Outputs = append(p.Outputs, pOutput)
+		return func(v ltcutil.Amount) { pOutput.Amount = v }, nil
+	}
+
+	pkScript, err := txscript.PayToAddrScript(addr)
+	if err != nil {
+		return nil, err
+	}
+	kernel := p.Kernels[s.getKernelIndex(p)]
+	txOut := wire.NewTxOut(value, pkScript)
+	kernel.PegOuts = append(kernel.PegOuts, txOut)
+	return func(v ltcutil.Amount) { txOut.Value = int64(v) }, nil
+}
+
+func (s *Server) PsbtRemoveInput(ctx context.Context,
+	req *proto.PsbtRemoveInputRequest) (*proto.PsbtResponse, error) {
+
+	p, err := psbt.NewFromRawBytes(strings.NewReader(req.PsbtB64), true)
+	if err != nil {
+		return nil, err
+	}
+	if int(req.Index) >= len(p.Inputs) {
+		return nil, errors.New("input index out of range")
+	}
+
+	pInput := p.Inputs[req.Index]
+	if pInput.MwebInputSig != nil || pInput.FinalScriptSig != nil ||
+		pInput.FinalScriptWitness != nil || len(pInput.PartialSigs) > 0 {
+		return nil, errors.New("input is already signed")
+	}
+	p.Inputs = slices.Delete(p.Inputs, int(req.Index), int(req.Index)+1)
+
+	s.adjustKernel(p, req.FeeRatePerKb)
+	return psbtResponse(p)
+}
+
+// findRecipient locates a recipient by its index in the order returned
+// by PsbtGetRecipients, i.e. the outputs followed by the peg-outs of
+// each kernel. Either the output index or the kernel and peg-out
+// indexes are returned, with the others set to -1.
+func findRecipient(p *psbt.Packet,
+	index uint32) (outputIndex, kernelIndex, pegoutIndex int, err error) {
+
+	i := int(index)
+	if i < len(p.Outputs) {
+		return i, -1, -1, nil
+	}
+	i -= len(p.Outputs)
+	for k, pKernel := range p.Kernels {
+		if i < len(pKernel.PegOuts) {
+			return -1, k, i, nil
+		}
+		i -= len(pKernel.PegOuts)
+	}
+	return -1, -1, -1, errors.New("recipient index out of range")
+}
+
+// removeRecipient removes the recipient at index, refusing to remove
+// signed outputs or peg-outs in signed kernels.
+func removeRecipient(p *psbt.Packet, index uint32) error {
+	o, k, i, err := findRecipient(p, index)
+	switch {
+	case err != nil:
+		return err
+	case o >= 0:
+		if p.Outputs[o].MwebSignature != nil {
+			return errors.New("output is already signed")
+		}
+		p.Outputs = slices.Delete(p.Outputs, o, o+1)
+	default:
+		kernel := p.Kernels[k]
+		if kernel.Signature != nil {
+			return errors.New("kernel is already signed")
+		}
+		kernel.PegOuts = slices.Delete(kernel.PegOuts, i, i+1)
+	}
+	return nil
+}
+
+func (s *Server) PsbtRemoveRecipient(ctx context.Context,
+	req *proto.PsbtRemoveRecipientRequest) (*proto.PsbtResponse, error) {
+
+	p, err := psbt.NewFromRawBytes(strings.NewReader(req.PsbtB64), true)
+	if err != nil {
+		return nil, err
+	}
+	if err = removeRecipient(p, req.Index); err != nil {
+		return nil, err
+	}
+
+	s.adjustKernel(p, req.FeeRatePerKb)
+	return psbtResponse(p)
+}
+
+func (s *Server) PsbtUpdateRecipient(ctx context.Context,
+	req *proto.PsbtUpdateRecipientRequest) (*proto.PsbtResponse, error) {
+
+	p, err := psbt.NewFromRawBytes(strings.NewReader(req.PsbtB64), true)
+	if err != nil {
+		return nil, err
+	}
+
+	addr, err := ltcutil.DecodeAddress(req.Recipient.Address, &s.cp)
+	if err != nil {
+		return nil, err
+	}
+	mwebAddr, isMweb := addr.(*ltcutil.AddressMweb)
+
+	o, k, i, err := findRecipient(p, req.Index)
+	if err != nil {
+		return nil, err
+	}
+
+	var pkScript []byte
+	if !isMweb {
+		if pkScript, err = txscript.PayToAddrScript(addr); err != nil {
+			return nil, err
+		}
+	}
+
+	switch {
+	case o >= 0 && p.Outputs[o].MwebSignature != nil:
+		return nil, errors.New("output is already signed")
+	case o < 0 && p.Kernels[k].Signature != nil:
+		return nil, errors.New("kernel is already signed")
+	case o >= 0 && isMwebOutput(p.Outputs[o]) == isMweb:
+		pOutput := p.Outputs[o]
+		pOutput.Amount = ltcutil.Amount(req.Recipient.Value)
+		if isMweb {
+			pOutput.StealthAddress = mwebAddr.StealthAddress()
+		} else {
+			pOutput.PKScript = pkScript
+		}
+	case o < 0 && !isMweb:
+		p.Kernels[k].PegOuts[i] = wire.NewTxOut(req.Recipient.Value, pkScript)
+	default:
+		if err = removeRecipient(p, req.Index); err != nil {
+			return nil, err
+		}
+		if _, err = s.addRecipient(p, addr, req.Recipient.Value); err != nil {
+			return nil, err
+		}
+	}
+
+	s.adjustKernel(p, req.FeeRatePerKb)
+	return psbtResponse(p)
+}
+
 func (s *Server) calcFee(p *psbt.Packet, feeRatePerKb uint64) uint64 {
-	divCeil := func(x, y uint64) uint64 { return (x + y - 1) / y }
+	mwebFee, pegoutFee := s.calcFeeParts(p, feeRatePerKb)
+	return mwebFee + pegoutFee
+}
+
+func (s *Server) calcFeeParts(p *psbt.Packet,
+	feeRatePerKb uint64) (mwebFee, pegoutFee uint64) {
+
 	var weight, txOutSize uint64
 	for _, pOutput := range p.Outputs {
 		if pOutput.StealthAddress != nil || pOutput.OutputCommit != nil {
@@ -171,16 +340,17 @@ func (s *Server) calcFee(p *psbt.Packet, feeRatePerKb uint64) uint64 {
 			txOutSize += uint64(pegout.SerializeSize())
 		}
 	}
-	return weight*mweb.BaseMwebFee + divCeil(txOutSize*feeRatePerKb, 1000)
+	return weight * mweb.BaseMwebFee, divCeil(txOutSize*feeRatePerKb, 1000)
 }
 
-func (s *Server) adjustKernel(p *psbt.Packet, feeRatePerKb uint64) {
-	var (
-		inputs, outputs ltcutil.Amount
+// balanceKernel returns the unsigned kernel along with the MWEB
+// inputs, outputs and fee that it needs to balance, after clearing
+// the amounts set on it previously.
+func (s *Server) balanceKernel(p *psbt.Packet, feeRatePerKb uint64) (
+	kernel *psbt.PKernel, inputs, outputs, fee ltcutil.Amount) {
 
-		kernel = &p.Kernels[s.getKernelIndex(p)]
-		fee    = ltcutil.Amount(s.calcFee(p, feeRatePerKb))
-	)
+	kernel = p.Kernels[s.getKernelIndex(p)]
+	fee = ltcutil.Amount(s.calcFee(p, feeRatePerKb))
 	for _, pInput := range p.Inputs {
 		if pInput.MwebAmount != nil {
 			inputs += *pInput.MwebAmount
@@ -191,7 +361,7 @@ func (s *Server) adjustKernel(p *psbt.Packet, feeRatePerKb uint64) {
 			outputs += pOutput.Amount
 		}
 	}
-	for i, pKernel := range p.Kernels {
+	for _, pKernel := range p.Kernels {
 		if pKernel.Signature != nil {
 			if pKernel.Fee != nil {
 				outputs += *pKernel.Fee
@@ -201,13 +371,18 @@ func (s *Server) adjustKernel(p *psbt.Packet, feeRatePerKb uint64) {
 				inputs += *pKernel.PeginAmount
 			}
 		} else {
-			p.Kernels[i].Fee = nil
-			p.Kernels[i].PeginAmount = nil
+			pKernel.Fee = nil
+			pKernel.PeginAmount = nil
 		}
 		for _, pegout := range pKernel.PegOuts {
 			outputs += ltcutil.Amount(pegout.Value)
 		}
 	}
+	return
+}
+
+func (s *Server) adjustKernel(p *psbt.Packet, feeRatePerKb uint64) {
+	kernel, inputs, outputs, fee := s.balanceKernel(p, feeRatePerKb)
 	if inputs < outputs+fee {
 		pegin := outputs + fee - inputs
 		kernel.PeginAmount = &pegin
@@ -220,7 +395,11 @@ func (s *Server) adjustKernel(p *psbt.Packet, feeRatePerKb uint64) {
 func (s *Server) PsbtGetRecipients(ctx context.Context,
 	req *proto.PsbtGetRecipientsRequest) (*proto.PsbtGetRecipientsResponse, error) {
 
-	resp, err := sign.PsbtGetRecipients(&sign.Psbt{PsbtB64: req.PsbtB64}, &s.cp)
+	b, err := base64.StdEncoding.DecodeString(req.PsbtB64)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := sign.PsbtGetRecipients(&sign.Psbt{Psbt: b}, &s.cp)
 	if err != nil {
 		return nil, err
 	}
@@ -241,29 +420,258 @@ func (s *Server) PsbtGetRecipients(ctx context.Context,
 func (s *Server) PsbtSign(ctx context.Context,
 	req *proto.PsbtSignRequest) (*proto.PsbtResponse, error) {
 
-	resp, err := sign.PsbtSign(&sign.PsbtSignRequest{
-		PsbtB64: req.PsbtB64,
-		Scan:    req.ScanSecret,
-		Spend:   req.SpendSecret,
-	})
+	b, err := base64.StdEncoding.DecodeString(req.PsbtB64)
 	if err != nil {
 		return nil, err
 	}
-	return &proto.PsbtResponse{PsbtB64: resp.PsbtB64}, nil
+	signReq := &sign.PsbtSignRequest{
+		Psbt:  b,
+		Scan:  req.ScanSecret,
+		Spend: req.SpendSecret,
+	}
+	defer signReq.Zero()
+	p, err := sign.PsbtSign(signReq)
+	if err != nil {
+		return nil, err
+	}
+	return psbtResponse(p)
 }
 
 func (s *Server) PsbtSignNonMweb(ctx context.Context,
 	req *proto.PsbtSignNonMwebRequest) (*proto.PsbtResponse, error) {
 
-	resp, err := sign.PsbtSignPubKeyHash(&sign.PsbtSignPubKeyHashRequest{
-		PsbtB64: req.PsbtB64,
-		PrivKey: req.PrivKey,
-		Index:   req.Index,
+	b, err := base64.StdEncoding.DecodeString(req.PsbtB64)
+	if err != nil {
+		return nil, err
+	}
+	p, err := sign.PsbtSignNonMweb(&sign.PsbtSignNonMwebRequest{
+		Psbt:  b,
+		Key:   req.PrivKey,
+		XPrv:  req.Xprv,
+		Index: req.Index,
 	})
 	if err != nil {
 		return nil, err
 	}
-	return &proto.PsbtResponse{PsbtB64: resp.PsbtB64}, nil
+	return psbtResponse(p)
+}
+
+func (s *Server) PsbtCombine(ctx context.Context,
+	req *proto.PsbtCombineRequest) (*proto.PsbtResponse, error) {
+
+	if len(req.PsbtB64) == 0 {
+		return nil, errors.New("no PSBTs to combine")
+	}
+	var p *psbt.Packet
+	for _, b64 := range req.PsbtB64 {
+		p2, err := psbt.NewFromRawBytes(strings.NewReader(b64), true)
+		if err != nil {
+			return nil, err
+		}
+		if p == nil {
+			p = p2
+		} else if err = combinePsbt(p, p2); err != nil {
+			return nil, err
+		}
+	}
+	return psbtResponse(p)
+}
+
+// combinePsbt merges the signatures in p2 into p. Since signing MWEB
+// components updates the offsets, the MWEB signatures of one PSBT
+// must be a subset of the other's for the offsets to be consistent.
+func combinePsbt(p, p2 *psbt.Packet) error {
+	if len(p.Inputs) != len(p2.Inputs) ||
+		len(p.Outputs) != len(p2.Outputs) ||
+		len(p.Kernels) != len(p2.Kernels) {
+		return errors.New("PSBTs have different components")
+	}
+	for i, pInput := range p.Inputs {
+		if !sameInput(pInput, p2.Inputs[i]) {
+			return fmt.Errorf("input %d differs", i)
+		}
+	}
+	for i, pOutput := range p.Outputs {
+		pOutput2 := p2.Outputs[i]
+		if pOutput.Amount != pOutput2.Amount ||
+			!bytes.Equal(pOutput.PKScript, pOutput2.PKScript) ||
+			isMwebOutput(pOutput) != isMwebOutput(pOutput2) {
+			return fmt.Errorf("output %d differs", i)
+		}
+	}
+	for i, pKernel := range p.Kernels {
+		if len(pKernel.PegOuts) != len(p2.Kernels[i].PegOuts) {
+			return fmt.Errorf("kernel %d differs", i)
+		}
+	}
+
+	signed, signed2 := mwebSigned(p), mwebSigned(p2)
+	switch {
+	case isSubset(signed2, signed):
+	case isSubset(signed, signed2):
+		p.MwebTxOffset = p2.MwebTxOffset
+		p.MwebStealthOffset = p2.MwebStealthOffset
+	default:
+		return errors.New("MWEB components were signed in parallel")
+	}
+
+	for i, pInput := range p.Inputs {
+		pInput2 := p2.Inputs[i]
+		if pInput.MwebOutputId != nil {
+			if pInput.MwebInputSig == nil && pInput2.MwebInputSig != nil {
+				p.Inputs[i] = pInput2
+			}
+			continue
+		}
+		if pInput.WitnessUtxo == nil {
+			pInput.WitnessUtxo = pInput2.WitnessUtxo
+		}
+		if pInput.NonWitnessUtxo == nil {
+			pInput.NonWitnessUtxo = pInput2.NonWitnessUtxo
+		}
+		if pInput.FinalScriptSig == nil && pInput.FinalScriptWitness == nil {
+			pInput.FinalScriptSig = pInput2.FinalScriptSig
+			pInput.FinalScriptWitness = pInput2.FinalScriptWitness
+		}
+		for _, sig := range pInput2.PartialSigs {
+			if !slices.ContainsFunc(pInput.PartialSigs, func(sig2 *psbt.PartialSig) bool {
+				return bytes.Equal(sig.PubKey, sig2.PubKey)
+			}) {
+				pInput.PartialSigs = append(pInput.PartialSigs, sig)
+			}
+		}
+	}
+	for i, pOutput := range p.Outputs {
+		if isMwebOutput(pOutput) && pOutput.MwebSignature == nil &&
+			p2.Outputs[i].MwebSignature != nil {
+			p.Outputs[i] = p2.Outputs[i]
+		}
+	}
+	for i, pKernel := range p.Kernels {
+		if pKernel.Signature == nil && p2.Kernels[i].Signature != nil {
+			p.Kernels[i] = p2.Kernels[i]
+		}
+	}
+	return nil
+}
+
+func sameInput(pInput, pInput2 *psbt.PInput) bool {
+	if pInput.MwebOutputId != nil || pInput2.MwebOutputId != nil {
+		return pInput.MwebOutputId != nil && pInput2.MwebOutputId != nil &&
+			*pInput.MwebOutputId == *pInput2.MwebOutputId
+	}
+	return pInput.PrevoutHash != nil && pInput2.PrevoutHash != nil &&
+		*pInput.PrevoutHash == *pInput2.PrevoutHash &&
+		*pInput.PrevoutIndex == *pInput2.PrevoutIndex
+}
+
+func isMwebOutput(pOutput *psbt.POutput) bool {
+	return pOutput.StealthAddress != nil || pOutput.OutputCommit != nil
+}
+
+// mwebSigned returns whether each MWEB input, output and kernel of
+// the PSBT is signed, in that order.
+func mwebSigned(p *psbt.Packet) (signed []bool) {
+	for _, pInput := range p.Inputs {
+		if pInput.MwebOutputId != nil {
+			signed = append(signed, pInput.MwebInputSig != nil)
+		}
+	}
+	for _, pOutput := range p.Outputs {
+		if isMwebOutput(pOutput) {
+			signed = append(signed, pOutput.MwebSignature != nil)
+		}
+	}
+	for _, pKernel := range p.Kernels {
+		signed = append(signed, pKernel.Signature != nil)
+	}
+	return
+}
+
+func isSubset(a, b []bool) bool {
+	for i := range a {
+		if a[i] && !b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func (s *Server) PsbtAnalyze(ctx context.Context,
+	req *proto.PsbtAnalyzeRequest) (*proto.PsbtAnalyzeResponse, error) {
+
+	p, err := psbt.NewFromRawBytes(strings.NewReader(req.PsbtB64), true)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		resp                            = &proto.PsbtAnalyzeResponse{}
+		needsUtxo, needsSig, needsFinal bool
+	)
+	for _, pInput := range p.Inputs {
+		a := &proto.PsbtInputAnalysis{Mweb: pInput.MwebOutputId != nil}
+		if a.Mweb {
+			a.HasUtxo = pInput.MwebAmount != nil
+			a.Signed = pInput.MwebInputSig != nil
+			a.Finalized = a.Signed
+		} else {
+			a.HasUtxo = pInput.WitnessUtxo != nil || pInput.NonWitnessUtxo != nil
+			a.Finalized = pInput.FinalScriptSig != nil ||
+				pInput.FinalScriptWitness != nil
+			a.Signed = a.Finalized || len(pInput.PartialSigs) > 0
+		}
+		needsUtxo = needsUtxo || !a.HasUtxo
+		needsSig = needsSig || !a.Signed
+		needsFinal = needsFinal || !a.Finalized
+		resp.Input = append(resp.Input, a)
+	}
+	for i, pOutput := range p.Outputs {
+		if isMwebOutput(pOutput) && pOutput.MwebSignature == nil {
+			resp.UnsignedMwebOutput = append(resp.UnsignedMwebOutput, uint32(i))
+			needsSig = true
+		}
+	}
+	for i, pKernel := range p.Kernels {
+		if pKernel.Signature == nil {
+			resp.UnsignedKernel = append(resp.UnsignedKernel, uint32(i))
+			needsSig = true
+		}
+	}
+
+	switch {
+	case needsUtxo:
+		resp.Next = "updater"
+	case needsSig:
+		resp.Next = "signer"
+	case needsFinal:
+		resp.Next = "finalizer"
+	default:
+		resp.Next = "extractor"
+	}
+	return resp, nil
+}
+
+func (s *Server) PsbtFinalize(ctx context.Context,
+	req *proto.PsbtFinalizeRequest) (*proto.PsbtResponse, error) {
+
+	b, err := base64.StdEncoding.DecodeString(req.PsbtB64)
+	if err != nil {
+		return nil, err
+	}
+	p, err := sign.PsbtFinalize(&sign.Psbt{Psbt: b})
+	if err != nil {
+		return nil, err
+	}
+	return psbtResponse(p)
+}
+
+func psbtResponse(p *psbt.Packet) (*proto.PsbtResponse, error) {
+	b64, err := p.B64Encode()
+	if err != nil {
+		return nil, err
+	}
+	return &proto.PsbtResponse{PsbtB64: b64}, nil
 }
 
 func (s *Server) PsbtExtract(ctx context.Context,
@@ -307,3 +715,157 @@ func (s *Server) PsbtExtract(ctx context.Context,
 
 	return resp, nil
 }
+
+func (s *Server) PsbtDecode(ctx context.Context,
+	req *proto.PsbtDecodeRequest) (*proto.PsbtDecodeResponse, error) {
+
+	b, err := base64.StdEncoding.DecodeString(req.PsbtB64)
+	if err != nil {
+		return nil, err
+	}
+	p, err := psbt.NewFromRawBytes(bytes.NewReader(b), false)
+	if err != nil {
+		return nil, err
+	}
+	recipients, err := sign.PsbtGetRecipients(&sign.Psbt{Psbt: b}, &s.cp)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &proto.PsbtDecodeResponse{
+		PsbtVersion: p.PsbtVersion,
+		TxVersion:   p.TxVersion,
+		Fee:         recipients.Fee,
+	}
+	if p.FallbackLocktime != nil {
+		resp.FallbackLocktime = *p.FallbackLocktime
+	}
+	if p.MwebTxOffset != nil {
+		resp.MwebTxOffset = hex.EncodeToString(p.MwebTxOffset[:])
+	}
+	if p.MwebStealthOffset != nil {
+		resp.MwebStealthOffset = hex.EncodeToString(p.MwebStealthOffset[:])
+	}
+
+	for _, pInput := range p.Inputs {
+		resp.Input = append(resp.Input, s.decodeInput(pInput))
+	}
+	for _, pOutput := range p.Outputs {
+		resp.Output = append(resp.Output, s.decodeOutput(pOutput))
+	}
+	for _, pKernel := range p.Kernels {
+		resp.Kernel = append(resp.Kernel, s.decodeKernel(pKernel))
+	}
+
+	json, err := protojson.MarshalOptions{Multiline: true}.Marshal(resp)
+	if err != nil {
+		return nil, err
+	}
+	resp.Json = string(json)
+	return resp, nil
+}
+
+func (s *Server) pkScriptAddress(pkScript []byte) string {
+	_, addrs, _, _ := txscript.ExtractPkScriptAddrs(pkScript, &s.cp)
+	var addrs2 []string
+	for _, addr := range addrs {
+		addrs2 = append(addrs2, addr.String())
+	}
+	return strings.Join(addrs2, ",")
+}
+
+func (s *Server) decodeInput(pInput *psbt.PInput) *proto.PsbtDecodedInput {
+	in := &proto.PsbtDecodedInput{
+		Mweb:                pInput.MwebOutputId != nil,
+		PartialSigs:         uint32(len(pInput.PartialSigs)),
+		Finalized:           pInput.FinalScriptSig != nil || pInput.FinalScriptWitness != nil,
+		HasMwebSharedSecret: pInput.MwebSharedSecret != nil,
+		MwebSigned:          pInput.MwebInputSig != nil,
+	}
+	if pInput.PrevoutHash != nil && pInput.PrevoutIndex != nil {
+		in.Prevout = wire.NewOutPoint(pInput.PrevoutHash, *pInput.PrevoutIndex).String()
+	}
+	if pInput.Sequence != nil {
+		in.Sequence = *pInput.Sequence
+	}
+	if pInput.WitnessUtxo != nil {
+		in.Value = pInput.WitnessUtxo.Value
+		in.PkScript = hex.EncodeToString(pInput.WitnessUtxo.PkScript)
+		in.Address = s.pkScriptAddress(pInput.WitnessUtxo.PkScript)
+	}
+	if pInput.MwebOutputId != nil {
+		in.MwebOutputId = hex.EncodeToString(pInput.MwebOutputId[:])
+	}
+	if pInput.MwebAddressIndex != nil {
+		in.MwebAddressIndex = *pInput.MwebAddressIndex
+	}
+	if pInput.MwebAmount != nil {
+		in.Value = int64(*pInput.MwebAmount)
+	}
+	if pInput.MwebKeyExchangePubkey != nil {
+		in.MwebKeyExchangePubkey = hex.EncodeToString(pInput.MwebKeyExchangePubkey[:])
+	}
+	if pInput.MwebCommit != nil {
+		in.MwebCommit = hex.EncodeToString(pInput.MwebCommit[:])
+	}
+	if pInput.MwebOutputPubkey != nil {
+		in.MwebOutputPubkey = hex.EncodeToString(pInput.MwebOutputPubkey[:])
+	}
+	if pInput.MwebInputPubkey != nil {
+		in.MwebInputPubkey = hex.EncodeToString(pInput.MwebInputPubkey[:])
+	}
+	return in
+}
+
+func (s *Server) decodeOutput(pOutput *psbt.POutput) *proto.PsbtDecodedOutput {
+	out := &proto.PsbtDecodedOutput{
+		Mweb:       isMwebOutput(pOutput),
+		Amount:     int64(pOutput.Amount),
+		PkScript:   hex.EncodeToString(pOutput.PKScript),
+		MwebSigned: pOutput.MwebSignature != nil,
+	}
+	if pOutput.StealthAddress != nil {
+		out.Address = ltcutil.NewAddressMweb(pOutput.StealthAddress, &s.cp).String()
+	} else if !out.Mweb {
+		out.Address = s.pkScriptAddress(pOutput.PKScript)
+	}
+	if pOutput.OutputCommit != nil {
+		out.MwebOutputCommit = hex.EncodeToString(pOutput.OutputCommit[:])
+	}
+	if pOutput.SenderPubkey != nil {
+		out.MwebSenderPubkey = hex.EncodeToString(pOutput.SenderPubkey[:])
+	}
+	if pOutput.OutputPubkey != nil {
+		out.MwebOutputPubkey = hex.EncodeToString(pOutput.OutputPubkey[:])
+	}
+	return out
+}
+
+func (s *Server) decodeKernel(pKernel *psbt.PKernel) *proto.PsbtDecodedKernel {
+	kernel := &proto.PsbtDecodedKernel{Signed: pKernel.Signature != nil}
+	if pKernel.Features != nil {
+		kernel.Features = uint32(*pKernel.Features)
+	}
+	if pKernel.Fee != nil {
+		kernel.Fee = int64(*pKernel.Fee)
+	}
+	if pKernel.PeginAmount != nil {
+		kernel.PeginAmount = int64(*pKernel.PeginAmount)
+	}
+	for _, pegout := range pKernel.PegOuts {
+		kernel.Pegout = append(kernel.Pegout, &proto.PsbtRecipient{
+			Address: s.pkScriptAddress(pegout.PkScript),
+			Value:   pegout.Value,
+		})
+	}
+	if pKernel.LockHeight != nil {
+		kernel.LockHeight = *pKernel.LockHeight
+	}
+	if pKernel.ExcessCommitment != nil {
+		kernel.Excess = hex.EncodeToString(pKernel.ExcessCommitment[:])
+	}
+	if pKernel.StealthExcess != nil {
+		kernel.StealthExcess = hex.EncodeToString(pKernel.StealthExcess[:])
+	}
+	return kernel
+}
@@ -0,0 +1,348 @@
+package mwebd
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/ltcmweb/ltcd/btcec/v2"
+	"github.com/ltcmweb/ltcd/chaincfg"
+	"github.com/ltcmweb/ltcd/chaincfg/chainhash"
+	"github.com/ltcmweb/ltcd/ltcutil"
+	"github.com/ltcmweb/ltcd/ltcutil/mweb/mw"
+	"github.com/ltcmweb/ltcd/ltcutil/psbt"
+	"github.com/ltcmweb/ltcd/txscript"
+	"github.com/ltcmweb/ltcd/wire"
+	"github.com/ltcmweb/mwebd/proto"
+)
+
+func decodePsbt(t *testing.T, b64 string) *psbt.Packet {
+	p, err := psbt.NewFromRawBytes(strings.NewReader(b64), true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return p
+}
+
+// newPsbtWithMwebInput returns a PSBT with a single MWEB input of the
+// given amount, as if added by PsbtAddInput.
+func newPsbtWithMwebInput(t *testing.T, amount ltcutil.Amount) string {
+	p := &psbt.Packet{PsbtVersion: 2, TxVersion: 2}
+	var outputId chainhash.Hash
+	rand.Read(outputId[:])
+	p.Inputs = append(p.Inputs, &psbt.PInput{
+		MwebOutputId: &outputId,
+		MwebAmount:   &amount,
+	})
+	b64, err := p.B64Encode()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b64
+}
+
+func TestPsbtAddRecipientSweep(t *testing.T) {
+	s := NewBareServer(chaincfg.MainNetParams)
+	ctx := context.Background()
+	mwebAddr := ltcutil.NewAddressMweb(randKeychain().Address(0), &s.cp)
+	pegoutAddr, _ := ltcutil.NewAddressWitnessPubKeyHash(make([]byte, 20), &s.cp)
+
+	resp, err := s.PsbtAddRecipient(ctx, &proto.PsbtAddRecipientRequest{
+		PsbtB64: newPsbtWithMwebInput(t, 100_000),
+		Recipient: &proto.PsbtRecipient{
+			Address: pegoutAddr.String(), Value: 30_000,
+		},
+		FeeRatePerKb: 10_000,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err = s.PsbtAddRecipient(ctx, &proto.PsbtAddRecipientRequest{
+		PsbtB64:      resp.PsbtB64,
+		Recipient:    &proto.PsbtRecipient{Address: mwebAddr.String()},
+		FeeRatePerKb: 10_000,
+		Sweep:        true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	p := decodePsbt(t, resp.PsbtB64)
+	kernel := p.Kernels[0]
+	if kernel.PeginAmount != nil {
+		t.Fatal("unexpected pegin")
+	}
+	if uint64(*kernel.Fee) != s.calcFee(p, 10_000) {
+		t.Fatal("fee should be exactly the estimated fee")
+	}
+	if p.Outputs[0].Amount+*kernel.Fee+30_000 != 100_000 {
+		t.Fatal("sweep output doesn't absorb the remainder")
+	}
+
+	_, err = s.PsbtAddRecipient(ctx, &proto.PsbtAddRecipientRequest{
+		PsbtB64:      newPsbtWithMwebInput(t, 100),
+		Recipient:    &proto.PsbtRecipient{Address: mwebAddr.String()},
+		FeeRatePerKb: 10_000,
+		Sweep:        true,
+	})
+	if err == nil {
+		t.Fatal("expected insufficient inputs error")
+	}
+}
+
+func TestPsbtCombine(t *testing.T) {
+	s := NewBareServer(chaincfg.MainNetParams)
+	ctx := context.Background()
+	kc := randKeychain()
+
+	key, err := btcec.NewPrivateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr, err := ltcutil.NewAddressWitnessPubKeyHash(
+		ltcutil.Hash160(key.PubKey().SerializeCompressed()), &s.cp)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pkScript, err := txscript.PayToAddrScript(addr)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tx := wire.NewMsgTx(2)
+	var prevHash chainhash.Hash
+	rand.Read(prevHash[:])
+	tx.AddTxIn(wire.NewTxIn(wire.NewOutPoint(&prevHash, 0), nil, nil))
+	resp, err := s.PsbtCreate(ctx, &proto.PsbtCreateRequest{
+		RawTx:       serializeTx(t, tx),
+		WitnessUtxo: []*proto.TxOut{{Value: 100_000, PkScript: pkScript}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	mwebAddr := ltcutil.NewAddressMweb(kc.Address(0), &s.cp)
+	resp, err = s.PsbtAddRecipient(ctx, &proto.PsbtAddRecipientRequest{
+		PsbtB64:      resp.PsbtB64,
+		Recipient:    &proto.PsbtRecipient{Address: mwebAddr.String(), Value: 90_000},
+		FeeRatePerKb: 10_000,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	unsigned := resp.PsbtB64
+
+	analyze := func(b64 string) *proto.PsbtAnalyzeResponse {
+		resp, err := s.PsbtAnalyze(ctx, &proto.PsbtAnalyzeRequest{PsbtB64: b64})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return resp
+	}
+	a := analyze(unsigned)
+	if a.Next != "signer" || a.Input[0].Mweb || !a.Input[0].HasUtxo ||
+		len(a.UnsignedMwebOutput) != 1 || len(a.UnsignedKernel) != 1 {
+		t.Fatal("unexpected analysis of unsigned PSBT")
+	}
+
+	mwebSignedResp, err := s.PsbtSign(ctx, &proto.PsbtSignRequest{
+		PsbtB64:     unsigned,
+		ScanSecret:  bytes.Clone(kc.Scan[:]),
+		SpendSecret: bytes.Clone(kc.Spend[:]),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	nonMwebSignedResp, err := s.PsbtSignNonMweb(ctx, &proto.PsbtSignNonMwebRequest{
+		PsbtB64: unsigned,
+		PrivKey: key.Serialize(),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if a = analyze(mwebSignedResp.PsbtB64); a.Next != "signer" ||
+		len(a.UnsignedMwebOutput) > 0 || len(a.UnsignedKernel) > 0 {
+		t.Fatal("unexpected analysis of MWEB signed PSBT")
+	}
+
+	for _, order := range [][]string{
+		{mwebSignedResp.PsbtB64, nonMwebSignedResp.PsbtB64},
+		{nonMwebSignedResp.PsbtB64, mwebSignedResp.PsbtB64},
+	} {
+		resp, err = s.PsbtCombine(ctx, &proto.PsbtCombineRequest{PsbtB64: order})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if a = analyze(resp.PsbtB64); a.Next != "extractor" {
+			t.Fatal("expected combined PSBT to be complete, next is", a.Next)
+		}
+		_, err = s.PsbtExtract(ctx, &proto.PsbtExtractRequest{PsbtB64: resp.PsbtB64})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	_, err = s.PsbtCombine(ctx, &proto.PsbtCombineRequest{
+		PsbtB64: []string{unsigned, newPsbtWithMwebInput(t, 1000)},
+	})
+	if err == nil {
+		t.Fatal("expected mismatched PSBTs error")
+	}
+}
+
+func TestPsbtEditRecipients(t *testing.T) {
+	const feeRatePerKb = 10_000
+
+	s := NewBareServer(chaincfg.MainNetParams)
+	ctx := context.Background()
+	mwebAddr := ltcutil.NewAddressMweb(randKeychain().Address(0), &s.cp)
+	pegoutAddr, _ := ltcutil.NewAddressWitnessPubKeyHash(make([]byte, 20), &s.cp)
+
+	checkPegin := func(b64 string, outputs ltcutil.Amount) *psbt.Packet {
+		p := decodePsbt(t, b64)
+		kernel := p.Kernels[0]
+		fee := ltcutil.Amount(s.calcFee(p, feeRatePerKb))
+		if *kernel.Fee != fee || kernel.PeginAmount == nil ||
+			*kernel.PeginAmount != outputs+fee-10_000 {
+			t.Fatal("kernel not adjusted")
+		}
+		return p
+	}
+
+	resp, err := s.PsbtAddRecipient(ctx, &proto.PsbtAddRecipientRequest{
+		PsbtB64:      newPsbtWithMwebInput(t, 10_000),
+		Recipient:    &proto.PsbtRecipient{Address: mwebAddr.String(), Value: 50_000},
+		FeeRatePerKb: feeRatePerKb,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err = s.PsbtAddRecipient(ctx, &proto.PsbtAddRecipientRequest{
+		PsbtB64:      resp.PsbtB64,
+		Recipient:    &proto.PsbtRecipient{Address: pegoutAddr.String(), Value: 20_000},
+		FeeRatePerKb: feeRatePerKb,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkPegin(resp.PsbtB64, 70_000)
+
+	resp, err = s.PsbtUpdateRecipient(ctx, &proto.PsbtUpdateRecipientRequest{
+		PsbtB64:      resp.PsbtB64,
+		Index:        1,
+		Recipient:    &proto.PsbtRecipient{Address: pegoutAddr.String(), Value: 25_000},
+		FeeRatePerKb: feeRatePerKb,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkPegin(resp.PsbtB64, 75_000)
+
+	resp, err = s.PsbtUpdateRecipient(ctx, &proto.PsbtUpdateRecipientRequest{
+		PsbtB64:      resp.PsbtB64,
+		Index:        0,
+		Recipient:    &proto.PsbtRecipient{Address: pegoutAddr.String(), Value: 30_000},
+		FeeRatePerKb: feeRatePerKb,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := checkPegin(resp.PsbtB64, 55_000)
+	if len(p.Outputs) != 0 || len(p.Kernels[0].PegOuts) != 2 ||
+		p.Kernels[0].PegOuts[1].Value != 30_000 {
+		t.Fatal("recipient should have moved to the peg-outs")
+	}
+
+	resp, err = s.PsbtRemoveRecipient(ctx, &proto.PsbtRemoveRecipientRequest{
+		PsbtB64:      resp.PsbtB64,
+		Index:        0,
+		FeeRatePerKb: feeRatePerKb,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkPegin(resp.PsbtB64, 30_000)
+
+	resp, err = s.PsbtRemoveInput(ctx, &proto.PsbtRemoveInputRequest{
+		PsbtB64:      resp.PsbtB64,
+		Index:        0,
+		FeeRatePerKb: feeRatePerKb,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	p = decodePsbt(t, resp.PsbtB64)
+	if len(p.Inputs) != 0 || *p.Kernels[0].PeginAmount != 30_000+*p.Kernels[0].Fee {
+		t.Fatal("pegin should cover the removed input")
+	}
+
+	p.Kernels[0].Signature = &mw.Signature{}
+	b64, err := p.B64Encode()
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = s.PsbtRemoveRecipient(ctx, &proto.PsbtRemoveRecipientRequest{
+		PsbtB64: b64, FeeRatePerKb: feeRatePerKb,
+	})
+	if err == nil {
+		t.Fatal("expected signed kernel error")
+	}
+	_, err = s.PsbtRemoveRecipient(ctx, &proto.PsbtRemoveRecipientRequest{
+		PsbtB64: b64, Index: 1, FeeRatePerKb: feeRatePerKb,
+	})
+	if err == nil {
+		t.Fatal("expected index out of range error")
+	}
+}
+
+func TestPsbtDecode(t *testing.T) {
+	s := NewBareServer(chaincfg.MainNetParams)
+	ctx := context.Background()
+	mwebAddr := ltcutil.NewAddressMweb(randKeychain().Address(0), &s.cp)
+	pegoutAddr, _ := ltcutil.NewAddressWitnessPubKeyHash(make([]byte, 20), &s.cp)
+
+	b64 := newPsbtWithMwebInput(t, 100_000)
+	for _, r := range []*proto.PsbtRecipient{
+		{Address: mwebAddr.String(), Value: 50_000},
+		{Address: pegoutAddr.String(), Value: 20_000},
+	} {
+		resp, err := s.PsbtAddRecipient(ctx, &proto.PsbtAddRecipientRequest{
+			PsbtB64: b64, Recipient: r, FeeRatePerKb: 10_000,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		b64 = resp.PsbtB64
+	}
+
+	resp, err := s.PsbtDecode(ctx, &proto.PsbtDecodeRequest{PsbtB64: b64})
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := decodePsbt(t, b64)
+	if len(resp.Input) != 1 || !resp.Input[0].Mweb ||
+		resp.Input[0].Value != 100_000 || resp.Input[0].MwebSigned ||
+		resp.Input[0].MwebOutputId != hex.EncodeToString(p.Inputs[0].MwebOutputId[:]) {
+		t.Fatal("unexpected input")
+	}
+	if len(resp.Output) != 1 || !resp.Output[0].Mweb ||
+		resp.Output[0].Address != mwebAddr.String() ||
+		resp.Output[0].Amount != 50_000 {
+		t.Fatal("unexpected output")
+	}
+	if len(resp.Kernel) != 1 || resp.Kernel[0].Signed ||
+		len(resp.Kernel[0].Pegout) != 1 ||
+		resp.Kernel[0].Pegout[0].Address != pegoutAddr.String() ||
+		resp.Kernel[0].Fee != int64(*p.Kernels[0].Fee) {
+		t.Fatal("unexpected kernel")
+	}
+	if resp.Fee != resp.Kernel[0].Fee {
+		t.Fatal("unexpected fee")
+	}
+	if !strings.Contains(resp.Json, `"mwebOutputId"`) ||
+		!strings.Contains(resp.Json, pegoutAddr.String()) {
+		t.Fatal("JSON is missing fields")
+	}
+}
@@ -0,0 +1,84 @@
+package mwebd
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"time"
+
+	"github.com/hashicorp/golang-lru/v2/expirable"
+	"github.com/ltcmweb/ltcd/chaincfg/chainhash"
+	"github.com/ltcmweb/ltcd/ltcutil/mweb"
+	"github.com/ltcmweb/ltcd/ltcutil/mweb/mw"
+	"github.com/ltcmweb/mwebd/ledger"
+	"github.com/ltcmweb/mwebd/sign"
+)
+
+const (
+	defaultCoinCacheAccounts = 10
+	defaultCoinCacheSize     = 100
+
+	// coinCacheLifetime bounds how long the coins rewound for an
+	// account are kept, since they hold the blinding factors.
+	coinCacheLifetime = time.Hour
+
+	// ledgerTxLifetime bounds how long a transaction being signed
+	// by a Ledger is kept between calls.
+	ledgerTxLifetime = 10 * time.Minute
+)
+
+// coinCache caches the coins rewound for each account. Accounts are
+// keyed by a salted hash of their scan secret, so that the secrets
+// aren't kept by the cache.
+type coinCache struct {
+	salt     [32]byte
+	size     int
+	accounts *expirable.LRU[chainhash.Hash, *expirable.LRU[chainhash.Hash, *mweb.Coin]]
+}
+
+// newCoinCache returns a cache of the given number of accounts, each
+// holding up to size coins.
+func newCoinCache(accounts, size int) *coinCache {
+	c := &coinCache{size: size, accounts: expirable.NewLRU[chainhash.Hash,
+		*expirable.LRU[chainhash.Hash, *mweb.Coin]](accounts, nil, coinCacheLifetime)}
+	if _, err := rand.Read(c.salt[:]); err != nil {
+		panic(err)
+	}
+	return c
+}
+
+// key returns the salted hash that the account of the scan secret is
+// keyed by. The server's other maps of accounts use it too.
+func (c *coinCache) key(scanSecret *mw.SecretKey) chainhash.Hash {
+	h := hmac.New(sha256.New, c.salt[:])
+	h.Write(scanSecret[:])
+	return chainhash.Hash(h.Sum(nil))
+}
+
+func (c *coinCache) account(scanSecret *mw.SecretKey) *expirable.LRU[chainhash.Hash, *mweb.Coin] {
+	key := c.key(scanSecret)
+	cache, ok := c.accounts.Get(key)
+	if !ok {
+		cache = expirable.NewLRU[chainhash.Hash, *mweb.Coin](c.size, nil, coinCacheLifetime)
+		c.accounts.Add(key, cache)
+	}
+	return cache
+}
+
+// pendingLedgerTx returns the transaction being signed by a Ledger,
+// discarding it if it was started too long ago. It must be called
+// with s.mtx held.
+func (s *Server) pendingLedgerTx() *ledger.TxContext {
+	if s.ledgerTx != nil && time.Since(s.ledgerTxTime) > ledgerTxLifetime {
+		s.ledgerTx = nil
+	}
+	return s.ledgerTx
+}
+
+// zeroSpendKeys zeroes the spend keys of coins once a transaction
+// spending them has been created.
+func zeroSpendKeys(coins []*mweb.Coin) {
+	for _, coin := range coins {
+		sign.Zero(coin.SpendKey)
+	}
+}
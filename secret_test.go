@@ -0,0 +1,48 @@
+package mwebd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ltcmweb/ltcd/chaincfg"
+	"github.com/ltcmweb/ltcd/chaincfg/chainhash"
+	"github.com/ltcmweb/ltcd/ltcutil/mweb"
+	"github.com/ltcmweb/mwebd/ledger"
+)
+
+func TestCoinCache(t *testing.T) {
+	c := newCoinCache(defaultCoinCacheAccounts, defaultCoinCacheSize)
+	kc, kc2 := randKeychain(), randKeychain()
+	c.account(kc.Scan).Add(chainhash.Hash{1}, &mweb.Coin{Value: 1})
+
+	if coin, ok := c.account(kc.Scan).Get(chainhash.Hash{1}); !ok || coin.Value != 1 {
+		t.Fatal("expected cached coin")
+	}
+	if _, ok := c.account(kc2.Scan).Get(chainhash.Hash{1}); ok {
+		t.Fatal("coin cached for wrong account")
+	}
+	for _, key := range c.accounts.Keys() {
+		if key == chainhash.Hash(*kc.Scan) || key == chainhash.Hash(*kc2.Scan) {
+			t.Fatal("cache keyed by scan secret")
+		}
+	}
+
+	c2 := newCoinCache(defaultCoinCacheAccounts, defaultCoinCacheSize)
+	c2.account(kc.Scan)
+	if c2.accounts.Keys()[0] == c.accounts.Keys()[0] {
+		t.Fatal("expected cache keys to be salted")
+	}
+}
+
+func TestPendingLedgerTx(t *testing.T) {
+	s := NewBareServer(chaincfg.RegressionNetParams)
+	s.ledgerTx = &ledger.TxContext{}
+	s.ledgerTxTime = time.Now()
+	if s.pendingLedgerTx() == nil {
+		t.Fatal("expected pending ledger tx")
+	}
+	s.ledgerTxTime = time.Now().Add(-ledgerTxLifetime - time.Second)
+	if s.pendingLedgerTx() != nil || s.ledgerTx != nil {
+		t.Fatal("expected ledger tx to expire")
+	}
+}
@@ -2,11 +2,14 @@ package mwebd
 
 import (
 	"bytes"
+	"cmp"
 	"context"
+	"crypto/rand"
 	"encoding/hex"
 	"errors"
 	"fmt"
 	"net"
+	"net/http"
 	"net/url"
 	"os"
 	"path/filepath"
@@ -16,7 +19,6 @@ import (
 	"time"
 
 	"github.com/btcsuite/btclog"
-	lru "github.com/hashicorp/golang-lru/v2"
 	"github.com/ltcmweb/ltcd/chaincfg"
 	"github.com/ltcmweb/ltcd/chaincfg/chainhash"
 	"github.com/ltcmweb/ltcd/ltcutil"
@@ -33,23 +35,87 @@ import (
 	_ "github.com/ltcsuite/ltcwallet/walletdb/bdb"
 	"golang.org/x/net/proxy"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 	"gopkg.in/natefinch/lumberjack.v2"
+	"lukechampine.com/blake3"
 )
 
+// mempoolBucket holds the unconfirmed MWEB outputs seen by neutrino.
+var mempoolBucket = []byte("mweb-mempool")
+
+// log is the daemon's logger, which it shares with neutrino. It's
+// disabled until NewServer2 opens the log file.
+var log = btclog.Disabled
+
+// Server implements the RPCs of the daemon. It may also be called
+// in-process, in which case note that the spend secrets of requests
+// are zeroed once used, clearing the caller's buffers. Callers that
+// need to keep a secret must pass a copy.
 type Server struct {
 	proto.UnimplementedRpcServer
 	db        walletdb.DB
+	crypt     dbCrypt
 	cs        *neutrino.ChainService
 	cp        chaincfg.Params
 	mtx       sync.Mutex
 	server    *grpc.Server
-	utxoChan  map[mw.SecretKey]map[*utxoStreamer]struct{}
-	coinCache *lru.Cache[mw.SecretKey, *lru.Cache[chainhash.Hash, *mweb.Coin]]
+	utxoChan  map[chainhash.Hash]map[*utxoStreamer]struct{}
+	coinCache *coinCache
 	ledgerTx  *ledger.TxContext
+
+	// ledgerTxTime is when ledgerTx was started. Both are guarded
+	// by mtx.
+	ledgerTxTime time.Time
+
+	coinswapMtx          sync.Mutex
+	coinswapWake         chan struct{}
+	coinswapBusy         map[string]bool
+	pinnedCoinswapNodes  []*coinswapNode
+	coinswapHopFee       uint64
+	coinswapHTTP         *http.Client
+	coinswapRequireProxy bool
+
+	autoMixMtx   sync.Mutex
+	autoMix      map[chainhash.Hash]*autoMixAccount
+	autoMixUtxos []*wire.MwebNetUtxo
+	autoMixWake  chan struct{}
 }
 
 type ServerArgs struct {
+	// PeerAddr may be a comma separated list of peers.
 	Chain, DataDir, PeerAddr, ProxyAddr string
+
+	// TLSCertFile and TLSKeyFile are the PEM files of the certificate
+	// that the RPC server uses for TLS. Without them, the server
+	// doesn't use TLS.
+	TLSCertFile, TLSKeyFile string
+
+	// LogLevel is the level of the debug log, which is debug if
+	// unset.
+	LogLevel string
+
+	// CoinCacheAccounts and CoinCacheSize are the number of accounts
+	// whose rewound coins are cached, and the number of coins cached
+	// for each. Zero means the default.
+	CoinCacheAccounts, CoinCacheSize int
+
+	// CoinswapNodes pins the coinswap nodes to use, as a comma
+	// separated list of <pubkey>@<url> in route order. This is
+	// needed on test networks, which have no public nodes.
+	CoinswapNodes string
+
+	// CoinswapHopFee is the fee paid to each coinswap node. If zero,
+	// the minimum that the nodes accept is paid.
+	CoinswapHopFee int64
+
+	// CoinswapRequireProxy refuses coinswaps unless ProxyAddr is set,
+	// so that the coinswap nodes never see the user's IP address.
+	CoinswapRequireProxy bool
+
+	// Encrypt encrypts the daemon's own data with a passphrase, which
+	// is set by the first call to the Unlock RPC. An encrypted
+	// database stays encrypted whether or not this is set.
+	Encrypt bool
 }
 
 func NewBareServer(chainParams chaincfg.Params) *Server {
@@ -63,11 +129,42 @@ func NewServer(chain, dataDir, peer string) (*Server, error) {
 }
 
 func NewServer2(args *ServerArgs) (s *Server, err error) {
-	s = &Server{server: grpc.NewServer()}
+	var opts []grpc.ServerOption
+	if args.TLSCertFile != "" || args.TLSKeyFile != "" {
+		creds, err := credentials.NewServerTLSFromFile(
+			args.TLSCertFile, args.TLSKeyFile)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, grpc.Creds(creds))
+	}
+	s = &Server{server: grpc.NewServer(opts...)}
 	proto.RegisterRpcServer(s.server, s)
 
-	s.utxoChan = map[mw.SecretKey]map[*utxoStreamer]struct{}{}
-	s.coinCache, _ = lru.New[mw.SecretKey, *lru.Cache[chainhash.Hash, *mweb.Coin]](10)
+	if args.CoinswapNodes != "" {
+		s.pinnedCoinswapNodes, err = parseCoinswapNodes(args.CoinswapNodes)
+		if err != nil {
+			return
+		}
+	}
+	if args.CoinswapHopFee < 0 {
+		return nil, errors.New("negative coinswap hop fee")
+	}
+	s.coinswapHopFee = uint64(args.CoinswapHopFee)
+	s.coinswapRequireProxy = args.CoinswapRequireProxy
+
+	logLevel, ok := btclog.LevelFromString(cmp.Or(args.LogLevel, "debug"))
+	if !ok {
+		return nil, fmt.Errorf("invalid log level %q", args.LogLevel)
+	}
+
+	s.utxoChan = map[chainhash.Hash]map[*utxoStreamer]struct{}{}
+	if args.CoinCacheAccounts < 0 || args.CoinCacheSize < 0 {
+		return nil, errors.New("negative coin cache size")
+	}
+	s.coinCache = newCoinCache(
+		cmp.Or(args.CoinCacheAccounts, defaultCoinCacheAccounts),
+		cmp.Or(args.CoinCacheSize, defaultCoinCacheSize))
 
 	s.db, err = walletdb.Create(
 		"bdb", filepath.Join(args.DataDir, "neutrino.db"), false, time.Minute)
@@ -88,8 +185,10 @@ func NewServer2(args *ServerArgs) (s *Server, err error) {
 		cfg.ChainParams = chaincfg.RegressionNetParams
 	}
 
-	if args.PeerAddr != "" {
-		cfg.AddPeers = []string{args.PeerAddr}
+	for _, peer := range strings.Split(args.PeerAddr, ",") {
+		if peer = strings.TrimSpace(peer); peer != "" {
+			cfg.AddPeers = append(cfg.AddPeers, peer)
+		}
 	}
 
 	if args.ProxyAddr != "" {
@@ -104,15 +203,16 @@ func NewServer2(args *ServerArgs) (s *Server, err error) {
 		cfg.Dialer = func(addr net.Addr) (net.Conn, error) {
 			return dialer.Dial(addr.Network(), addr.String())
 		}
+		s.coinswapHTTP = proxyHTTPClient(dialer)
 	}
 
-	log := btclog.NewBackend(&lumberjack.Logger{
+	log = btclog.NewBackend(&lumberjack.Logger{
 		Filename:   filepath.Join(args.DataDir, "logs", "debug.log"),
 		MaxSize:    10,
 		MaxBackups: 10,
 		Compress:   true,
 	}).Logger("")
-	log.SetLevel(btclog.LevelDebug)
+	log.SetLevel(logLevel)
 	neutrino.UseLogger(log)
 
 	s.cs, err = neutrino.NewChainService(cfg)
@@ -121,10 +221,38 @@ func NewServer2(args *ServerArgs) (s *Server, err error) {
 	}
 	s.cp = s.cs.ChainParams()
 
+	s.coinswapWake = make(chan struct{}, 1)
+	go s.watchCoinswaps()
+	s.autoMixWake = make(chan struct{}, 1)
+	go s.watchAutoMix()
 	s.cs.RegisterMwebUtxosCallback(s.utxoHandler)
+	if err = s.initCrypt(args.Encrypt); err != nil {
+		return
+	}
+
+	// The utxos that neutrino reports can't be stored until the
+	// database is unlocked, so it isn't started until then.
+	if s.crypt.enabled {
+		return
+	}
 	return s, s.cs.Start()
 }
 
+// proxyHTTPClient returns an HTTP client that makes all of its
+// connections through the proxy.
+func proxyHTTPClient(dialer proxy.Dialer) *http.Client {
+	dial := func(ctx context.Context, network, addr string) (net.Conn, error) {
+		return dialer.Dial(network, addr)
+	}
+	if dialer, ok := dialer.(proxy.ContextDialer); ok {
+		dial = dialer.DialContext
+	}
+	return &http.Client{Transport: &http.Transport{
+		DialContext:       dial,
+		ForceAttemptHTTP2: true,
+	}}
+}
+
 func (s *Server) Start(port int) (int, error) {
 	return s.StartAddr(fmt.Sprintf("127.0.0.1:%d", port))
 }
@@ -155,8 +283,10 @@ func (s *Server) serve(lis net.Listener) error {
 	if err := s.server.Serve(lis); err != nil {
 		return err
 	}
-	if err := s.cs.Stop(); err != nil {
-		return err
+	if !s.dbLocked() {
+		if err := s.cs.Stop(); err != nil {
+			return err
+		}
 	}
 	return s.db.Close()
 }
@@ -195,12 +325,13 @@ func (s *Server) Status(context.Context,
 		MwebHeaderHeight:  int32(mhHeight),
 		MwebUtxosHeight:   int32(lfs.Height),
 		BlockTime:         uint32(bh.Timestamp.Unix()),
+		Locked:            s.dbLocked(),
 	}, nil
 }
 
 func (s *Server) utxoHandler(lfs *mweb.Leafset, utxos []*wire.MwebNetUtxo) {
-	walletdb.Update(s.db, func(tx walletdb.ReadWriteTx) error {
-		bucket, err := tx.CreateTopLevelBucket([]byte("mweb-mempool"))
+	s.update(func(tx walletdb.ReadWriteTx) error {
+		bucket, err := s.writeBucket(tx, mempoolBucket)
 		if err != nil {
 			return err
 		}
@@ -220,6 +351,9 @@ func (s *Server) utxoHandler(lfs *mweb.Leafset, utxos []*wire.MwebNetUtxo) {
 		return nil
 	})
 
+	s.notifyCoinswaps()
+	s.queueAutoMix(utxos)
+
 	var leaves []uint64
 	for _, utxo := range utxos {
 		if utxo.Height > 0 {
@@ -230,10 +364,15 @@ func (s *Server) utxoHandler(lfs *mweb.Leafset, utxos []*wire.MwebNetUtxo) {
 	s.mtx.Lock()
 	defer s.mtx.Unlock()
 
-	for scanSecret, us := range s.utxoChan {
-		utxos := s.filterUtxos(&scanSecret, utxos)
+	// The streamers of an account share its utxos, which are
+	// filtered with the scan secret of any of them.
+	for _, us := range s.utxoChan {
+		var filtered []*proto.Utxo
 		for u := range us {
-			u.notify(lfs, utxos, leaves)
+			if filtered == nil {
+				filtered = s.filterUtxos(u.scan, utxos)
+			}
+			u.notify(lfs, filtered, leaves)
 		}
 	}
 }
@@ -265,21 +404,31 @@ func (s *Server) filterUtxos(scanSecret *mw.SecretKey,
 func (s *Server) Utxos(req *proto.UtxosRequest,
 	stream proto.Rpc_UtxosServer) (err error) {
 
-	scanSecret := (*mw.SecretKey)(req.ScanSecret)
+	var scanSecret *mw.SecretKey
+	if req.AccountDescriptor != "" {
+		d, err := sign.ParseDescriptor(req.AccountDescriptor, &s.cp)
+		if err != nil {
+			return err
+		}
+		scanSecret = d.Scan
+	} else {
+		scanSecret = (*mw.SecretKey)(req.ScanSecret)
+	}
 	u := s.newUtxoStreamer(scanSecret)
+	key := s.coinCache.key(scanSecret)
 	s.mtx.Lock()
-	if s.utxoChan[*scanSecret] == nil {
-		s.utxoChan[*scanSecret] = map[*utxoStreamer]struct{}{}
+	if s.utxoChan[key] == nil {
+		s.utxoChan[key] = map[*utxoStreamer]struct{}{}
 	}
-	s.utxoChan[*scanSecret][u] = struct{}{}
+	s.utxoChan[key][u] = struct{}{}
 	s.mtx.Unlock()
 
 	defer func() {
 		close(u.quit)
 		s.mtx.Lock()
-		delete(s.utxoChan[*scanSecret], u)
-		if len(s.utxoChan[*scanSecret]) == 0 {
-			delete(s.utxoChan, *scanSecret)
+		delete(s.utxoChan[key], u)
+		if len(s.utxoChan[key]) == 0 {
+			delete(s.utxoChan, key)
 		}
 		s.mtx.Unlock()
 	}()
@@ -303,40 +452,90 @@ func (s *Server) Utxos(req *proto.UtxosRequest,
 	if err != nil {
 		return
 	}
-	for leaves := []uint64{}; leaf < u.lfs.Size; leaf++ {
-		if u.lfs.Contains(leaf) {
+	err = s.fetchLeaves(u.lfs, leaf, func(utxos []*wire.MwebNetUtxo) error {
+		for _, utxo := range s.filterUtxos(scanSecret, utxos) {
+			if err := stream.Send(utxo); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	for ; err == nil; err = stream.Send(<-u.ch) {
+	}
+	return
+}
+
+// fetchLeaves fetches the utxos in the leafset from the given leaf
+// onwards, passing them to fn in batches.
+func (s *Server) fetchLeaves(lfs *mweb.Leafset, leaf uint64,
+	fn func([]*wire.MwebNetUtxo) error) error {
+
+	for leaves := []uint64{}; leaf < lfs.Size; leaf++ {
+		if lfs.Contains(leaf) {
 			leaves = append(leaves, leaf)
 		}
-		if len(leaves) == 1000 || leaf == u.lfs.Size-1 {
+		if len(leaves) == 1000 || leaf == lfs.Size-1 {
 			utxos, err := s.cs.MwebCoinDB.FetchLeaves(leaves)
 			if err != nil {
 				return err
 			}
-			for _, utxo := range s.filterUtxos(scanSecret, utxos) {
-				if err = stream.Send(utxo); err != nil {
-					return err
-				}
+			if err = fn(utxos); err != nil {
+				return err
 			}
 			leaves = leaves[:0]
 		}
 	}
-	for ; err == nil; err = stream.Send(<-u.ch) {
-	}
-	return
+	return nil
 }
 
 func (s *Server) Addresses(ctx context.Context,
 	req *proto.AddressRequest) (*proto.AddressResponse, error) {
 
+	scanSecret, spendPubKey := req.ScanSecret, req.SpendPubkey
+	if req.AccountDescriptor != "" {
+		d, err := sign.ParseDescriptor(req.AccountDescriptor, &s.cp)
+		if err != nil {
+			return nil, err
+		}
+		scanSecret, spendPubKey = d.Scan[:], d.SpendPub[:]
+	}
 	resp := sign.Addresses(&sign.AddressesRequest{
-		Scan:     req.ScanSecret,
-		SpendPub: req.SpendPubkey,
+		Scan:     scanSecret,
+		SpendPub: spendPubKey,
 		From:     req.FromIndex,
 		To:       req.ToIndex,
 	}, &s.cp)
 	return &proto.AddressResponse{Address: resp.Address}, nil
 }
 
+func (s *Server) Keychain(ctx context.Context,
+	req *proto.KeychainRequest) (*proto.KeychainResponse, error) {
+
+	resp, err := sign.Keychain(&sign.KeychainRequest{
+		XPrv:         req.Xprv,
+		Mnemonic:     req.Mnemonic,
+		Passphrase:   req.Passphrase,
+		Account:      req.Account,
+		IncludeSpend: req.IncludeSpendSecret,
+	}, &s.cp)
+	if err != nil {
+		return nil, err
+	}
+	d := &sign.Descriptor{
+		Scan:     (*mw.SecretKey)(resp.Scan),
+		SpendPub: (*mw.PublicKey)(resp.SpendPub),
+	}
+	if resp.Spend != nil {
+		d.Spend = (*mw.SecretKey)(resp.Spend)
+	}
+	return &proto.KeychainResponse{
+		ScanSecret:        resp.Scan,
+		SpendPubkey:       resp.SpendPub,
+		SpendSecret:       resp.Spend,
+		AccountDescriptor: d.String(),
+	}, nil
+}
+
 func Addresses(scanSecret, spendPubKey []byte, i, j int32) string {
 	resp := sign.Addresses(&sign.AddressesRequest{
 		Scan:     scanSecret,
@@ -370,18 +569,21 @@ func (s *Server) fetchCoin(outputId chainhash.Hash) (*wire.MwebOutput, error) {
 		output, err = s.cs.MwebCoinDB.FetchCoin(&outputId)
 	}
 	if err == mwebdb.ErrCoinNotFound {
-		err = walletdb.View(s.db, func(tx walletdb.ReadTx) error {
-			bucket := tx.ReadBucket([]byte("mweb-mempool"))
-			if bucket == nil {
-				return err
+		err = s.view(func(tx walletdb.ReadTx) error {
+			bucket, err := s.readBucket(tx, mempoolBucket)
+			var b []byte
+			if bucket != nil {
+				b, err = bucket.Get(outputId[:])
+				if b == nil && err == nil {
+					slices.Reverse(outputId[:])
+					b, err = bucket.Get(outputId[:])
+				}
 			}
-			b := bucket.Get(outputId[:])
-			if b == nil {
-				slices.Reverse(outputId[:])
-				b = bucket.Get(outputId[:])
+			if err != nil {
+				return err
 			}
 			if b == nil {
-				return err
+				return mwebdb.ErrCoinNotFound
 			}
 			output = &wire.MwebOutput{}
 			return output.Deserialize(bytes.NewReader(b))
@@ -393,12 +595,8 @@ func (s *Server) fetchCoin(outputId chainhash.Hash) (*wire.MwebOutput, error) {
 func (s *Server) rewindOutput(output *wire.MwebOutput,
 	scanSecret *mw.SecretKey) (coin *mweb.Coin, err error) {
 
-	cache, ok := s.coinCache.Get(*scanSecret)
-	if !ok {
-		cache, _ = lru.New[chainhash.Hash, *mweb.Coin](100)
-		s.coinCache.Add(*scanSecret, cache)
-	}
-	coin, ok = cache.Get(*output.Hash())
+	cache := s.coinCache.account(scanSecret)
+	coin, ok := cache.Get(*output.Hash())
 	if !ok {
 		coin, err = mweb.RewindOutput(output, scanSecret)
 		if err == nil {
@@ -412,58 +610,72 @@ func (s *Server) rewindOutput(output *wire.MwebOutput,
 	return
 }
 
-func (s *Server) Create(ctx context.Context,
-	req *proto.CreateRequest) (*proto.CreateResponse, error) {
+// createTemplate is a transaction template as passed to Create,
+// split into its MWEB and non-MWEB parts.
+type createTemplate struct {
+	tx         wire.MsgTx
+	partialTx  *wire.MwebTx
+	txIns      []*wire.TxIn
+	txOuts     []*wire.TxOut
+	pegouts    []*wire.TxOut
+	peginOuts  []*wire.TxOut
+	coins      []*mweb.Coin
+	addrIndex  []uint32
+	recipients []*mweb.Recipient
+	mwebOuts   []*wire.TxOut
+	sumCoins   uint64
+	sumOutputs uint64
+}
 
-	var (
-		tx         wire.MsgTx
-		txIns      []*wire.TxIn
-		pegouts    []*wire.TxOut
-		coins      []*mweb.Coin
-		addrIndex  []uint32
-		recipients []*mweb.Recipient
-		pegin      uint64
-		sumCoins   uint64
-		sumOutputs uint64
-	)
+func (s *Server) parseTemplate(rawTx []byte,
+	scanSecret *mw.SecretKey) (*createTemplate, error) {
 
-	err := tx.Deserialize(bytes.NewReader(req.RawTx))
+	t := &createTemplate{}
+	err := t.tx.Deserialize(bytes.NewReader(rawTx))
 	if err != nil {
 		return nil, err
 	}
 
-	keychain := &mweb.Keychain{
-		Scan:  (*mw.SecretKey)(req.ScanSecret),
-		Spend: (*mw.SecretKey)(req.SpendSecret),
+	// A template that already carries an MWEB transaction is
+	// being built by multiple parties. Our inputs, outputs and
+	// kernel get appended to it rather than replacing it.
+	t.partialTx = t.tx.Mweb
+	if t.partialTx != nil {
+		if err = verifyMwebTx(t.partialTx); err != nil {
+			return nil, err
+		}
 	}
 
-	for _, txIn := range tx.TxIn {
+	for _, txIn := range t.tx.TxIn {
 		output, err := s.fetchCoin(txIn.PreviousOutPoint.Hash)
 		switch err {
 		case nil:
-			coin, err := s.rewindOutput(output, keychain.Scan)
+			coin, err := s.rewindOutput(output, scanSecret)
 			if err != nil {
 				return nil, err
 			}
-
-			index := txIn.PreviousOutPoint.Index
-			coin.CalculateOutputKey(keychain.SpendKey(index))
-			coins = append(coins, coin)
-			addrIndex = append(addrIndex, index)
-			sumCoins += coin.Value
+			t.coins = append(t.coins, coin)
+			t.addrIndex = append(t.addrIndex, txIn.PreviousOutPoint.Index)
+			t.sumCoins += coin.Value
 
 		case mwebdb.ErrCoinNotFound:
-			txIns = append(txIns, txIn)
+			t.txIns = append(t.txIns, txIn)
 
 		default:
 			return nil, err
 		}
 	}
 
-	for _, txOut := range tx.TxOut {
-		sumOutputs += uint64(txOut.Value)
+	for _, txOut := range t.tx.TxOut {
+		if t.partialTx != nil && txscript.GetScriptClass(txOut.PkScript) ==
+			txscript.WitnessMwebPeginTy {
+			t.peginOuts = append(t.peginOuts, txOut)
+			continue
+		}
+		t.txOuts = append(t.txOuts, txOut)
+		t.sumOutputs += uint64(txOut.Value)
 		if !txscript.IsMweb(txOut.PkScript) {
-			pegouts = append(pegouts, txOut)
+			t.pegouts = append(t.pegouts, txOut)
 			continue
 		}
 
@@ -472,42 +684,156 @@ func (s *Server) Create(ctx context.Context,
 			return nil, err
 		}
 
-		recipients = append(recipients, &mweb.Recipient{
+		t.recipients = append(t.recipients, &mweb.Recipient{
 			Value:   uint64(txOut.Value),
 			Address: addrs[0].(*ltcutil.AddressMweb).StealthAddress(),
 		})
+		t.mwebOuts = append(t.mwebOuts, txOut)
+	}
+
+	return t, nil
+}
+
+// sweep sets the value of the template's TxOut at index to whatever
+// remains of the MWEB inputs after the other outputs and the fee.
+func (t *createTemplate) sweep(index int, feeRatePerKb uint64) error {
+	if index >= len(t.tx.TxOut) {
+		return errors.New("sweep index out of range")
+	}
+	txOut := t.tx.TxOut[index]
+	if !slices.Contains(t.txOuts, txOut) {
+		return errors.New("cannot sweep to a peg-in output")
+	}
+
+	est := t.estimateFee(feeRatePerKb)
+	fee := est.mwebFee + est.pegoutFee
+	outputs := t.sumOutputs - uint64(txOut.Value)
+	if t.sumCoins <= outputs+fee {
+		return errors.New("insufficient MWEB inputs to sweep")
+	}
+
+	txOut.Value = int64(t.sumCoins - outputs - fee)
+	t.sumOutputs = outputs + uint64(txOut.Value)
+	if i := slices.Index(t.mwebOuts, txOut); i >= 0 {
+		t.recipients[i].Value = uint64(txOut.Value)
+	}
+	return nil
+}
+
+func (t *createTemplate) estimateFee(feeRatePerKb uint64) *feeEstimate {
+	mwebFee := mweb.EstimateFee(t.txOuts, 0, false)
+	pegoutFee := mweb.EstimateFee(t.txOuts,
+		ltcutil.Amount(feeRatePerKb), false) - mwebFee
+	return newFeeEstimate(mwebFee, pegoutFee,
+		t.sumCoins, t.sumOutputs, feeRatePerKb)
+}
+
+func (s *Server) Create(ctx context.Context,
+	req *proto.CreateRequest) (*proto.CreateResponse, error) {
+
+	keychain := &mweb.Keychain{}
+	if req.AccountDescriptor != "" {
+		d, err := sign.ParseDescriptor(req.AccountDescriptor, &s.cp)
+		if err != nil {
+			return nil, err
+		}
+		// A Ledger is used by leaving the raw spend secret unset,
+		// never by passing a watch-only descriptor by mistake.
+		if d.Spend == nil {
+			return nil, errors.New("descriptor is watch-only, " +
+				"a spend secret is required")
+		}
+		keychain.Scan, keychain.Spend = d.Scan, d.Spend
+	} else {
+		keychain.Scan = (*mw.SecretKey)(req.ScanSecret)
+		keychain.Spend = (*mw.SecretKey)(req.SpendSecret)
 	}
+	defer sign.Zero(keychain.Spend)
+
+	t, err := s.parseTemplate(req.RawTx, keychain.Scan)
+	if err != nil {
+		return nil, err
+	}
+	ledgerCoins := false
+	defer func() {
+		if !ledgerCoins {
+			zeroSpendKeys(t.coins)
+		}
+	}()
+
+	var (
+		tx         = &t.tx
+		coins      = t.coins
+		recipients = t.recipients
+		pegouts    = t.pegouts
+	)
 
 	if len(coins) == 0 && len(recipients) == 0 {
 		return &proto.CreateResponse{RawTx: req.RawTx}, nil
 	}
 
-	fee := mweb.EstimateFee(tx.TxOut, ltcutil.Amount(req.FeeRatePerKb), false)
-	if sumOutputs+fee > sumCoins {
-		pegin = sumOutputs + fee - sumCoins
-	} else {
-		fee = sumCoins - sumOutputs
+	for i, coin := range coins {
+		spendKey := keychain.SpendKey(t.addrIndex[i])
+		coin.CalculateOutputKey(spendKey)
+		sign.Zero(spendKey)
+	}
+
+	if req.Sweep {
+		if err = t.sweep(int(req.SweepIndex), req.FeeRatePerKb); err != nil {
+			return nil, err
+		}
+	}
+
+	est := t.estimateFee(req.FeeRatePerKb)
+	fee, pegin := est.fee, est.pegin
+	lockHeight := kernelLockHeight(tx.LockTime)
+
+	switch req.PeginPolicy {
+	case proto.PeginPolicy_PEGIN_FORBID:
+		if pegin > 0 {
+			return nil, fmt.Errorf("peg-in of %d required but forbidden", pegin)
+		}
+	case proto.PeginPolicy_PEGIN_EXACT:
+		if pegin != req.PeginAmount {
+			return nil, fmt.Errorf("peg-in of %d required, expected %d",
+				pegin, req.PeginAmount)
+		}
 	}
 
 	if !req.DryRun {
 		if *keychain.Spend == (mw.SecretKey{}) {
-			if s.ledgerTx == nil || s.ledgerTx.Tx == nil {
+			s.mtx.Lock()
+			ledgerTx := s.pendingLedgerTx()
+			if ledgerTx == nil || ledgerTx.Tx == nil {
+				ledgerCoins = true
+				s.ledgerTxTime = time.Now()
 				s.ledgerTx = &ledger.TxContext{
 					Coins:      coins,
-					AddrIndex:  addrIndex,
+					AddrIndex:  t.addrIndex,
 					Recipients: recipients,
 					Fee:        fee,
 					Pegin:      pegin,
 					Pegouts:    pegouts,
 				}
+				if lockHeight != nil {
+					s.ledgerTx.LockHeight = *lockHeight
+				}
+				s.mtx.Unlock()
 				return &proto.CreateResponse{}, nil
 			}
-			tx.Mweb = s.ledgerTx.Tx
-			coins = s.ledgerTx.NewCoins
 			s.ledgerTx = nil
+			s.mtx.Unlock()
+			tx.Mweb = ledgerTx.Tx
+			coins = ledgerTx.NewCoins
+			zeroSpendKeys(ledgerTx.Coins)
 		} else {
+			var createFunc mweb.CreateInputsAndKernelFunc
+			if lockHeight != nil {
+				createFunc = createInputsAndKernelFunc(
+					coins, fee, pegin, pegouts, lockHeight, nil)
+			}
 			tx.Mweb, coins, err = mweb.NewTransaction(
-				coins, recipients, fee, pegin, pegouts, nil, nil)
+				coins, recipients, fee, pegin, pegouts, nil, createFunc)
 		}
 		if err != nil {
 			return nil, err
@@ -520,10 +846,15 @@ func (s *Server) Create(ctx context.Context,
 		}
 	}
 
-	tx.TxIn = txIns
-	tx.TxOut = nil
+	kernel := tx.Mweb.TxBody.Kernels[0]
+	if t.partialTx != nil {
+		tx.Mweb = mergeMwebTx(t.partialTx, tx.Mweb)
+	}
+
+	tx.TxIn = t.txIns
+	tx.TxOut = t.peginOuts
 	if pegin > 0 {
-		tx.AddTxOut(mweb.NewPegin(pegin, tx.Mweb.TxBody.Kernels[0].Hash()))
+		tx.AddTxOut(mweb.NewPegin(pegin, kernel.Hash()))
 	}
 
 	var buf bytes.Buffer
@@ -536,22 +867,228 @@ func (s *Server) Create(ctx context.Context,
 		resp.OutputId = append(resp.OutputId, hex.EncodeToString(coin.OutputId[:]))
 	}
 
+	if pegin > 0 {
+		txOut := tx.TxOut[len(tx.TxOut)-1]
+		resp.Pegin = &proto.Pegin{
+			Value:       pegin,
+			PkScript:    txOut.PkScript,
+			OutputIndex: uint32(len(tx.TxOut) - 1),
+		}
+		if !req.DryRun {
+			resp.Pegin.KernelHash = hex.EncodeToString(kernel.Hash()[:])
+		}
+		// The transparent inputs of a template built by multiple
+		// parties may fund the other parties' peg-ins, so they're
+		// only known to fund ours when there are no other parties.
+		if t.partialTx == nil {
+			for _, txIn := range tx.TxIn {
+				resp.Pegin.FundingInput = append(resp.Pegin.FundingInput,
+					txIn.PreviousOutPoint.String())
+			}
+		}
+	}
+
 	return resp, nil
 }
 
+// kernelLockHeight interprets a transaction lock time as a kernel
+// lock height. Lock times that are unset or are timestamps return nil.
+func kernelLockHeight(lockTime uint32) *int32 {
+	if lockTime == 0 || lockTime >= txscript.LockTimeThreshold {
+		return nil
+	}
+	lockHeight := int32(lockTime)
+	return &lockHeight
+}
+
+// createInputsAndKernelFunc does the same as the default used by
+// mweb.NewTransaction, except that the kernel has a lock height, as
+// mweb.NewTransaction has no option for it. It must stay in step with
+// the default, which TestCreateInputsAndKernelFunc checks, and should
+// be removed once mweb.NewTransaction takes a lock height.
+func createInputsAndKernelFunc(coins []*mweb.Coin, fee, pegin uint64,
+	pegouts []*wire.TxOut, lockHeight *int32,
+	randFunc mweb.RandFunc) mweb.CreateInputsAndKernelFunc {
+
+	if randFunc == nil {
+		randFunc = func(b []byte) error {
+			_, err := rand.Read(b)
+			return err
+		}
+	}
+	return func(outputKey *mw.SecretKey, kernelBlind *mw.BlindingFactor) (
+		inputs []*wire.MwebInput, kernel *wire.MwebKernel,
+		stealthOffset *mw.BlindingFactor, err error) {
+
+		var inputKey, ephemeralKey mw.SecretKey
+		for _, coin := range coins {
+			if err = randFunc(ephemeralKey[:]); err != nil {
+				return
+			}
+			inputs = append(inputs, mweb.CreateInput(coin, &ephemeralKey))
+			inputKey = *inputKey.Add(&ephemeralKey).Sub(coin.SpendKey)
+		}
+
+		var stealthBlind mw.BlindingFactor
+		if err = randFunc(stealthBlind[:]); err != nil {
+			return
+		}
+		kernel = mweb.CreateKernel(kernelBlind, &stealthBlind,
+			&fee, &pegin, pegouts, lockHeight)
+		stealthOffset = (*mw.BlindingFactor)(outputKey.Add(&inputKey)).Sub(&stealthBlind)
+		return
+	}
+}
+
+// verifyMwebTx checks an MWEB transaction built by another party
+// before it's merged with ours: every signature and range proof must
+// verify, and the transaction must satisfy the kernel and stealth sums
+// on its own.
+func verifyMwebTx(tx *wire.MwebTx) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = errors.New("invalid mweb tx")
+		}
+	}()
+
+	if tx.TxBody == nil || len(tx.TxBody.Kernels) == 0 {
+		return errors.New("mweb tx has no kernels")
+	}
+
+	var (
+		zeroBlind     mw.BlindingFactor
+		lhs           []*mw.Commitment
+		rhs           []*mw.Commitment
+		lhsKeys       []*mw.PublicKey
+		rhsKeys       []*mw.PublicKey
+		supplyChange  int64
+		stealthOffset = tx.StealthOffset
+	)
+	if tx.KernelOffset != zeroBlind {
+		rhs = append(rhs, mw.NewCommitment(&tx.KernelOffset, 0))
+	}
+	if stealthOffset != zeroBlind {
+		rhsKeys = append(rhsKeys,
+			(*mw.SecretKey)(&stealthOffset).PubKey())
+	}
+
+	for _, input := range tx.TxBody.Inputs {
+		if input.InputPubKey == nil || !input.VerifySig() {
+			return errors.New("invalid mweb input signature")
+		}
+		rhs = append(rhs, &input.Commitment)
+		lhsKeys = append(lhsKeys, input.InputPubKey)
+		rhsKeys = append(rhsKeys, &input.OutputPubKey)
+	}
+
+	for _, output := range tx.TxBody.Outputs {
+		if output.RangeProof == nil ||
+			output.RangeProofHash != blake3.Sum256(output.RangeProof[:]) {
+			return errors.New("invalid mweb output range proof hash")
+		}
+		var message bytes.Buffer
+		if err := output.Message.Serialize(&message); err != nil {
+			return err
+		}
+		if !output.RangeProof.Verify(output.Commitment, message.Bytes()) {
+			return errors.New("invalid mweb output range proof")
+		}
+		if !output.VerifySig() {
+			return errors.New("invalid mweb output signature")
+		}
+		lhs = append(lhs, &output.Commitment)
+		lhsKeys = append(lhsKeys, &output.SenderPubKey)
+	}
+
+	for _, kernel := range tx.TxBody.Kernels {
+		sigKey := kernel.Excess.PubKey()
+		if kernel.Features&wire.MwebKernelStealthExcessFeatureBit > 0 {
+			h := blake3.New(32, nil)
+			h.Write(sigKey[:])
+			h.Write(kernel.StealthExcess[:])
+			sigKey = sigKey.Mul((*mw.SecretKey)(h.Sum(nil))).
+				Add(&kernel.StealthExcess)
+			rhsKeys = append(rhsKeys, &kernel.StealthExcess)
+		}
+		if !kernel.Signature.Verify(sigKey, kernel.MessageHash()[:]) {
+			return errors.New("invalid mweb kernel signature")
+		}
+		rhs = append(rhs, &kernel.Excess)
+		supplyChange += kernel.SupplyChange()
+	}
+
+	// sum(outputs) - sum(inputs) = sum(rhs) + offset*G + supply*H
+	if supplyChange > 0 {
+		rhs = append(rhs,
+			mw.NewCommitment(&zeroBlind, uint64(supplyChange)))
+	} else if supplyChange < 0 {
+		lhs = append(lhs,
+			mw.NewCommitment(&zeroBlind, uint64(-supplyChange)))
+	}
+	if len(lhs) == 0 || len(rhs) == 0 ||
+		*sumCommits(lhs) != *sumCommits(rhs) {
+		return errors.New("mweb tx kernel sums unbalanced")
+	}
+
+	// sum(sender keys) + sum(input keys) =
+	//   sum(spent output keys) + sum(stealth rhs) + offset*G
+	if len(lhsKeys) == 0 || len(rhsKeys) == 0 ||
+		*sumPubKeys(lhsKeys) != *sumPubKeys(rhsKeys) {
+		return errors.New("mweb tx stealth sums unbalanced")
+	}
+	return nil
+}
+
+func sumCommits(commits []*mw.Commitment) *mw.Commitment {
+	sum := commits[0]
+	for _, c := range commits[1:] {
+		sum = sum.Add(c)
+	}
+	return sum
+}
+
+func sumPubKeys(keys []*mw.PublicKey) *mw.PublicKey {
+	sum := keys[0]
+	for _, k := range keys[1:] {
+		sum = sum.Add(k)
+	}
+	return sum
+}
+
+// mergeMwebTx combines two independently balanced MWEB transactions.
+// Each keeps its own kernel, and since both satisfy the kernel and
+// stealth sums on their own, the offsets can simply be added.
+func mergeMwebTx(tx, tx2 *wire.MwebTx) *wire.MwebTx {
+	txBody := &wire.MwebTxBody{
+		Inputs:  slices.Concat(tx.TxBody.Inputs, tx2.TxBody.Inputs),
+		Outputs: slices.Concat(tx.TxBody.Outputs, tx2.TxBody.Outputs),
+		Kernels: slices.Concat(tx.TxBody.Kernels, tx2.TxBody.Kernels),
+	}
+	txBody.Sort()
+	return &wire.MwebTx{
+		KernelOffset:  *tx.KernelOffset.Add(&tx2.KernelOffset),
+		StealthOffset: *tx.StealthOffset.Add(&tx2.StealthOffset),
+		TxBody:        txBody,
+	}
+}
+
 func (s *Server) LedgerExchange(ctx context.Context,
 	req *proto.LedgerApdu) (*proto.LedgerApdu, error) {
 
-	if s.ledgerTx == nil {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	ledgerTx := s.pendingLedgerTx()
+	if ledgerTx == nil {
 		return nil, errors.New("nil ledger tx")
 	}
-	if err := s.ledgerTx.Process(req.Data); err != nil {
+	if err := ledgerTx.Process(req.Data); err != nil {
 		return nil, err
 	}
-	if s.ledgerTx.Tx != nil {
+	if ledgerTx.Tx != nil {
 		return &proto.LedgerApdu{}, nil
 	}
-	return &proto.LedgerApdu{Data: s.ledgerTx.Request()}, nil
+	return &proto.LedgerApdu{Data: ledgerTx.Request()}, nil
 }
 
 func (s *Server) Broadcast(ctx context.Context,
@@ -561,11 +1098,23 @@ func (s *Server) Broadcast(ctx context.Context,
 	if err := tx.Deserialize(bytes.NewReader(req.RawTx)); err != nil {
 		return nil, err
 	}
+	if tx.Mweb != nil {
+		if err := s.checkLockHeights(tx.Mweb); err != nil {
+			return nil, err
+		}
+	}
 	if err := s.cs.SendTransaction(&tx); err != nil {
 		return nil, err
 	}
 
 	if tx.Mweb != nil {
+		if err := s.putPegouts(&tx); err != nil {
+			return nil, err
+		}
+		if err := s.putPegins(&tx); err != nil {
+			return nil, err
+		}
+
 		var utxos []*wire.MwebNetUtxo
 		for _, output := range tx.Mweb.TxBody.Outputs {
 			utxos = append(utxos, &wire.MwebNetUtxo{
@@ -578,3 +1127,20 @@ func (s *Server) Broadcast(ctx context.Context,
 
 	return &proto.BroadcastResponse{Txid: tx.TxHash().String()}, nil
 }
+
+// checkLockHeights rejects MWEB transactions containing kernels
+// that can't be included in the next block.
+func (s *Server) checkLockHeights(tx *wire.MwebTx) error {
+	_, height, err := s.cs.BlockHeaders.ChainTip()
+	if err != nil {
+		return err
+	}
+	for _, kernel := range tx.TxBody.Kernels {
+		if kernel.Features&wire.MwebKernelHeightLockFeatureBit > 0 &&
+			kernel.LockHeight > int32(height)+1 {
+			return fmt.Errorf("kernel is locked until height %d, "+
+				"current height is %d", kernel.LockHeight, height)
+		}
+	}
+	return nil
+}
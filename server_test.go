@@ -0,0 +1,427 @@
+package mwebd
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	mathrand "math/rand/v2"
+	"slices"
+	"testing"
+
+	"github.com/ltcmweb/ltcd/chaincfg"
+	"github.com/ltcmweb/ltcd/ltcutil"
+	"github.com/ltcmweb/ltcd/ltcutil/mweb"
+	"github.com/ltcmweb/ltcd/ltcutil/mweb/mw"
+	"github.com/ltcmweb/ltcd/txscript"
+	"github.com/ltcmweb/ltcd/wire"
+	"github.com/ltcmweb/mwebd/proto"
+	"github.com/ltcmweb/mwebd/sign"
+)
+
+func randKeychain() *mweb.Keychain {
+	var scan, spend mw.SecretKey
+	rand.Read(scan[:])
+	rand.Read(spend[:])
+	return &mweb.Keychain{Scan: &scan, Spend: &spend}
+}
+
+func checkMwebTxSums(t *testing.T, tx *wire.MwebTx) {
+	if err := verifyMwebTx(tx); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// newPartialTx returns a transaction with an MWEB part built by
+// another party. A template without inputs must carry an MWEB tx
+// in order to be deserializable.
+func newPartialTx(t *testing.T) *wire.MsgTx {
+	kc := randKeychain()
+	mwebTx, _, err := mweb.NewTransaction(nil, []*mweb.Recipient{{
+		Value: 10_000, Address: kc.Address(1),
+	}}, 1000, 11_000, nil, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tx := &wire.MsgTx{Version: 2, Mweb: mwebTx}
+	tx.AddTxOut(mweb.NewPegin(11_000, mwebTx.TxBody.Kernels[0].Hash()))
+	return tx
+}
+
+func serializeTx(t *testing.T, tx *wire.MsgTx) []byte {
+	var buf bytes.Buffer
+	if err := tx.Serialize(&buf); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestCreateMultiParty(t *testing.T) {
+	s := NewBareServer(chaincfg.MainNetParams)
+	rawTx := serializeTx(t, newPartialTx(t))
+
+	for i := 1; i < 3; i++ {
+		var tx wire.MsgTx
+		if err := tx.Deserialize(bytes.NewReader(rawTx)); err != nil {
+			t.Fatal(err)
+		}
+		kc := randKeychain()
+		addr := ltcutil.NewAddressMweb(kc.Address(1), &s.cp)
+		pkScript, err := txscript.PayToAddrScript(addr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		tx.AddTxOut(wire.NewTxOut(int64(10_000*(i+1)), pkScript))
+
+		resp, err := s.Create(context.Background(), &proto.CreateRequest{
+			RawTx:        serializeTx(t, &tx),
+			ScanSecret:   kc.Scan[:],
+			SpendSecret:  kc.Spend[:],
+			FeeRatePerKb: 1000,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		rawTx = resp.RawTx
+	}
+
+	var tx wire.MsgTx
+	if err := tx.Deserialize(bytes.NewReader(rawTx)); err != nil {
+		t.Fatal(err)
+	}
+	if len(tx.Mweb.TxBody.Kernels) != 3 || len(tx.Mweb.TxBody.Outputs) != 3 {
+		t.Fatal("expected 3 kernels and outputs")
+	}
+	if len(tx.TxOut) != 3 {
+		t.Fatal("expected 3 peg-in outputs")
+	}
+	for _, txOut := range tx.TxOut {
+		found := false
+		for _, kernel := range tx.Mweb.TxBody.Kernels {
+			pegin := mweb.NewPegin(kernel.Pegin, kernel.Hash())
+			found = found || bytes.Equal(pegin.PkScript, txOut.PkScript) &&
+				pegin.Value == txOut.Value
+		}
+		if !found {
+			t.Fatal("peg-in output doesn't match a kernel")
+		}
+	}
+	checkMwebTxSums(t, tx.Mweb)
+}
+
+func TestCreateRejectsInvalidPartialTx(t *testing.T) {
+	s := NewBareServer(chaincfg.MainNetParams)
+	kc := randKeychain()
+	for _, tamper := range []func(*wire.MwebTx){
+		func(tx *wire.MwebTx) { tx.TxBody.Kernels[0].Fee++ },
+		func(tx *wire.MwebTx) { tx.TxBody.Outputs[0].Message.MaskedValue++ },
+		func(tx *wire.MwebTx) { tx.KernelOffset[0]++ },
+		func(tx *wire.MwebTx) { tx.StealthOffset[0]++ },
+		func(tx *wire.MwebTx) {
+			tx.TxBody.Outputs = append(tx.TxBody.Outputs,
+				newPartialTx(t).Mweb.TxBody.Outputs...)
+		},
+	} {
+		tx := newPartialTx(t)
+		checkMwebTxSums(t, tx.Mweb)
+		tamper(tx.Mweb)
+		_, err := s.Create(context.Background(), &proto.CreateRequest{
+			RawTx:        serializeTx(t, tx),
+			ScanSecret:   kc.Scan[:],
+			SpendSecret:  kc.Spend[:],
+			FeeRatePerKb: 1000,
+		})
+		if err == nil {
+			t.Fatal("expected an invalid partial tx to be rejected")
+		}
+	}
+}
+
+func TestCreateInputsAndKernelLockHeight(t *testing.T) {
+	if kernelLockHeight(0) != nil || kernelLockHeight(500_000_000) != nil {
+		t.Fatal("expected no lock height")
+	}
+	lockHeight := kernelLockHeight(3_000_000)
+	if lockHeight == nil || *lockHeight != 3_000_000 {
+		t.Fatal("expected lock height")
+	}
+
+	kc := randKeychain()
+	var senderKey mw.SecretKey
+	rand.Read(senderKey[:])
+	output, blind, _ := mweb.CreateOutput(&mweb.Recipient{
+		Value: 5000, Address: kc.Address(2),
+	}, &senderKey)
+	mweb.SignOutput(output, 5000, blind, &senderKey)
+	coin, err := mweb.RewindOutput(output, kc.Scan)
+	if err != nil {
+		t.Fatal(err)
+	}
+	coin.CalculateOutputKey(kc.SpendKey(2))
+
+	coins := []*mweb.Coin{coin}
+	recipients := []*mweb.Recipient{{Value: 8000, Address: kc.Address(0)}}
+	tx, _, err := mweb.NewTransaction(coins, recipients, 1000, 4000, nil, nil,
+		createInputsAndKernelFunc(coins, 1000, 4000, nil, lockHeight, nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	kernel := tx.TxBody.Kernels[0]
+	if kernel.Features&wire.MwebKernelHeightLockFeatureBit == 0 ||
+		kernel.LockHeight != *lockHeight {
+		t.Fatal("kernel lock height not set")
+	}
+	if !tx.TxBody.Inputs[0].VerifySig() {
+		t.Fatal("invalid input signature")
+	}
+	checkMwebTxSums(t, tx)
+}
+
+// Without a lock height, the transaction must be the same as with the
+// default of mweb.NewTransaction, given the same randomness.
+func TestCreateInputsAndKernelFunc(t *testing.T) {
+	kc := randKeychain()
+	var coins []*mweb.Coin
+	for i := range uint32(2) {
+		var senderKey mw.SecretKey
+		rand.Read(senderKey[:])
+		output, blind, _ := mweb.CreateOutput(&mweb.Recipient{
+			Value: 5000, Address: kc.Address(i),
+		}, &senderKey)
+		mweb.SignOutput(output, 5000, blind, &senderKey)
+		coin, err := mweb.RewindOutput(output, kc.Scan)
+		if err != nil {
+			t.Fatal(err)
+		}
+		coin.CalculateOutputKey(kc.SpendKey(i))
+		coins = append(coins, coin)
+	}
+	recipients := []*mweb.Recipient{{Value: 8000, Address: kc.Address(5)}}
+	pegouts := []*wire.TxOut{wire.NewTxOut(1000, []byte{0x51})}
+
+	newRand := func() mweb.RandFunc {
+		r := mathrand.NewChaCha8([32]byte{1})
+		return func(b []byte) error {
+			r.Read(b)
+			return nil
+		}
+	}
+	serialize := func(tx *wire.MwebTx) []byte {
+		return serializeTx(t, &wire.MsgTx{Version: 2, Mweb: tx})
+	}
+
+	tx, _, err := mweb.NewTransaction(coins, recipients,
+		1000, 0, pegouts, newRand(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	randFunc := newRand()
+	tx2, _, err := mweb.NewTransaction(coins, recipients, 1000, 0, pegouts,
+		randFunc, createInputsAndKernelFunc(coins, 1000, 0, pegouts, nil, randFunc))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Range proofs aren't deterministic, and the outputs aren't
+	// created by the function.
+	tx2.TxBody.Outputs = tx.TxBody.Outputs
+	if !bytes.Equal(serialize(tx), serialize(tx2)) {
+		t.Fatal("transaction differs from mweb.NewTransaction's")
+	}
+}
+
+func TestCreateTemplateSweep(t *testing.T) {
+	s := NewBareServer(chaincfg.MainNetParams)
+	addr := ltcutil.NewAddressMweb(randKeychain().Address(0), &s.cp)
+	pkScript, err := txscript.PayToAddrScript(addr)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	newTemplate := func(sumCoins uint64) *createTemplate {
+		t := &createTemplate{sumCoins: sumCoins}
+		t.tx.AddTxOut(wire.NewTxOut(0, pkScript))
+		t.txOuts = t.tx.TxOut
+		t.mwebOuts = t.tx.TxOut
+		t.recipients = []*mweb.Recipient{{Address: addr.StealthAddress()}}
+		return t
+	}
+
+	tmpl := newTemplate(100_000)
+	if err = tmpl.sweep(0, 1000); err != nil {
+		t.Fatal(err)
+	}
+	est := tmpl.estimateFee(1000)
+	if est.pegin != 0 || est.fee != est.mwebFee+est.pegoutFee {
+		t.Fatal("sweep should leave no pegin or excess fee")
+	}
+	if tmpl.recipients[0].Value+est.fee != 100_000 {
+		t.Fatal("recipient doesn't absorb the remainder")
+	}
+
+	if err = newTemplate(100).sweep(0, 1000); err == nil {
+		t.Fatal("expected insufficient inputs error")
+	}
+	if err = newTemplate(100_000).sweep(1, 1000); err == nil {
+		t.Fatal("expected index out of range error")
+	}
+}
+
+func TestCreatePeginPolicy(t *testing.T) {
+	s := NewBareServer(chaincfg.MainNetParams)
+	ctx := context.Background()
+	kc := randKeychain()
+
+	tx := newPartialTx(t)
+	addr := ltcutil.NewAddressMweb(kc.Address(1), &s.cp)
+	pkScript, err := txscript.PayToAddrScript(addr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tx.AddTxOut(wire.NewTxOut(25_000, pkScript))
+
+	req := &proto.CreateRequest{
+		RawTx:        serializeTx(t, tx),
+		ScanSecret:   kc.Scan[:],
+		SpendSecret:  bytes.Clone(kc.Spend[:]),
+		FeeRatePerKb: 1000,
+		PeginPolicy:  proto.PeginPolicy_PEGIN_FORBID,
+	}
+	if _, err = s.Create(ctx, req); err == nil {
+		t.Fatal("expected peg-in to be forbidden")
+	}
+
+	// Create zeroes the spend secret of each request.
+	req.SpendSecret = bytes.Clone(kc.Spend[:])
+	req.PeginPolicy = proto.PeginPolicy_PEGIN_EXACT
+	req.PeginAmount = 25_000
+	if _, err = s.Create(ctx, req); err == nil {
+		t.Fatal("expected peg-in amount mismatch")
+	}
+
+	est, err := s.EstimateFee(ctx, &proto.EstimateFeeRequest{
+		RawTx:        req.RawTx,
+		ScanSecret:   req.ScanSecret,
+		FeeRatePerKb: req.FeeRatePerKb,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.SpendSecret = bytes.Clone(kc.Spend[:])
+	req.PeginAmount = est.Pegin
+	resp, err := s.Create(ctx, req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(req.SpendSecret, make([]byte, len(kc.Spend))) {
+		t.Fatal("expected spend secret to be zeroed")
+	}
+
+	var tx2 wire.MsgTx
+	if err = tx2.Deserialize(bytes.NewReader(resp.RawTx)); err != nil {
+		t.Fatal(err)
+	}
+	pegin := resp.Pegin
+	if pegin == nil || pegin.Value != est.Pegin {
+		t.Fatal("expected peg-in description")
+	}
+	txOut := tx2.TxOut[pegin.OutputIndex]
+	if uint64(txOut.Value) != pegin.Value ||
+		!bytes.Equal(txOut.PkScript, pegin.PkScript) {
+		t.Fatal("peg-in output mismatch")
+	}
+	found := false
+	for _, kernel := range tx2.Mweb.TxBody.Kernels {
+		found = found || hex.EncodeToString(kernel.Hash()[:]) == pegin.KernelHash
+	}
+	if !found {
+		t.Fatal("peg-in kernel not found")
+	}
+}
+
+func TestCreateWatchOnlyDescriptor(t *testing.T) {
+	s := NewBareServer(chaincfg.MainNetParams)
+	kc := randKeychain()
+	d := &sign.Descriptor{Scan: kc.Scan, SpendPub: kc.Spend.PubKey()}
+	_, err := s.Create(context.Background(), &proto.CreateRequest{
+		RawTx:             serializeTx(t, newPartialTx(t)),
+		AccountDescriptor: d.String(),
+		FeeRatePerKb:      1000,
+	})
+	if err == nil {
+		t.Fatal("expected watch-only descriptor to be rejected")
+	}
+}
+
+func TestAddressesDescriptor(t *testing.T) {
+	s := NewBareServer(chaincfg.MainNetParams)
+	kc, err := s.Keychain(context.Background(), &proto.KeychainRequest{
+		Mnemonic: "abandon abandon abandon abandon abandon abandon " +
+			"abandon abandon abandon abandon abandon about",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if kc.SpendSecret != nil {
+		t.Fatal("spend secret wasn't requested")
+	}
+
+	resp, err := s.Addresses(context.Background(), &proto.AddressRequest{
+		FromIndex: 0, ToIndex: 3,
+		ScanSecret: kc.ScanSecret, SpendPubkey: kc.SpendPubkey,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp2, err := s.Addresses(context.Background(), &proto.AddressRequest{
+		FromIndex: 0, ToIndex: 3, AccountDescriptor: kc.AccountDescriptor,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := range resp.Address {
+		if resp.Address[i] != resp2.Address[i] {
+			t.Fatal("address mismatch")
+		}
+	}
+
+	_, err = s.Addresses(context.Background(), &proto.AddressRequest{
+		ToIndex: 3, AccountDescriptor: kc.AccountDescriptor + "x",
+	})
+	if err == nil {
+		t.Fatal("expected invalid descriptor error")
+	}
+}
+
+func TestCreatePeginFundingInput(t *testing.T) {
+	s := newTestServer(t)
+	kc := randKeychain()
+	addr := ltcutil.NewAddressMweb(kc.Address(1), &s.cp)
+	pkScript, err := txscript.PayToAddrScript(addr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	txIn := wire.NewTxIn(&wire.OutPoint{Index: 1}, nil, nil)
+
+	for _, tx := range []*wire.MsgTx{{Version: 2}, newPartialTx(t)} {
+		tx.AddTxIn(txIn)
+		tx.AddTxOut(wire.NewTxOut(25_000, pkScript))
+		resp, err := s.Create(context.Background(), &proto.CreateRequest{
+			RawTx:        serializeTx(t, tx),
+			ScanSecret:   kc.Scan[:],
+			SpendSecret:  bytes.Clone(kc.Spend[:]),
+			FeeRatePerKb: 1000,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		funding := resp.Pegin.FundingInput
+		if tx.Mweb == nil && !slices.Equal(funding,
+			[]string{txIn.PreviousOutPoint.String()}) {
+			t.Fatal("expected the transparent input to fund the peg-in")
+		}
+		if tx.Mweb != nil && len(funding) > 0 {
+			t.Fatal("expected no funding inputs with other parties")
+		}
+	}
+}
@@ -0,0 +1,149 @@
+package sign
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/ltcmweb/ltcd/chaincfg"
+	"github.com/ltcmweb/ltcd/ltcutil/psbt"
+)
+
+// Func identifies a function of the package for Call.
+type Func uint8
+
+const (
+	FuncAddresses Func = iota + 1
+	FuncAddressesPubKeyHash
+	FuncKeychain
+	FuncPsbtGetRecipients
+	FuncPsbtSign
+	FuncPsbtSignPubKeyHash
+	FuncPsbtSignNonMweb
+	FuncPsbtFinalize
+)
+
+// CallError is returned by Call, with a code for FFI clients.
+type CallError struct {
+	Code int32
+	Err  error
+}
+
+const (
+	ErrUnknownFunc int32 = iota + 1
+	ErrUnknownChain
+	ErrDecode
+	ErrFailed
+)
+
+func (e *CallError) Error() string { return e.Err.Error() }
+
+func (e *CallError) Unwrap() error { return e.Err }
+
+// ChainParams returns the parameters of a chain by the names that
+// the daemon accepts.
+func ChainParams(chain string) (*chaincfg.Params, error) {
+	switch chain {
+	case "", "mainnet":
+		return &chaincfg.MainNetParams, nil
+	case "testnet":
+		return &chaincfg.TestNet4Params, nil
+	case "regtest":
+		return &chaincfg.RegressionNetParams, nil
+	}
+	return nil, fmt.Errorf("unknown chain %q", chain)
+}
+
+// Call calls a function with an encoded request message, and returns
+// the encoded response. It's the entry point for FFI clients, which
+// only exchange byte buffers. PSBTs are returned as a Psbt message.
+func Call(fn Func, chain string, b []byte) (resp []byte, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = &CallError{ErrFailed, fmt.Errorf("%v", r)}
+		}
+	}()
+
+	cp, err := ChainParams(chain)
+	if err != nil {
+		return nil, &CallError{ErrUnknownChain, err}
+	}
+
+	var (
+		m      Message
+		result func() (Message, error)
+	)
+	fromPsbt := func(p *psbt.Packet, err error) (Message, error) {
+		if err != nil {
+			return nil, err
+		}
+		var buf bytes.Buffer
+		if err = p.Serialize(&buf); err != nil {
+			return nil, err
+		}
+		return &Psbt{Psbt: buf.Bytes()}, nil
+	}
+
+	switch fn {
+	case FuncAddresses:
+		req := &AddressesRequest{}
+		m = req
+		result = func() (Message, error) {
+			resp := Addresses(req, cp)
+			return &resp, nil
+		}
+	case FuncAddressesPubKeyHash:
+		req := &AddressesPubKeyHashRequest{}
+		m = req
+		result = func() (Message, error) {
+			resp, err := AddressesPubKeyHash(req, cp)
+			return &resp, err
+		}
+	case FuncKeychain:
+		req := &KeychainRequest{}
+		m = req
+		result = func() (Message, error) {
+			resp, err := Keychain(req, cp)
+			return &resp, err
+		}
+	case FuncPsbtGetRecipients:
+		req := &Psbt{}
+		m = req
+		result = func() (Message, error) {
+			resp, err := PsbtGetRecipients(req, cp)
+			return &resp, err
+		}
+	case FuncPsbtSign:
+		req := &PsbtSignRequest{}
+		m = req
+		result = func() (Message, error) { return fromPsbt(PsbtSign(req)) }
+	case FuncPsbtSignPubKeyHash:
+		req := &PsbtSignPubKeyHashRequest{}
+		m = req
+		result = func() (Message, error) { return fromPsbt(PsbtSignPubKeyHash(req)) }
+	case FuncPsbtSignNonMweb:
+		req := &PsbtSignNonMwebRequest{}
+		m = req
+		result = func() (Message, error) { return fromPsbt(PsbtSignNonMweb(req)) }
+	case FuncPsbtFinalize:
+		req := &Psbt{}
+		m = req
+		result = func() (Message, error) { return fromPsbt(PsbtFinalize(req)) }
+	default:
+		return nil, &CallError{ErrUnknownFunc, fmt.Errorf("unknown function %d", fn)}
+	}
+
+	if m, ok := m.(zeroer); ok {
+		defer m.Zero()
+	}
+	if err = Decode(b, m); err != nil {
+		return nil, &CallError{ErrDecode, err}
+	}
+	respMsg, err := result()
+	if err != nil {
+		return nil, &CallError{ErrFailed, err}
+	}
+	if m, ok := respMsg.(zeroer); ok {
+		defer m.Zero()
+	}
+	return Encode(respMsg)
+}
@@ -0,0 +1,48 @@
+package main
+
+// #include <stdlib.h>
+import "C"
+
+import (
+	"errors"
+	"unsafe"
+
+	"github.com/ltcmweb/mwebd/sign"
+)
+
+// MwebSignCall calls a sign function with the request in req, and
+// the chain given as a C string, or NULL for mainnet. It returns
+// zero on success, or one of the sign.Err codes. The response, or
+// the error message if the call failed, is written to a buffer
+// allocated with malloc, which the caller must release with
+// MwebSignFree.
+//
+//export MwebSignCall
+func MwebSignCall(fn uint8, chain, req unsafe.Pointer, reqLen uintptr,
+	resp *unsafe.Pointer, respLen *uintptr) int32 {
+
+	reqBytes := C.GoBytes(req, C.int(reqLen))
+	b, err := sign.Call(sign.Func(fn), C.GoString((*C.char)(chain)), reqBytes)
+	clear(reqBytes)
+	code, b := result(b, err)
+	*resp, *respLen = C.CBytes(b), uintptr(len(b))
+	return code
+}
+
+// MwebSignFree releases a buffer returned by MwebSignCall.
+//
+//export MwebSignFree
+func MwebSignFree(p unsafe.Pointer) {
+	C.free(p)
+}
+
+// result returns the code and the buffer to return for a call,
+// which is the error message if it failed.
+func result(resp []byte, err error) (int32, []byte) {
+	if err == nil {
+		return 0, resp
+	}
+	callErr := &sign.CallError{Code: sign.ErrFailed, Err: err}
+	errors.As(err, &callErr)
+	return callErr.Code, []byte(err.Error())
+}
@@ -0,0 +1,105 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+	"unsafe"
+
+	"github.com/ltcmweb/ltcd/chaincfg"
+	"github.com/ltcmweb/mwebd/sign"
+)
+
+func call(t *testing.T, fn sign.Func, chain string,
+	req []byte) (code int32, resp []byte) {
+
+	var chainPtr unsafe.Pointer
+	if chain != "" {
+		chainPtr = unsafe.Pointer(unsafe.StringData(chain + "\x00"))
+	}
+	var (
+		respPtr unsafe.Pointer
+		respLen uintptr
+	)
+	code = MwebSignCall(uint8(fn), chainPtr, unsafe.Pointer(unsafe.SliceData(req)),
+		uintptr(len(req)), &respPtr, &respLen)
+	if respPtr == nil {
+		t.Fatal("no response buffer")
+	}
+	resp = bytes.Clone(unsafe.Slice((*byte)(respPtr), respLen))
+	MwebSignFree(respPtr)
+	return
+}
+
+func TestMwebSignCall(t *testing.T) {
+	req, err := sign.Encode(&sign.KeychainRequest{
+		Mnemonic: "abandon abandon abandon abandon abandon abandon " +
+			"abandon abandon abandon abandon abandon about",
+		IncludeSpend: true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	code, b := call(t, sign.FuncKeychain, "testnet", req)
+	if code != 0 {
+		t.Fatal("unexpected code", code, string(b))
+	}
+	var kc sign.KeychainResponse
+	if err = sign.Decode(b, &kc); err != nil {
+		t.Fatal(err)
+	}
+	want, err := sign.Keychain(&sign.KeychainRequest{
+		Mnemonic: "abandon abandon abandon abandon abandon abandon " +
+			"abandon abandon abandon abandon abandon about",
+		IncludeSpend: true,
+	}, &chaincfg.TestNet4Params)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(kc.Scan, want.Scan) || !bytes.Equal(kc.Spend, want.Spend) {
+		t.Fatal("keychain mismatch")
+	}
+
+	req, err = sign.Encode(&sign.AddressesRequest{
+		Scan: kc.Scan, SpendPub: kc.SpendPub, From: 0, To: 2,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	code, b = call(t, sign.FuncAddresses, "", req)
+	if code != 0 {
+		t.Fatal("unexpected code", code, string(b))
+	}
+	var addrs sign.AddressesResponse
+	if err = sign.Decode(b, &addrs); err != nil {
+		t.Fatal(err)
+	}
+	wantAddrs := sign.Addresses(&sign.AddressesRequest{
+		Scan: kc.Scan, SpendPub: kc.SpendPub, From: 0, To: 2,
+	}, &chaincfg.MainNetParams)
+	if len(addrs.Address) != 2 || addrs.Address[1] != wantAddrs.Address[1] {
+		t.Fatal("addresses mismatch", addrs.Address)
+	}
+
+	badPsbt, _ := sign.Encode(&sign.PsbtSignRequest{Psbt: []byte("psbt")})
+	for _, test := range []struct {
+		fn    sign.Func
+		chain string
+		req   []byte
+		code  int32
+	}{
+		{0, "", req, sign.ErrUnknownFunc},
+		{sign.FuncAddresses, "simnet", req, sign.ErrUnknownChain},
+		{sign.FuncAddresses, "", req[:len(req)-1], sign.ErrDecode},
+		{sign.FuncPsbtSign, "", []byte{}, sign.ErrDecode},
+		{sign.FuncPsbtSign, "", badPsbt, sign.ErrFailed},
+	} {
+		code, b = call(t, test.fn, test.chain, test.req)
+		if code != test.code || len(b) == 0 {
+			t.Fatal("unexpected result", code, string(b))
+		}
+	}
+	badKey, _ := sign.Encode(&sign.AddressesRequest{Scan: []byte{1}, To: 1})
+	if code, _ = call(t, sign.FuncAddresses, "", badKey); code != sign.ErrFailed {
+		t.Fatal("panic should be returned as an error", code)
+	}
+}
@@ -0,0 +1,13 @@
+// Command mwebsign exports the sign package as a C shared library:
+//
+//	go build -buildmode=c-shared -o libmwebsign.so ./cmd/mwebsign
+//
+// Each function takes an encoded sign message and returns an encoded
+// response, as in sign.Call.
+//
+// There is no WASM build, as the MWEB range proofs come from
+// libsecp256k1-zkp through cgo, which the js and wasip1 ports of Go
+// don't support.
+package main
+
+func main() {}
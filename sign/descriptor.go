@@ -0,0 +1,247 @@
+package sign
+
+import (
+	"encoding/hex"
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/ltcmweb/ltcd/btcec/v2"
+	"github.com/ltcmweb/ltcd/chaincfg"
+	"github.com/ltcmweb/ltcd/ltcutil/hdkeychain"
+	"github.com/ltcmweb/ltcd/ltcutil/mweb/mw"
+)
+
+// Descriptor is an output descriptor for an MWEB account, of the
+// form mweb(<scan>,<spend>)#<checksum>. The scan key is a hex scan
+// secret, and the spend key is either a hex spend pubkey for
+// watch-only accounts or a hex spend secret. Either key may instead
+// be an extended key followed by a derivation path, such as
+// xprv.../1000h/2h/0h/0h. A single xprv of the account may also be
+// given, from which the scan and spend keys are derived as the 0'
+// and 1' children. The checksum is optional when parsing.
+type Descriptor struct {
+	Scan     *mw.SecretKey
+	SpendPub *mw.PublicKey
+
+	// Spend is nil for watch-only accounts.
+	Spend *mw.SecretKey
+}
+
+// String returns the descriptor with hex keys and a checksum.
+func (d *Descriptor) String() string {
+	spend := d.SpendPub[:]
+	if d.Spend != nil {
+		spend = d.Spend[:]
+	}
+	s := "mweb(" + hex.EncodeToString(d.Scan[:]) + "," +
+		hex.EncodeToString(spend) + ")"
+	checksum, _ := DescriptorChecksum(s)
+	return s + "#" + checksum
+}
+
+// ParseDescriptor parses a descriptor, validating the checksum if
+// one is present.
+func ParseDescriptor(s string, cp *chaincfg.Params) (*Descriptor, error) {
+	if desc, checksum, found := strings.Cut(s, "#"); found {
+		expected, err := DescriptorChecksum(desc)
+		if err != nil {
+			return nil, err
+		}
+		if checksum != expected {
+			return nil, errors.New("invalid descriptor checksum")
+		}
+		s = desc
+	}
+	args, found := strings.CutPrefix(s, "mweb(")
+	if !found {
+		return nil, errors.New("not an MWEB descriptor")
+	}
+	if args, found = strings.CutSuffix(args, ")"); !found {
+		return nil, errors.New("invalid descriptor")
+	}
+
+	var (
+		d    = &Descriptor{}
+		keys = strings.Split(args, ",")
+		err  error
+	)
+	switch len(keys) {
+	case 1:
+		key, err := parseExtendedKey(keys[0], cp)
+		if err != nil {
+			return nil, err
+		}
+		if !key.IsPrivate() {
+			return nil, errors.New("an xprv is required to derive " +
+				"the hardened scan and spend keys")
+		}
+		scan, err := deriveChild(key, "0h")
+		if err != nil {
+			return nil, err
+		}
+		spend, err := deriveChild(key, "1h")
+		if err != nil {
+			return nil, err
+		}
+		if d.Scan, _, err = extendedKeyPair(scan); err != nil {
+			return nil, err
+		}
+		if d.Spend, d.SpendPub, err = extendedKeyPair(spend); err != nil {
+			return nil, err
+		}
+	case 2:
+		if d.Scan, _, err = parseDescriptorKey(keys[0], cp); err != nil {
+			return nil, err
+		}
+		if d.Scan == nil {
+			return nil, errors.New("scan key must be private")
+		}
+		if d.Spend, d.SpendPub, err = parseDescriptorKey(keys[1], cp); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, errors.New("invalid number of descriptor keys")
+	}
+	return d, nil
+}
+
+// parseDescriptorKey parses a hex secret or pubkey, or an extended
+// key with an optional derivation path. The secret is nil if only
+// the pubkey is known.
+func parseDescriptorKey(s string, cp *chaincfg.Params) (
+	*mw.SecretKey, *mw.PublicKey, error) {
+
+	if b, err := hex.DecodeString(s); err == nil {
+		switch len(b) {
+		case len(mw.SecretKey{}):
+			var k btcec.ModNScalar
+			if k.SetByteSlice(b) || k.IsZero() {
+				return nil, nil, errors.New("invalid descriptor secret key")
+			}
+			secret := (*mw.SecretKey)(b)
+			return secret, secret.PubKey(), nil
+		case len(mw.PublicKey{}):
+			if _, err = btcec.ParsePubKey(b); err != nil {
+				return nil, nil, errors.New("invalid descriptor pubkey")
+			}
+			return nil, (*mw.PublicKey)(b), nil
+		}
+		return nil, nil, errors.New("invalid descriptor key length")
+	}
+
+	key, err := parseExtendedKey(s, cp)
+	if err != nil {
+		return nil, nil, err
+	}
+	return extendedKeyPair(key)
+}
+
+// parseExtendedKey parses an extended key and derives the child at
+// the derivation path following it, if any.
+func parseExtendedKey(s string, cp *chaincfg.Params) (
+	*hdkeychain.ExtendedKey, error) {
+
+	path := strings.Split(s, "/")
+	key, err := hdkeychain.NewKeyFromString(path[0])
+	if err != nil {
+		return nil, err
+	}
+	if !key.IsForNet(cp) {
+		return nil, errors.New("extended key is for the wrong network")
+	}
+	for _, elem := range path[1:] {
+		if key, err = deriveChild(key, elem); err != nil {
+			return nil, err
+		}
+	}
+	return key, nil
+}
+
+func extendedKeyPair(key *hdkeychain.ExtendedKey) (
+	*mw.SecretKey, *mw.PublicKey, error) {
+
+	if !key.IsPrivate() {
+		pubKey, err := key.ECPubKey()
+		if err != nil {
+			return nil, nil, err
+		}
+		return nil, (*mw.PublicKey)(pubKey.SerializeCompressed()), nil
+	}
+	privKey, err := key.ECPrivKey()
+	if err != nil {
+		return nil, nil, err
+	}
+	secret := (*mw.SecretKey)(privKey.Serialize())
+	return secret, secret.PubKey(), nil
+}
+
+// deriveChild derives a child from a path element, where a
+// trailing h, H or ' denotes a hardened child.
+func deriveChild(key *hdkeychain.ExtendedKey,
+	elem string) (*hdkeychain.ExtendedKey, error) {
+
+	var offset uint32
+	if n := len(elem); n > 0 && strings.ContainsRune("hH'", rune(elem[n-1])) {
+		elem, offset = elem[:n-1], hdkeychain.HardenedKeyStart
+	}
+	i, err := strconv.ParseUint(elem, 10, 31)
+	if err != nil {
+		return nil, errors.New("invalid derivation path")
+	}
+	return key.Derive(uint32(i) + offset)
+}
+
+const (
+	descriptorInputCharset = "0123456789()[],'/*abcdefgh@:$%{}" +
+		"IJKLMNOPQRSTUVWXYZ&+-.;<=>?!^_|~" +
+		"ijklmnopqrstuvwxyzABCDEFGH`#\"\\ "
+	descriptorChecksumCharset = "qpzry9x8gf2tvdw0s3jn54khce6mua7l"
+)
+
+func descriptorPolymod(c uint64, val int) uint64 {
+	c0 := c >> 35
+	c = (c&0x7ffffffff)<<5 ^ uint64(val)
+	for i, g := range []uint64{
+		0xf5dee51989, 0xa9fdca3312, 0x1bab10e32d, 0x3706b1677a, 0x644d626ffd,
+	} {
+		if c0>>i&1 > 0 {
+			c ^= g
+		}
+	}
+	return c
+}
+
+// DescriptorChecksum computes the BIP380 checksum of a descriptor.
+func DescriptorChecksum(s string) (string, error) {
+	var (
+		c     uint64 = 1
+		class int
+		count int
+	)
+	for _, ch := range s {
+		pos := strings.IndexRune(descriptorInputCharset, ch)
+		if pos < 0 {
+			return "", errors.New("invalid descriptor character")
+		}
+		c = descriptorPolymod(c, pos&31)
+		class = class*3 + pos>>5
+		if count++; count == 3 {
+			c = descriptorPolymod(c, class)
+			class, count = 0, 0
+		}
+	}
+	if count > 0 {
+		c = descriptorPolymod(c, class)
+	}
+	for range 8 {
+		c = descriptorPolymod(c, 0)
+	}
+	c ^= 1
+
+	checksum := make([]byte, 8)
+	for i := range checksum {
+		checksum[i] = descriptorChecksumCharset[c>>(5*(7-i))&31]
+	}
+	return string(checksum), nil
+}
@@ -0,0 +1,121 @@
+package sign
+
+import (
+	"bytes"
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/ltcmweb/ltcd/chaincfg"
+	"github.com/ltcmweb/ltcd/ltcutil/hdkeychain"
+)
+
+func TestDescriptorChecksum(t *testing.T) {
+	checksum, err := DescriptorChecksum("raw(deadbeef)")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if checksum != "89f8spxm" {
+		t.Fatal("unexpected checksum", checksum)
+	}
+	if _, err = DescriptorChecksum("raw(deadbeef)\n"); err == nil {
+		t.Fatal("expected invalid character error")
+	}
+}
+
+func TestParseDescriptor(t *testing.T) {
+	cp := &chaincfg.MainNetParams
+	master, err := hdkeychain.NewMaster(bytes.Repeat([]byte{2}, 32), cp)
+	if err != nil {
+		t.Fatal(err)
+	}
+	account := master
+	for _, i := range []uint32{1000, 2, 0} {
+		if account, err = account.Derive(hdkeychain.HardenedKeyStart + i); err != nil {
+			t.Fatal(err)
+		}
+	}
+	kc, err := Keychain(&KeychainRequest{
+		XPrv: master.String(), IncludeSpend: true,
+	}, cp)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	d, err := ParseDescriptor("mweb("+account.String()+")", cp)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(d.Scan[:], kc.Scan) || !bytes.Equal(d.Spend[:], kc.Spend) ||
+		!bytes.Equal(d.SpendPub[:], kc.SpendPub) {
+		t.Fatal("account xprv descriptor mismatch")
+	}
+
+	d2, err := ParseDescriptor("mweb("+master.String()+
+		"/1000h/2h/0h/0h,"+master.String()+"/1000'/2H/0'/1')", cp)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d2.String() != d.String() {
+		t.Fatal("derived descriptor mismatch")
+	}
+
+	spend, _ := account.Derive(hdkeychain.HardenedKeyStart + 1)
+	spendXpub, _ := spend.Neuter()
+	d2, err = ParseDescriptor("mweb("+hex.EncodeToString(kc.Scan)+","+
+		spendXpub.String()+")", cp)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d2.Spend != nil || !bytes.Equal(d2.SpendPub[:], kc.SpendPub) {
+		t.Fatal("xpub spend key mismatch")
+	}
+
+	watchOnly := "mweb(" + hex.EncodeToString(kc.Scan) + "," +
+		hex.EncodeToString(kc.SpendPub) + ")"
+	d2, err = ParseDescriptor(watchOnly, cp)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := d2.String()
+	if !strings.HasPrefix(s, watchOnly+"#") {
+		t.Fatal("unexpected watch-only descriptor", s)
+	}
+	if d2, err = ParseDescriptor(s, cp); err != nil {
+		t.Fatal(err)
+	}
+	if d2.Spend != nil || *d2.Scan != *d.Scan || *d2.SpendPub != *d.SpendPub {
+		t.Fatal("watch-only round trip mismatch")
+	}
+
+	tampered := []byte(s)
+	tampered[len(tampered)-1] ^= 1
+	accountXpub, _ := account.Neuter()
+	for _, s := range []string{
+		string(tampered),
+		"wpkh(" + hex.EncodeToString(kc.SpendPub) + ")",
+		"mweb(" + accountXpub.String() + ")",
+		"mweb(" + hex.EncodeToString(kc.SpendPub) + "," +
+			hex.EncodeToString(kc.SpendPub) + ")",
+		"mweb(" + hex.EncodeToString(kc.Scan) + ")",
+		"mweb(" + account.String() + "/x)",
+		"mweb(" + account.String() + "/0hh)",
+		"mweb(" + strings.Repeat("ff", 32) + "," +
+			hex.EncodeToString(kc.SpendPub) + ")",
+		"mweb(" + strings.Repeat("00", 32) + "," +
+			hex.EncodeToString(kc.SpendPub) + ")",
+		"mweb(" + hex.EncodeToString(kc.Scan) + ",02" +
+			strings.Repeat("ff", 32) + ")",
+	} {
+		if _, err = ParseDescriptor(s, cp); err == nil {
+			t.Fatal("expected error for", s)
+		}
+	}
+	if _, err = ParseDescriptor(d.String(), &chaincfg.TestNet4Params); err != nil {
+		t.Fatal("hex descriptors are network independent", err)
+	}
+	if _, err = ParseDescriptor("mweb("+account.String()+")",
+		&chaincfg.TestNet4Params); err == nil {
+		t.Fatal("expected wrong network error")
+	}
+}
@@ -0,0 +1,118 @@
+package sign
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// Messages are exchanged with FFI clients in an envelope of a 4 byte
+// magic, a version byte, a message type byte and the little-endian
+// uint32 length of the body, followed by the body as written by the
+// message's Serialize method.
+//
+// A body must hold exactly the fields of the message, each of them
+// whole, so that a truncated or padded body is never mistaken for a
+// valid one. Any change to the layout of a message increments the
+// version, so that a decoder built against an older layout rejects
+// the newer envelope rather than misreading it.
+//
+// Bodies without an envelope, as written before it was introduced,
+// are still decoded. They're distinguished by their first four bytes,
+// which would be the length of the first field and so never equal
+// the magic.
+var envelopeMagic = [4]byte{'M', 'W', 'S', 'G'}
+
+// EnvelopeVersion is the version of the envelope written by Encode.
+const EnvelopeVersion = 1
+
+const envelopeHeaderLen = len(envelopeMagic) + 2 + 4
+
+type MsgType uint8
+
+const (
+	MsgAddressesRequest MsgType = iota + 1
+	MsgAddressesPubKeyHashRequest
+	MsgAddressesResponse
+	MsgPsbt
+	MsgRecipient
+	MsgPsbtGetRecipientsResponse
+	MsgPsbtSignRequest
+	MsgPsbtSignPubKeyHashRequest
+	MsgPsbtSignNonMwebRequest
+	MsgKeychainRequest
+	MsgKeychainResponse
+)
+
+// Message is implemented by all messages exchanged with FFI clients.
+type Message interface {
+	Serializer
+	Deserialize(io.Reader) error
+	MsgType() MsgType
+}
+
+func (*AddressesRequest) MsgType() MsgType           { return MsgAddressesRequest }
+func (*AddressesPubKeyHashRequest) MsgType() MsgType { return MsgAddressesPubKeyHashRequest }
+func (*AddressesResponse) MsgType() MsgType          { return MsgAddressesResponse }
+func (*Psbt) MsgType() MsgType                       { return MsgPsbt }
+func (*Recipient) MsgType() MsgType                  { return MsgRecipient }
+func (*PsbtGetRecipientsResponse) MsgType() MsgType  { return MsgPsbtGetRecipientsResponse }
+func (*PsbtSignRequest) MsgType() MsgType            { return MsgPsbtSignRequest }
+func (*PsbtSignPubKeyHashRequest) MsgType() MsgType  { return MsgPsbtSignPubKeyHashRequest }
+func (*PsbtSignNonMwebRequest) MsgType() MsgType     { return MsgPsbtSignNonMwebRequest }
+func (*KeychainRequest) MsgType() MsgType            { return MsgKeychainRequest }
+func (*KeychainResponse) MsgType() MsgType           { return MsgKeychainResponse }
+
+// Encode serializes the message in an envelope.
+func Encode(m Message) ([]byte, error) {
+	var body bytes.Buffer
+	if err := m.Serialize(&body); err != nil {
+		return nil, err
+	}
+	b := make([]byte, envelopeHeaderLen, envelopeHeaderLen+body.Len())
+	copy(b, envelopeMagic[:])
+	b[4] = EnvelopeVersion
+	b[5] = byte(m.MsgType())
+	binary.LittleEndian.PutUint32(b[6:], uint32(body.Len()))
+	return append(b, body.Bytes()...), nil
+}
+
+// Decode deserializes a message from an envelope, or from a body
+// without an envelope.
+func Decode(b []byte, m Message) error {
+	if !bytes.HasPrefix(b, envelopeMagic[:]) {
+		return decodeBody(b, m)
+	}
+	if len(b) < envelopeHeaderLen {
+		return errors.New("envelope too short")
+	}
+	if b[4] == 0 || b[4] > EnvelopeVersion {
+		return fmt.Errorf("unsupported envelope version %d", b[4])
+	}
+	if MsgType(b[5]) != m.MsgType() {
+		return fmt.Errorf("unexpected message type %d, expected %d",
+			b[5], m.MsgType())
+	}
+	body := b[envelopeHeaderLen:]
+	if binary.LittleEndian.Uint32(b[6:]) != uint32(len(body)) {
+		return errors.New("envelope length mismatch")
+	}
+	return decodeBody(body, m)
+}
+
+// decodeBody deserializes a body that must hold all of the message's
+// fields and nothing else.
+func decodeBody(body []byte, m Message) error {
+	r := bytes.NewReader(body)
+	if err := m.Deserialize(r); err == io.EOF || err == io.ErrUnexpectedEOF {
+		return errors.New("message body truncated")
+	} else if err != nil {
+		return err
+	}
+	if r.Len() > 0 {
+		return errors.New("message body has trailing bytes")
+	}
+	return nil
+}
@@ -0,0 +1,133 @@
+package sign
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"testing"
+)
+
+func newMessage(t *testing.T, typ MsgType) Message {
+	for _, m := range []Message{
+		&AddressesRequest{}, &AddressesPubKeyHashRequest{},
+		&AddressesResponse{}, &Psbt{}, &Recipient{},
+		&PsbtGetRecipientsResponse{}, &PsbtSignRequest{},
+		&PsbtSignPubKeyHashRequest{}, &PsbtSignNonMwebRequest{},
+		&KeychainRequest{}, &KeychainResponse{},
+	} {
+		if m.MsgType() == typ {
+			return m
+		}
+	}
+	t.Fatal("unknown message type", typ)
+	return nil
+}
+
+func serialize(t *testing.T, m Message) []byte {
+	var buf bytes.Buffer
+	if err := m.Serialize(&buf); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+// The vectors give each message's fields as encoding/json would, so
+// byte fields are base64, along with its encoding in hex.
+func TestEnvelopeVectors(t *testing.T) {
+	b, err := os.ReadFile("testdata/envelope_vectors.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var vectors []struct {
+		Type    MsgType
+		Name    string
+		Message json.RawMessage
+		Legacy  bool
+		Encoded string
+	}
+	if err = json.Unmarshal(b, &vectors); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, v := range vectors {
+		m := newMessage(t, v.Type)
+		if err = json.Unmarshal(v.Message, m); err != nil {
+			t.Fatal(err)
+		}
+		encoded, err := hex.DecodeString(v.Encoded)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var b []byte
+		if v.Legacy {
+			b = serialize(t, m)
+		} else if b, err = Encode(m); err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(b, encoded) {
+			t.Fatal("encoding mismatch for", v.Name, hex.EncodeToString(b))
+		}
+
+		m2 := newMessage(t, v.Type)
+		if err = Decode(encoded, m2); err != nil {
+			t.Fatal(v.Name, err)
+		}
+		if !bytes.Equal(serialize(t, m2), serialize(t, m)) {
+			t.Fatal("decoding mismatch for", v.Name)
+		}
+	}
+}
+
+func TestEnvelopeStrict(t *testing.T) {
+	m := &KeychainRequest{
+		XPrv: "xprv", Mnemonic: "abandon", Account: 1, IncludeSpend: true,
+	}
+	b, err := Encode(m)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Bodies of another layout are rejected rather than misread.
+	b2 := append(bytes.Clone(b), 3, 0, 0, 0, 'n', 'e', 'w')
+	b2[6] += 7
+	var m2 KeychainRequest
+	if err = Decode(b2, &m2); err == nil {
+		t.Fatal("expected error for trailing fields")
+	}
+	b2, err = Encode(&Psbt{Psbt: []byte("xprv")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	b2[5] = byte(MsgKeychainRequest)
+	if err = Decode(b2, &m2); err == nil {
+		t.Fatal("expected error for missing fields")
+	}
+
+	// A field cut short, even if the envelope length matches.
+	b2, err = Encode(&Psbt{Psbt: []byte("psbt")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	b2 = b2[:len(b2)-1]
+	b2[6]--
+	if err = Decode(b2, &Psbt{}); err == nil {
+		t.Fatal("expected error for truncated field")
+	}
+	if err = Decode(b2[envelopeHeaderLen:], &Psbt{}); err == nil {
+		t.Fatal("expected error for truncated legacy body")
+	}
+
+	for _, corrupt := range []func([]byte) []byte{
+		func(b []byte) []byte { b[4] = EnvelopeVersion + 1; return b },
+		func(b []byte) []byte { b[4] = 0; return b },
+		func(b []byte) []byte { b[5] = byte(MsgKeychainResponse); return b },
+		func(b []byte) []byte { return b[:len(b)-1] },
+		func(b []byte) []byte { return append(b, 0) },
+		func(b []byte) []byte { return b[:envelopeHeaderLen-1] },
+	} {
+		if err = Decode(corrupt(bytes.Clone(b)), &m2); err == nil {
+			t.Fatal("expected error for corrupt envelope")
+		}
+	}
+}
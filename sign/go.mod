@@ -5,6 +5,8 @@ go 1.24.0
 require (
 	github.com/ltcmweb/ltcd v0.25.14
 	github.com/ltcmweb/ltcd/btcec/v2 v2.3.3
+	github.com/ltcmweb/ltcd/chaincfg/chainhash v1.0.3
+	github.com/tyler-smith/go-bip39 v1.1.0
 )
 
 require (
@@ -12,7 +14,6 @@ require (
 	github.com/decred/dcrd/crypto/blake256 v1.1.0 // indirect
 	github.com/decred/dcrd/dcrec/secp256k1/v4 v4.4.0 // indirect
 	github.com/klauspost/cpuid/v2 v2.3.0 // indirect
-	github.com/ltcmweb/ltcd/chaincfg/chainhash v1.0.3 // indirect
 	github.com/ltcmweb/secp256k1 v0.1.6 // indirect
 	golang.org/x/crypto v0.48.0 // indirect
 	golang.org/x/sys v0.41.0 // indirect
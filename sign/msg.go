@@ -32,6 +32,38 @@ func (m *AddressesPubKeyHashRequest) Deserialize(r io.Reader) error {
 	return get(r, &m.XPub, &m.From, &m.To)
 }
 
+// KeychainRequest derives the MWEB keychain of an account from a
+// BIP32 root key, given either as an xprv or as a BIP39 mnemonic
+// with an optional passphrase. An xprv at the account level, that
+// is m/1000'/coin'/account', may also be given, in which case the
+// account index must be unset or match the key's. The spend secret
+// is only returned if requested.
+type KeychainRequest struct {
+	XPrv, Mnemonic, Passphrase string
+	Account                    uint32
+	IncludeSpend               bool
+}
+
+func (m *KeychainRequest) Serialize(w io.Writer) error {
+	return put(w, m.XPrv, m.Mnemonic, m.Passphrase, m.Account, m.IncludeSpend)
+}
+
+func (m *KeychainRequest) Deserialize(r io.Reader) error {
+	return get(r, &m.XPrv, &m.Mnemonic, &m.Passphrase, &m.Account, &m.IncludeSpend)
+}
+
+type KeychainResponse struct {
+	Scan, SpendPub, Spend []byte
+}
+
+func (m *KeychainResponse) Serialize(w io.Writer) error {
+	return put(w, m.Scan, m.SpendPub, m.Spend)
+}
+
+func (m *KeychainResponse) Deserialize(r io.Reader) error {
+	return get(r, &m.Scan, &m.SpendPub, &m.Spend)
+}
+
 type AddressesResponse struct {
 	Address []string
 }
@@ -126,6 +158,23 @@ func (m *PsbtSignPubKeyHashRequest) Deserialize(r io.Reader) error {
 	return get(r, &m.Psbt, &m.Key, &m.Index)
 }
 
+// PsbtSignNonMwebRequest signs a non-MWEB input with either a raw
+// private key, or an extended private key from which the key is
+// derived using the BIP32 derivation paths of the input.
+type PsbtSignNonMwebRequest struct {
+	Psbt, Key []byte
+	XPrv      string
+	Index     uint32
+}
+
+func (m *PsbtSignNonMwebRequest) Serialize(w io.Writer) error {
+	return put(w, m.Psbt, m.Key, m.XPrv, m.Index)
+}
+
+func (m *PsbtSignNonMwebRequest) Deserialize(r io.Reader) error {
+	return get(r, &m.Psbt, &m.Key, &m.XPrv, &m.Index)
+}
+
 type CountWriter struct{ Len int }
 
 func (w *CountWriter) Write(p []byte) (int, error) {
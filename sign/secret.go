@@ -0,0 +1,38 @@
+package sign
+
+import "github.com/ltcmweb/ltcd/ltcutil/mweb/mw"
+
+// Zero overwrites secret keys once they're no longer needed. The
+// runtime may have copied a key elsewhere, so this only shortens the
+// time that it stays in memory.
+func Zero(keys ...*mw.SecretKey) {
+	for _, key := range keys {
+		if key != nil {
+			clear(key[:])
+		}
+	}
+}
+
+// zeroer is implemented by messages holding secrets, which Call
+// zeroes once it's done with them.
+type zeroer interface {
+	Zero()
+}
+
+func (m *KeychainResponse) Zero() {
+	clear(m.Scan)
+	clear(m.Spend)
+}
+
+func (m *PsbtSignRequest) Zero() {
+	clear(m.Scan)
+	clear(m.Spend)
+}
+
+func (m *PsbtSignPubKeyHashRequest) Zero() {
+	clear(m.Key)
+}
+
+func (m *PsbtSignNonMwebRequest) Zero() {
+	clear(m.Key)
+}
@@ -2,11 +2,14 @@ package sign
 
 import (
 	"bytes"
+	"encoding/binary"
 	"encoding/hex"
 	"errors"
+	"slices"
 	"strings"
 
 	"github.com/ltcmweb/ltcd/btcec/v2"
+	"github.com/ltcmweb/ltcd/btcec/v2/schnorr"
 	"github.com/ltcmweb/ltcd/chaincfg"
 	"github.com/ltcmweb/ltcd/ltcutil"
 	"github.com/ltcmweb/ltcd/ltcutil/hdkeychain"
@@ -15,6 +18,7 @@ import (
 	"github.com/ltcmweb/ltcd/ltcutil/psbt"
 	"github.com/ltcmweb/ltcd/txscript"
 	"github.com/ltcmweb/ltcd/wire"
+	"github.com/tyler-smith/go-bip39"
 )
 
 func Addresses(req *AddressesRequest,
@@ -50,6 +54,104 @@ func AddressesPubKeyHash(req *AddressesPubKeyHashRequest,
 	return
 }
 
+// Keychain derives the scan and spend keys of an account, at
+// m/1000'/coin'/account'/0' and m/1000'/coin'/account'/1'. Both are
+// hardened children, so they can't be derived from an xpub.
+func Keychain(req *KeychainRequest,
+	cp *chaincfg.Params) (resp KeychainResponse, err error) {
+
+	var key *hdkeychain.ExtendedKey
+	switch {
+	case req.XPrv != "" && req.Mnemonic != "":
+		return resp, errors.New("specify either an xprv or a mnemonic")
+	case req.XPrv != "":
+		if key, err = hdkeychain.NewKeyFromString(req.XPrv); err != nil {
+			return
+		}
+		if !key.IsPrivate() {
+			return resp, errors.New("an xprv is required to derive " +
+				"the hardened scan and spend keys")
+		}
+	case req.Mnemonic != "":
+		seed, err := bip39.NewSeedWithErrorChecking(req.Mnemonic, req.Passphrase)
+		if err != nil {
+			return resp, err
+		}
+		key, err = hdkeychain.NewMaster(seed, cp)
+		clear(seed)
+		if err != nil {
+			return resp, err
+		}
+	default:
+		return resp, errors.New("xprv or mnemonic required")
+	}
+
+	var path []uint32
+	switch key.Depth() {
+	case 0:
+		path = []uint32{
+			hdkeychain.HardenedKeyStart + 1000,
+			hdkeychain.HardenedKeyStart + cp.HDCoinType,
+			hdkeychain.HardenedKeyStart + req.Account,
+		}
+	case 3:
+		// The account is that of the key, so a requested account
+		// can only be checked against it.
+		index := key.ChildIndex()
+		if index < hdkeychain.HardenedKeyStart {
+			return resp, errors.New("account key must be a hardened child")
+		}
+		if req.Account != 0 && index != hdkeychain.HardenedKeyStart+req.Account {
+			return resp, errors.New("xprv is not the key of the requested account")
+		}
+	default:
+		return resp, errors.New("xprv must be a root or account key")
+	}
+	defer func() {
+		if key != nil {
+			key.Zero()
+		}
+	}()
+	for _, i := range path {
+		parent := key
+		key, err = key.Derive(i)
+		parent.Zero()
+		if err != nil {
+			return
+		}
+	}
+
+	childKey := func(i uint32) (*mw.SecretKey, error) {
+		child, err := key.Derive(hdkeychain.HardenedKeyStart + i)
+		if err != nil {
+			return nil, err
+		}
+		defer child.Zero()
+		privKey, err := child.ECPrivKey()
+		if err != nil {
+			return nil, err
+		}
+		defer privKey.Zero()
+		return (*mw.SecretKey)(privKey.Serialize()), nil
+	}
+	scan, err := childKey(0)
+	if err != nil {
+		return
+	}
+	spend, err := childKey(1)
+	if err != nil {
+		return
+	}
+	resp.Scan = scan[:]
+	resp.SpendPub = spend.PubKey()[:]
+	if req.IncludeSpend {
+		resp.Spend = spend[:]
+	} else {
+		Zero(spend)
+	}
+	return
+}
+
 func PsbtGetRecipients(req *Psbt, cp *chaincfg.Params) (
 	resp PsbtGetRecipientsResponse, err error) {
 
@@ -134,6 +236,9 @@ func PsbtSign(req *PsbtSignRequest) (resp *psbt.Packet, err error) {
 		Spend: (*mw.SecretKey)(req.Spend),
 	}
 
+	var keys []*mw.SecretKey
+	defer func() { Zero(keys...) }()
+
 	addrIndex := map[mw.PublicKey]uint32{}
 	for _, pInput := range p.Inputs {
 		if pInput.MwebOutputPubkey != nil && pInput.MwebAddressIndex != nil {
@@ -157,8 +262,11 @@ func PsbtSign(req *PsbtSignRequest) (resp *psbt.Packet, err error) {
 			return nil, nil, errors.New("address mismatch")
 		}
 
+		spendKey := kc.SpendKey(addrIndex[*Ko])
+		outputKey := spendKey.Mul(htOutKey)
+		keys = append(keys, spendKey, outputKey)
 		return (*mw.BlindingFactor)(mw.Hashed(mw.HashTagBlind, t[:])),
-			kc.SpendKey(addrIndex[*Ko]).Mul(htOutKey), nil
+			outputKey, nil
 	}}
 
 	signer, err := psbt.NewSigner(p, inputSigner)
@@ -173,10 +281,28 @@ func PsbtSign(req *PsbtSignRequest) (resp *psbt.Packet, err error) {
 }
 
 func PsbtSignPubKeyHash(req *PsbtSignPubKeyHashRequest) (resp *psbt.Packet, err error) {
+	return PsbtSignNonMweb(&PsbtSignNonMwebRequest{
+		Psbt: req.Psbt, Key: req.Key, Index: req.Index,
+	})
+}
+
+// PsbtSignNonMweb signs a non-MWEB input, detecting the script type
+// from the utxo being spent. P2PKH, P2SH multisig, P2WPKH, P2SH-P2WPKH,
+// P2WSH, P2SH-P2WSH and Taproot key spends are supported. The input is
+// finalized once it has enough signatures, so multisig inputs may
+// need to be combined with other signers' PSBTs first.
+func PsbtSignNonMweb(req *PsbtSignNonMwebRequest) (resp *psbt.Packet, err error) {
 	p, err := psbt.NewFromRawBytes(bytes.NewReader(req.Psbt), false)
 	if err != nil {
 		return
 	}
+	if int(req.Index) >= len(p.Inputs) {
+		return nil, errors.New("input index out of range")
+	}
+	pInput := p.Inputs[req.Index]
+	if pInput.MwebOutputId != nil {
+		return nil, errors.New("input is an MWEB input")
+	}
 
 	tx, err := psbt.ExtractUnsignedTx(p)
 	if err != nil {
@@ -187,28 +313,109 @@ func PsbtSignPubKeyHash(req *PsbtSignPubKeyHashRequest) (resp *psbt.Packet, err
 	fetcher := txscript.NewMultiPrevOutFetcher(nil)
 	for i, pInput := range p.Inputs {
 		if pInput.MwebOutputId == nil {
+			txOut, err := prevOut(pInput)
+			if err != nil {
+				return nil, err
+			}
 			op := wire.NewOutPoint(pInput.PrevoutHash, *pInput.PrevoutIndex)
-			fetcher.AddPrevOut(*op, pInput.WitnessUtxo)
+			fetcher.AddPrevOut(*op, txOut)
 			if i < int(req.Index) {
 				txInIdx++
 			}
 		}
 	}
 
-	txOut := p.Inputs[req.Index].WitnessUtxo
-	key, pub := btcec.PrivKeyFromBytes(req.Key)
-	sig, err := txscript.RawTxInWitnessSignature(tx,
-		txscript.NewTxSigHashes(tx, fetcher), txInIdx,
-		txOut.Value, txOut.PkScript, txscript.SigHashAll, key)
+	key, err := signingKey(req, pInput)
 	if err != nil {
 		return
 	}
+	defer key.Zero()
+	pub := key.PubKey().SerializeCompressed()
+
+	txOut, _ := prevOut(pInput)
+	pkScript := txOut.PkScript
+	sigHashes := txscript.NewTxSigHashes(tx, fetcher)
+
+	if txscript.IsPayToTaproot(pkScript) {
+		pInput.TaprootKeySpendSig, err = txscript.RawTxInTaprootSignature(
+			tx, sigHashes, txInIdx, txOut.Value, pkScript,
+			pInput.TaprootMerkleRoot, txscript.SigHashDefault, key)
+		if err != nil {
+			return
+		}
+		return p, psbt.Finalize(p, int(req.Index))
+	}
+
+	// Work out the script that is signed, adding the redeem script
+	// of P2SH-P2WPKH inputs if it's missing from the PSBT.
+	script := pkScript
+	if txscript.IsPayToScriptHash(pkScript) {
+		if pInput.RedeemScript == nil {
+			redeemScript, err := txscript.NewScriptBuilder().AddOp(txscript.OP_0).
+				AddData(ltcutil.Hash160(pub)).Script()
+			if err != nil {
+				return nil, err
+			}
+			if !bytes.Equal(pkScript, payToScriptHash(redeemScript)) {
+				return nil, errors.New("missing redeem script")
+			}
+			pInput.RedeemScript = redeemScript
+		}
+		script = pInput.RedeemScript
+	}
+	if txscript.IsPayToWitnessScriptHash(script) {
+		if pInput.WitnessScript == nil {
+			return nil, errors.New("missing witness script")
+		}
+		script = pInput.WitnessScript
+	}
+
+	legacyMultisig := txscript.IsPayToScriptHash(pkScript) &&
+		txscript.GetScriptClass(pInput.RedeemScript) == txscript.MultiSigTy
 
-	u := psbt.Updater{Upsbt: p}
-	_, err = u.Sign(int(req.Index), sig, pub.SerializeCompressed(), nil, nil)
+	var sig []byte
+	if txscript.IsWitnessProgram(pkScript) || len(pInput.RedeemScript) > 0 &&
+		txscript.IsWitnessProgram(pInput.RedeemScript) {
+
+		sig, err = txscript.RawTxInWitnessSignature(tx, sigHashes, txInIdx,
+			txOut.Value, script, txscript.SigHashAll, key)
+	} else if txscript.IsPayToPubKeyHash(pkScript) || legacyMultisig {
+		sig, err = txscript.RawTxInSignature(
+			tx, txInIdx, script, txscript.SigHashAll, key)
+	} else {
+		err = errors.New("unsupported script type")
+	}
 	if err != nil {
 		return
 	}
+
+	// Legacy inputs are finalized here, as the psbt package can only
+	// finalize them in version 0 PSBTs.
+	if txscript.IsPayToPubKeyHash(pkScript) {
+		pInput.FinalScriptSig, err = txscript.NewScriptBuilder().
+			AddData(sig).AddData(pub).Script()
+		pInput.PartialSigs = nil
+		return p, err
+	}
+
+	if !slices.ContainsFunc(pInput.PartialSigs, func(ps *psbt.PartialSig) bool {
+		return bytes.Equal(ps.PubKey, pub)
+	}) {
+		pInput.PartialSigs = append(pInput.PartialSigs,
+			&psbt.PartialSig{PubKey: pub, Signature: sig})
+	}
+
+	// As are legacy multisig inputs, once they have enough signatures.
+	if legacyMultisig {
+		return p, finalizeLegacyMultisig(pInput)
+	}
+
+	if pInput.WitnessScript != nil {
+		_, numSigs, err := txscript.CalcMultiSigStats(pInput.WitnessScript)
+		if err != nil || len(pInput.PartialSigs) < numSigs {
+			return p, nil
+		}
+	}
 	if err = psbt.Finalize(p, int(req.Index)); err != nil {
 		return
 	}
@@ -216,6 +423,134 @@ func PsbtSignPubKeyHash(req *PsbtSignPubKeyHashRequest) (resp *psbt.Packet, err
 	return p, nil
 }
 
+// prevOut returns the output spent by a non-MWEB input. A full
+// previous transaction must be the one that the input spends from,
+// or its outputs could be made up.
+func prevOut(pInput *psbt.PInput) (*wire.TxOut, error) {
+	switch {
+	case pInput.WitnessUtxo != nil:
+		return pInput.WitnessUtxo, nil
+	case pInput.NonWitnessUtxo != nil && pInput.PrevoutIndex != nil &&
+		int(*pInput.PrevoutIndex) < len(pInput.NonWitnessUtxo.TxOut):
+		if pInput.PrevoutHash == nil ||
+			pInput.NonWitnessUtxo.TxHash() != *pInput.PrevoutHash {
+			return nil, errors.New("non-witness utxo doesn't match the input")
+		}
+		return pInput.NonWitnessUtxo.TxOut[*pInput.PrevoutIndex], nil
+	}
+	return nil, errors.New("missing utxo for input")
+}
+
+// finalizeLegacyMultisig sets the script sig of a P2SH multisig input
+// once it has enough signatures, which must be in the order of their
+// keys in the redeem script.
+func finalizeLegacyMultisig(pInput *psbt.PInput) error {
+	_, numSigs, err := txscript.CalcMultiSigStats(pInput.RedeemScript)
+	if err != nil {
+		return err
+	}
+	pubKeys, err := txscript.PushedData(pInput.RedeemScript)
+	if err != nil {
+		return err
+	}
+
+	var sigs [][]byte
+	for _, pub := range pubKeys {
+		i := slices.IndexFunc(pInput.PartialSigs, func(ps *psbt.PartialSig) bool {
+			return bytes.Equal(ps.PubKey, pub)
+		})
+		if i >= 0 && len(sigs) < numSigs {
+			sigs = append(sigs, pInput.PartialSigs[i].Signature)
+		}
+	}
+	if len(sigs) < numSigs {
+		return nil
+	}
+
+	b := txscript.NewScriptBuilder().AddOp(txscript.OP_0)
+	for _, sig := range sigs {
+		b.AddData(sig)
+	}
+	pInput.FinalScriptSig, err = b.AddData(pInput.RedeemScript).Script()
+	pInput.PartialSigs = nil
+	return err
+}
+
+func payToScriptHash(script []byte) []byte {
+	pkScript, _ := txscript.NewScriptBuilder().AddOp(txscript.OP_HASH160).
+		AddData(ltcutil.Hash160(script)).AddOp(txscript.OP_EQUAL).Script()
+	return pkScript
+}
+
+// signingKey returns the raw key of the request, or derives it from
+// the extended key using the input's BIP32 derivation paths, which
+// must start at the master key.
+func signingKey(req *PsbtSignNonMwebRequest,
+	pInput *psbt.PInput) (*btcec.PrivateKey, error) {
+
+	if req.XPrv == "" {
+		key, _ := btcec.PrivKeyFromBytes(req.Key)
+		return key, nil
+	}
+
+	master, err := hdkeychain.NewKeyFromString(req.XPrv)
+	if err != nil {
+		return nil, err
+	}
+	defer master.Zero()
+	masterPub, err := master.ECPubKey()
+	if err != nil {
+		return nil, err
+	}
+	fingerprint := binary.LittleEndian.Uint32(
+		ltcutil.Hash160(masterPub.SerializeCompressed()))
+
+	derive := func(path []uint32) (*btcec.PrivateKey, error) {
+		key := master
+		for _, i := range path {
+			parent := key
+			if key, err = key.Derive(i); err != nil {
+				return nil, err
+			}
+			if parent != master {
+				parent.Zero()
+			}
+		}
+		if key != master {
+			defer key.Zero()
+		}
+		return key.ECPrivKey()
+	}
+
+	for _, d := range pInput.Bip32Derivation {
+		if d.MasterKeyFingerprint != fingerprint {
+			continue
+		}
+		key, err := derive(d.Bip32Path)
+		if err != nil {
+			return nil, err
+		}
+		if bytes.Equal(key.PubKey().SerializeCompressed(), d.PubKey) {
+			return key, nil
+		}
+		key.Zero()
+	}
+	for _, d := range pInput.TaprootBip32Derivation {
+		if d.MasterKeyFingerprint != fingerprint {
+			continue
+		}
+		key, err := derive(d.Bip32Path)
+		if err != nil {
+			return nil, err
+		}
+		if bytes.Equal(schnorr.SerializePubKey(key.PubKey()), d.XOnlyPubKey) {
+			return key, nil
+		}
+		key.Zero()
+	}
+	return nil, errors.New("no matching BIP32 derivation for input")
+}
+
 func PsbtFinalize(req *Psbt) (resp *psbt.Packet, err error) {
 	p, err := psbt.NewFromRawBytes(bytes.NewReader(req.Psbt), false)
 	if err != nil {
@@ -0,0 +1,352 @@
+package sign
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"testing"
+
+	"github.com/ltcmweb/ltcd/btcec/v2"
+	"github.com/ltcmweb/ltcd/chaincfg"
+	"github.com/ltcmweb/ltcd/chaincfg/chainhash"
+	"github.com/ltcmweb/ltcd/ltcutil"
+	"github.com/ltcmweb/ltcd/ltcutil/hdkeychain"
+	"github.com/ltcmweb/ltcd/ltcutil/mweb/mw"
+	"github.com/ltcmweb/ltcd/ltcutil/psbt"
+	"github.com/ltcmweb/ltcd/txscript"
+	"github.com/ltcmweb/ltcd/wire"
+)
+
+func testKey(seed string) *btcec.PrivateKey {
+	h := sha256.Sum256([]byte(seed))
+	key, _ := btcec.PrivKeyFromBytes(h[:])
+	return key
+}
+
+func mustScript(t *testing.T, b *txscript.ScriptBuilder) []byte {
+	script, err := b.Script()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return script
+}
+
+func TestPsbtSignNonMweb(t *testing.T) {
+	var (
+		key1 = testKey("key1")
+		key2 = testKey("key2")
+		pub1 = key1.PubKey().SerializeCompressed()
+		pub2 = key2.PubKey().SerializeCompressed()
+	)
+
+	master, err := hdkeychain.NewMaster(
+		bytes.Repeat([]byte{1}, 32), &chaincfg.MainNetParams)
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := []uint32{
+		hdkeychain.HardenedKeyStart + 84,
+		hdkeychain.HardenedKeyStart + 2,
+		hdkeychain.HardenedKeyStart, 0, 7,
+	}
+	hdKey := master
+	for _, i := range path {
+		if hdKey, err = hdKey.Derive(i); err != nil {
+			t.Fatal(err)
+		}
+	}
+	hdPub, _ := hdKey.ECPubKey()
+	masterPub, _ := master.ECPubKey()
+	fingerprint := ltcutil.Hash160(masterPub.SerializeCompressed())
+
+	p2wpkh := func(pub []byte) []byte {
+		return mustScript(t, txscript.NewScriptBuilder().
+			AddOp(txscript.OP_0).AddData(ltcutil.Hash160(pub)))
+	}
+	multisig := mustScript(t, txscript.NewScriptBuilder().
+		AddOp(txscript.OP_2).AddData(pub1).AddData(pub2).
+		AddOp(txscript.OP_2).AddOp(txscript.OP_CHECKMULTISIG))
+	multisigHash := sha256.Sum256(multisig)
+	p2wsh := mustScript(t, txscript.NewScriptBuilder().
+		AddOp(txscript.OP_0).AddData(multisigHash[:]))
+	taprootKey := txscript.ComputeTaprootKeyNoScript(key1.PubKey())
+	p2tr, err := txscript.PayToTaprootScript(taprootKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p2pkh := mustScript(t, txscript.NewScriptBuilder().
+		AddOp(txscript.OP_DUP).AddOp(txscript.OP_HASH160).
+		AddData(ltcutil.Hash160(pub1)).
+		AddOp(txscript.OP_EQUALVERIFY).AddOp(txscript.OP_CHECKSIG))
+
+	prevTx := wire.NewMsgTx(2)
+	prevTx.AddTxIn(wire.NewTxIn(&wire.OutPoint{}, nil, nil))
+	prevTx.AddTxOut(wire.NewTxOut(60_000, p2pkh))
+	prevTxHash := prevTx.TxHash()
+
+	tests := []struct {
+		name     string
+		pkScript []byte
+		signers  []*PsbtSignNonMwebRequest
+		setup    func(*psbt.PInput)
+	}{{
+		name:     "p2pkh",
+		pkScript: p2pkh,
+		signers:  []*PsbtSignNonMwebRequest{{Key: key1.Serialize()}},
+	}, {
+		name:     "p2pkh non-witness utxo",
+		pkScript: p2pkh,
+		signers:  []*PsbtSignNonMwebRequest{{Key: key1.Serialize()}},
+		setup: func(pInput *psbt.PInput) {
+			pInput.WitnessUtxo = nil
+			pInput.NonWitnessUtxo = prevTx
+			pInput.PrevoutHash = &prevTxHash
+			pInput.PrevoutIndex = new(uint32)
+		},
+	}, {
+		name:     "p2wpkh",
+		pkScript: p2wpkh(pub1),
+		signers:  []*PsbtSignNonMwebRequest{{Key: key1.Serialize()}},
+	}, {
+		name:     "p2sh-p2wpkh",
+		pkScript: payToScriptHash(p2wpkh(pub2)),
+		signers:  []*PsbtSignNonMwebRequest{{Key: key2.Serialize()}},
+	}, {
+		name:     "p2sh multisig",
+		pkScript: payToScriptHash(multisig),
+		signers: []*PsbtSignNonMwebRequest{
+			{Key: key2.Serialize()}, {Key: key1.Serialize()},
+		},
+		setup: func(pInput *psbt.PInput) {
+			pInput.RedeemScript = multisig
+		},
+	}, {
+		name:     "p2wsh multisig",
+		pkScript: p2wsh,
+		signers: []*PsbtSignNonMwebRequest{
+			{Key: key1.Serialize()}, {Key: key2.Serialize()},
+		},
+		setup: func(pInput *psbt.PInput) {
+			pInput.WitnessScript = multisig
+		},
+	}, {
+		name:     "p2sh-p2wsh multisig",
+		pkScript: payToScriptHash(p2wsh),
+		signers: []*PsbtSignNonMwebRequest{
+			{Key: key2.Serialize()}, {Key: key1.Serialize()},
+		},
+		setup: func(pInput *psbt.PInput) {
+			pInput.RedeemScript = p2wsh
+			pInput.WitnessScript = multisig
+		},
+	}, {
+		name:     "p2tr key spend",
+		pkScript: p2tr,
+		signers:  []*PsbtSignNonMwebRequest{{Key: key1.Serialize()}},
+	}, {
+		name:     "bip32 derivation",
+		pkScript: p2wpkh(hdPub.SerializeCompressed()),
+		signers:  []*PsbtSignNonMwebRequest{{XPrv: master.String()}},
+		setup: func(pInput *psbt.PInput) {
+			pInput.Bip32Derivation = []*psbt.Bip32Derivation{{
+				PubKey:               hdPub.SerializeCompressed(),
+				MasterKeyFingerprint: binary.LittleEndian.Uint32(fingerprint),
+				Bip32Path:            path,
+			}}
+		},
+	}}
+
+	p := &psbt.Packet{PsbtVersion: 2, TxVersion: 2}
+	for i, test := range tests {
+		prevHash := chainhash.Hash{byte(i + 1)}
+		prevIndex := uint32(i)
+		pInput := &psbt.PInput{
+			WitnessUtxo:  wire.NewTxOut(int64(60_000+i), test.pkScript),
+			PrevoutHash:  &prevHash,
+			PrevoutIndex: &prevIndex,
+		}
+		if test.setup != nil {
+			test.setup(pInput)
+		}
+		p.Inputs = append(p.Inputs, pInput)
+	}
+	p.Outputs = append(p.Outputs, &psbt.POutput{
+		Amount: 400_000, PKScript: p2wpkh(pub2),
+	})
+
+	for i, test := range tests {
+		for j, req := range test.signers {
+			var buf bytes.Buffer
+			if err = p.Serialize(&buf); err != nil {
+				t.Fatal(err)
+			}
+			req.Psbt = buf.Bytes()
+			req.Index = uint32(i)
+			if p, err = PsbtSignNonMweb(req); err != nil {
+				t.Fatal(test.name, err)
+			}
+			pInput := p.Inputs[i]
+			finalized := pInput.FinalScriptSig != nil ||
+				pInput.FinalScriptWitness != nil
+			if finalized != (j == len(test.signers)-1) {
+				t.Fatal(test.name, "unexpected finalization after signer", j)
+			}
+		}
+	}
+
+	tx, err := psbt.Extract(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fetcher := txscript.NewMultiPrevOutFetcher(nil)
+	for i, pInput := range p.Inputs {
+		txOut, err := prevOut(pInput)
+		if err != nil {
+			t.Fatal(err)
+		}
+		fetcher.AddPrevOut(tx.TxIn[i].PreviousOutPoint, txOut)
+	}
+	sigHashes := txscript.NewTxSigHashes(tx, fetcher)
+	for i, test := range tests {
+		txOut := fetcher.FetchPrevOutput(tx.TxIn[i].PreviousOutPoint)
+		vm, err := txscript.NewEngine(txOut.PkScript, tx, i,
+			txscript.StandardVerifyFlags, nil, sigHashes, txOut.Value, fetcher)
+		if err != nil {
+			t.Fatal(test.name, err)
+		}
+		if err = vm.Execute(); err != nil {
+			t.Fatal(test.name, err)
+		}
+	}
+}
+
+func TestPsbtSignNonMwebErrors(t *testing.T) {
+	key := testKey("key1")
+	p := &psbt.Packet{PsbtVersion: 2, TxVersion: 2}
+	prevHash := chainhash.Hash{1}
+	p.Inputs = append(p.Inputs, &psbt.PInput{
+		WitnessUtxo:  wire.NewTxOut(1000, []byte{txscript.OP_TRUE}),
+		PrevoutHash:  &prevHash,
+		PrevoutIndex: new(uint32),
+	})
+	var buf bytes.Buffer
+	if err := p.Serialize(&buf); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := PsbtSignNonMweb(&PsbtSignNonMwebRequest{
+		Psbt: buf.Bytes(), Key: key.Serialize(),
+	})
+	if err == nil {
+		t.Fatal("expected unsupported script error")
+	}
+	_, err = PsbtSignNonMweb(&PsbtSignNonMwebRequest{
+		Psbt: buf.Bytes(), Key: key.Serialize(), Index: 1,
+	})
+	if err == nil {
+		t.Fatal("expected index out of range error")
+	}
+
+	// A previous transaction that the input doesn't spend from.
+	prevTx := wire.NewMsgTx(2)
+	prevTx.AddTxOut(wire.NewTxOut(1000, []byte{txscript.OP_TRUE}))
+	p.Inputs[0].WitnessUtxo = nil
+	p.Inputs[0].NonWitnessUtxo = prevTx
+	buf.Reset()
+	if err = p.Serialize(&buf); err != nil {
+		t.Fatal(err)
+	}
+	_, err = PsbtSignNonMweb(&PsbtSignNonMwebRequest{
+		Psbt: buf.Bytes(), Key: key.Serialize(),
+	})
+	if err == nil {
+		t.Fatal("expected non-witness utxo mismatch error")
+	}
+
+	master, _ := hdkeychain.NewMaster(
+		bytes.Repeat([]byte{1}, 32), &chaincfg.MainNetParams)
+	_, err = PsbtSignNonMweb(&PsbtSignNonMwebRequest{
+		Psbt: buf.Bytes(), XPrv: master.String(),
+	})
+	if err == nil {
+		t.Fatal("expected no matching derivation error")
+	}
+}
+
+func TestKeychain(t *testing.T) {
+	const mnemonic = "abandon abandon abandon abandon abandon abandon " +
+		"abandon abandon abandon abandon abandon about"
+	seed, _ := hex.DecodeString("c55257c360c07c72029aebc1b53c05ed0362ada" +
+		"38ead3e3e9efa3708e53495531f09a6987599d18264c1e1c92f2cf141630c7a3" +
+		"c4ab7c81b2f001698e7463b04")
+	cp := &chaincfg.MainNetParams
+
+	resp, err := Keychain(&KeychainRequest{
+		Mnemonic: mnemonic, Passphrase: "TREZOR", Account: 1, IncludeSpend: true,
+	}, cp)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	master, err := hdkeychain.NewMaster(seed, cp)
+	if err != nil {
+		t.Fatal(err)
+	}
+	key := master
+	for _, i := range []uint32{1000, 2, 1, 1} {
+		if key, err = key.Derive(hdkeychain.HardenedKeyStart + i); err != nil {
+			t.Fatal(err)
+		}
+	}
+	spend, _ := key.ECPrivKey()
+	if !bytes.Equal(resp.Spend, spend.Serialize()) {
+		t.Fatal("spend secret mismatch")
+	}
+	if !bytes.Equal(resp.SpendPub, (*mw.SecretKey)(resp.Spend).PubKey()[:]) {
+		t.Fatal("spend pubkey mismatch")
+	}
+
+	resp2, err := Keychain(&KeychainRequest{
+		XPrv: master.String(), Account: 1,
+	}, cp)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(resp2.Scan, resp.Scan) ||
+		!bytes.Equal(resp2.SpendPub, resp.SpendPub) || resp2.Spend != nil {
+		t.Fatal("xprv keychain mismatch")
+	}
+
+	account, _ := master.Derive(hdkeychain.HardenedKeyStart + 1000)
+	account, _ = account.Derive(hdkeychain.HardenedKeyStart + 2)
+	account, _ = account.Derive(hdkeychain.HardenedKeyStart + 1)
+	resp2, err = Keychain(&KeychainRequest{XPrv: account.String()}, cp)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(resp2.Scan, resp.Scan) {
+		t.Fatal("account xprv keychain mismatch")
+	}
+	resp2, err = Keychain(&KeychainRequest{XPrv: account.String(), Account: 1}, cp)
+	if err != nil || !bytes.Equal(resp2.Scan, resp.Scan) {
+		t.Fatal("account xprv keychain mismatch")
+	}
+	unhardened, _ := master.Derive(hdkeychain.HardenedKeyStart + 1000)
+	unhardened, _ = unhardened.Derive(hdkeychain.HardenedKeyStart + 2)
+	unhardened, _ = unhardened.Derive(1)
+
+	xpub, _ := master.Neuter()
+	for _, req := range []*KeychainRequest{
+		{XPrv: xpub.String()},
+		{XPrv: account.String(), Account: 2},
+		{XPrv: unhardened.String()},
+		{Mnemonic: mnemonic + " abandon"},
+		{XPrv: master.String(), Mnemonic: mnemonic},
+		{},
+	} {
+		if _, err = Keychain(req, cp); err == nil {
+			t.Fatal("expected error")
+		}
+	}
+}